@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mopemope/git-rovo/internal/changelog"
+	"github.com/mopemope/git-rovo/internal/config"
+	"github.com/mopemope/git-rovo/internal/llm"
+	"github.com/mopemope/git-rovo/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+// polishTimeout bounds the LLM requests that polish a release, one per section
+const polishTimeout = 2 * time.Minute
+
+// newChangelogCommand renders the changelog of a revision range, or updates
+// the changelog file with it
+func newChangelogCommand(opts *options) *cobra.Command {
+	var releaseVersion, releaseDate, file string
+	var polish, update bool
+
+	cmd := &cobra.Command{
+		Use:   "changelog <from>..<to>",
+		Short: "Generate the changelog of a revision range from Conventional Commits",
+		Example: `  git-rovo changelog v1.2.0..HEAD
+  git-rovo changelog v1.2.0..HEAD --version 1.3.0 --polish --update`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := opts.loadConfig()
+			if err != nil {
+				return err
+			}
+			defer func() { _ = logger.Close() }()
+			repo, err := opts.openRepository()
+			if err != nil {
+				return err
+			}
+
+			date, err := parseReleaseDate(releaseDate)
+			if err != nil {
+				return err
+			}
+			release, err := changelog.Generate(repo, args[0], releaseVersion, date, cfg.Changelog)
+			if err != nil {
+				return err
+			}
+			if release.Empty() {
+				return fmt.Errorf("no changelog entries in %s", args[0])
+			}
+
+			if !cmd.Flags().Changed("polish") {
+				polish = cfg.Changelog.Polish
+			}
+			if polish {
+				if err := polishRelease(cmd.Context(), cfg, release); err != nil {
+					return err
+				}
+			}
+
+			text, err := release.RenderWith(cfg.Changelog)
+			if err != nil {
+				return err
+			}
+			if !update {
+				_, err := fmt.Fprint(cmd.OutOrStdout(), text)
+				return err
+			}
+
+			if file == "" {
+				file = cfg.Changelog.File
+			}
+			if err := changelog.UpdateFile(file, release.Version, text); err != nil {
+				return fmt.Errorf("failed to update %s: %w", file, err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Updated %s in %s\n", release.Heading(), file)
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&releaseVersion, "version", "", "version of the release (default Unreleased)")
+	flags.StringVar(&releaseDate, "date", "", "release date as YYYY-MM-DD (default today)")
+	flags.BoolVar(&polish, "polish", false, "rewrite each section as release notes with the LLM (default changelog.polish)")
+	flags.BoolVar(&update, "update", false, "update the changelog file in place instead of printing")
+	flags.StringVar(&file, "file", "", "changelog file to update (default changelog.file)")
+	return cmd
+}
+
+// parseReleaseDate parses a YYYY-MM-DD date, defaulting to today
+func parseReleaseDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Now(), nil
+	}
+	date, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", value)
+	}
+	return date, nil
+}
+
+// polishRelease has the configured LLM provider rewrite the sections of a
+// release as user-facing prose
+func polishRelease(ctx context.Context, cfg *config.Config, release *changelog.Release) error {
+	if err := validateLLM(cfg); err != nil {
+		return err
+	}
+	client, err := newLLMClient(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to initialize LLM client: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	ctx, cancel := context.WithTimeout(ctx, polishTimeout)
+	defer cancel()
+
+	return release.Polish(ctx, client, llm.CommitMessageRequest{
+		Language:    cfg.LLM.Language,
+		MaxTokens:   cfg.LLM.OpenAI.MaxTokens,
+		Temperature: cfg.LLM.OpenAI.Temperature,
+	})
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// commitChanges adds empty commits with the given subjects
+func commitChanges(t *testing.T, test *commandTest, subjects ...string) {
+	t.Helper()
+	for _, subject := range subjects {
+		test.git(t, "commit", "-q", "--allow-empty", "-m", subject)
+	}
+}
+
+func TestChangelogCommand(t *testing.T) {
+	test := setupCommandTest(t)
+	test.git(t, "tag", "v1.0.0")
+	commitChanges(t, test, "feat(cli): add the changelog command", "fix: handle empty ranges", "chore: tidy up")
+
+	output, err := test.run(t, "changelog", "v1.0.0..HEAD")
+	if err != nil {
+		t.Fatalf("changelog failed: %v\n%s", err, output)
+	}
+	for _, want := range []string{"## [Unreleased]", "### Added", "- **cli:** add the changelog command", "### Fixed", "- handle empty ranges"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "tidy up") {
+		t.Errorf("Expected chores to be left out:\n%s", output)
+	}
+
+	if _, err := test.run(t, "changelog", "v1.0.0"); err == nil || !strings.Contains(err.Error(), "expected a range") {
+		t.Errorf("Expected a single revision to be refused, got %v", err)
+	}
+	if _, err := test.run(t, "changelog", "HEAD~1..HEAD"); err == nil || !strings.Contains(err.Error(), "no changelog entries") {
+		t.Errorf("Expected an empty range to be refused, got %v", err)
+	}
+}
+
+func TestChangelogCommandUpdatesFile(t *testing.T) {
+	test := setupCommandTest(t)
+	commitChanges(t, test, "feat: add search")
+	provider := useScriptedLLM(t, "Search the history by author and message.")
+
+	file := filepath.Join(test.dir, "CHANGELOG.md")
+	output, err := test.run(t, "changelog", "HEAD~1..HEAD", "--version", "1.1.0", "--date", "2024-03-01", "--polish", "--update", "--file", file)
+	if err != nil {
+		t.Fatalf("changelog failed: %v\n%s", err, output)
+	}
+	if !strings.Contains(output, "Updated ## [1.1.0] - 2024-03-01") {
+		t.Errorf("Unexpected output %q", output)
+	}
+
+	if len(provider.requests) != 1 || !strings.Contains(provider.requests[0].Diff, "add search") {
+		t.Fatalf("Expected the Added section to be polished, got %+v", provider.requests)
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "## [1.1.0] - 2024-03-01\n\n### Added\n\nSearch the history by author and message.\n") {
+		t.Errorf("Unexpected changelog\n%s", content)
+	}
+
+	if _, err := test.run(t, "changelog", "HEAD~1..HEAD", "--date", "March 1"); err == nil || !strings.Contains(err.Error(), "invalid date") {
+		t.Errorf("Expected an invalid date to be refused, got %v", err)
+	}
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mopemope/git-rovo/internal/config"
+	"github.com/mopemope/git-rovo/internal/git"
+	"github.com/mopemope/git-rovo/internal/llm"
+	"github.com/mopemope/git-rovo/internal/logger"
+	"github.com/mopemope/git-rovo/internal/tui"
+	"github.com/spf13/cobra"
+)
+
+// Set by the Makefile with -ldflags
+var (
+	version = "dev"
+	commit  = "unknown"
+	date    = "unknown"
+)
+
+// newLLMClient creates the LLM client of the configured provider; tests
+// replace it with a scripted provider
+var newLLMClient = llm.CreateClient
+
+// generateTimeout bounds a single LLM request
+const generateTimeout = 30 * time.Second
+
+// options are the global flags shared by every command
+type options struct {
+	configPath string
+	workDir    string
+	logLevel   string
+}
+
+func main() {
+	if err := newRootCommand().Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+// newRootCommand creates the git-rovo command, which starts the TUI
+func newRootCommand() *cobra.Command {
+	opts := &options{}
+	cmd := &cobra.Command{
+		Use:          "git-rovo",
+		Short:        "A Git TUI that writes commit messages with an LLM",
+		SilenceUsage: true,
+		Args:         cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := opts.loadConfig()
+			if err != nil {
+				return err
+			}
+			defer func() { _ = logger.Close() }()
+			if err := validateLLM(cfg); err != nil {
+				return err
+			}
+
+			app, err := tui.NewApp(cfg, opts.workDir)
+			if err != nil {
+				return err
+			}
+			return app.Run()
+		},
+	}
+
+	flags := cmd.PersistentFlags()
+	flags.StringVar(&opts.configPath, "config", "", "configuration file (default ~/.git-rovo.toml or ~/.config/git-rovo/config.toml)")
+	flags.StringVar(&opts.workDir, "work-dir", ".", "Git repository to work in")
+	flags.StringVar(&opts.logLevel, "log-level", "", "log level: debug, info, warn or error")
+
+	cmd.AddCommand(
+		newVersionCommand(),
+		newChangelogCommand(opts),
+		newNextVersionCommand(opts),
+		newUndoCommand(opts),
+		newSquashMessageCommand(opts),
+		newPullRequestCommand(opts),
+	)
+	return cmd
+}
+
+// newVersionCommand prints the version git-rovo was built from
+func newVersionCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "version",
+		Short: "Print the version",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			fmt.Fprintf(cmd.OutOrStdout(), "git-rovo %s (commit %s, built %s)\n", version, commit, date)
+		},
+	}
+}
+
+// loadConfig loads the configuration and starts the logger. The LLM provider
+// settings are checked by validateLLM, so that commands which never call the
+// LLM work without an API key.
+func (o *options) loadConfig() (*config.Config, error) {
+	cfg, err := config.LoadSettings(o.configPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("configuration file %s not found", o.configPath)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if o.logLevel != "" {
+		cfg.Logger.Level = o.logLevel
+	}
+	if err := logger.Init(cfg.Logger.FilePath, cfg.Logger.Level); err != nil {
+		return nil, fmt.Errorf("failed to initialize logger: %w", err)
+	}
+	return cfg, nil
+}
+
+// validateLLM checks the LLM provider settings before a client is created
+func validateLLM(cfg *config.Config) error {
+	if err := cfg.ValidateLLM(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+	return nil
+}
+
+// openRepository opens the repository of the working directory
+func (o *options) openRepository() (*git.Repository, error) {
+	repo, err := git.New(o.workDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open git repository: %w", err)
+	}
+	return repo, nil
+}
+
+// generate sends a request to the configured LLM provider with the
+// configured language and generation settings, and returns the trimmed reply
+func generate(ctx context.Context, cfg *config.Config, request *llm.CommitMessageRequest) (string, error) {
+	if err := validateLLM(cfg); err != nil {
+		return "", err
+	}
+	client, err := newLLMClient(cfg)
+	if err != nil {
+		return "", fmt.Errorf("failed to initialize LLM client: %w", err)
+	}
+	defer func() { _ = client.Close() }()
+
+	request.Language = cfg.LLM.Language
+	request.MaxTokens = cfg.LLM.OpenAI.MaxTokens
+	request.Temperature = cfg.LLM.OpenAI.Temperature
+
+	ctx, cancel := context.WithTimeout(ctx, generateTimeout)
+	defer cancel()
+
+	response, err := client.GenerateCommitMessage(ctx, request, "")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(response.Message), nil
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mopemope/git-rovo/internal/config"
+	"github.com/mopemope/git-rovo/internal/llm"
+)
+
+// commandTest is a repository and configuration to run git-rovo commands in
+type commandTest struct {
+	dir        string
+	configPath string
+}
+
+// setupCommandTest creates a repository with an initial commit and a
+// configuration logging to a temporary file
+func setupCommandTest(t *testing.T) *commandTest {
+	t.Helper()
+
+	test := &commandTest{dir: t.TempDir(), configPath: filepath.Join(t.TempDir(), "config.toml")}
+	test.git(t, "init", "-q", "-b", "main")
+	test.git(t, "config", "user.name", "Test User")
+	test.git(t, "config", "user.email", "test@example.com")
+	test.git(t, "commit", "-q", "--allow-empty", "-m", "Initial commit")
+
+	config := fmt.Sprintf(`[llm]
+provider = "openai"
+
+[llm.openai]
+api_key = "test-key"
+model = "gpt-4o-mini"
+
+[logger]
+file_path = %q
+`, filepath.Join(t.TempDir(), "git-rovo.log"))
+	if err := os.WriteFile(test.configPath, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return test
+}
+
+// git runs a git command in the repository and returns its trimmed output
+func (c *commandTest) git(t *testing.T, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = c.dir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, output)
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// run runs git-rovo with args in the repository and returns its output
+func (c *commandTest) run(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	cmd := newRootCommand()
+	var output bytes.Buffer
+	cmd.SetOut(&output)
+	cmd.SetErr(&output)
+	cmd.SetArgs(append([]string{"--config", c.configPath, "--work-dir", c.dir}, args...))
+	err := cmd.Execute()
+	return output.String(), err
+}
+
+// scriptedLLMProvider returns a fixed response and records the requests
+type scriptedLLMProvider struct {
+	message  string
+	requests []*llm.CommitMessageRequest
+}
+
+func (p *scriptedLLMProvider) GenerateCommitMessage(ctx context.Context, request *llm.CommitMessageRequest) (*llm.CommitMessageResponse, error) {
+	p.requests = append(p.requests, request)
+	return &llm.CommitMessageResponse{Message: p.message, Provider: "scripted"}, nil
+}
+
+func (p *scriptedLLMProvider) GetProviderName() string { return "scripted" }
+
+func (p *scriptedLLMProvider) Close() error { return nil }
+
+// useScriptedLLM makes the commands answer LLM requests with message
+func useScriptedLLM(t *testing.T, message string) *scriptedLLMProvider {
+	t.Helper()
+	provider := &scriptedLLMProvider{message: message}
+	original := newLLMClient
+	newLLMClient = func(cfg *config.Config) (*llm.Client, error) {
+		client := llm.NewClient()
+		if err := client.RegisterProvider("scripted", provider); err != nil {
+			return nil, err
+		}
+		return client, client.SetDefaultProvider("scripted")
+	}
+	t.Cleanup(func() { newLLMClient = original })
+	return provider
+}
+
+func TestVersionCommand(t *testing.T) {
+	test := setupCommandTest(t)
+	output, err := test.run(t, "version")
+	if err != nil || !strings.HasPrefix(output, "git-rovo dev") {
+		t.Errorf("Unexpected version output %q (%v)", output, err)
+	}
+}
+
+func TestCommandsWithoutAPIKey(t *testing.T) {
+	test := setupCommandTest(t)
+	t.Setenv("OPENAI_API_KEY", "")
+	t.Setenv("GIT_ROVO_OPENAI_API_KEY", "")
+	config := fmt.Sprintf("[logger]\nfile_path = %q\n", filepath.Join(t.TempDir(), "git-rovo.log"))
+	if err := os.WriteFile(test.configPath, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	test.git(t, "tag", "v1.0.0")
+	test.git(t, "commit", "-q", "--allow-empty", "-m", "feat: add search")
+
+	// Commands that never call the LLM do not need a key
+	if _, err := test.run(t, "undo"); err == nil || !strings.Contains(err.Error(), "nothing to undo") {
+		t.Errorf("Expected undo to run without a key, got %v", err)
+	}
+	if output, err := test.run(t, "next-version"); err != nil || !strings.Contains(output, "v1.1.0") {
+		t.Errorf("Expected next-version to run without a key, got %q (%v)", output, err)
+	}
+	if output, err := test.run(t, "changelog", "v1.0.0..HEAD"); err != nil || !strings.Contains(output, "add search") {
+		t.Errorf("Expected changelog to run without a key, got %q (%v)", output, err)
+	}
+
+	if _, err := test.run(t, "changelog", "v1.0.0..HEAD", "--polish"); err == nil || !strings.Contains(err.Error(), "openai.api_key is required") {
+		t.Errorf("Expected polishing to require a key, got %v", err)
+	}
+	if _, err := test.run(t, "squash-msg", "v1.0.0..HEAD"); err == nil || !strings.Contains(err.Error(), "openai.api_key is required") {
+		t.Errorf("Expected squash-msg to require a key, got %v", err)
+	}
+}
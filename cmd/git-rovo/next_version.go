@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mopemope/git-rovo/internal/changelog"
+	"github.com/mopemope/git-rovo/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+// newNextVersionCommand prints the next semantic version recommended from the
+// commits since the latest release tag, and optionally tags it
+func newNextVersionCommand(opts *options) *cobra.Command {
+	var tag bool
+
+	cmd := &cobra.Command{
+		Use:   "next-version",
+		Short: "Recommend the next semantic version from the commits since the latest tag",
+		Example: `  git-rovo next-version
+  git-rovo next-version --tag`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := opts.loadConfig()
+			if err != nil {
+				return err
+			}
+			defer func() { _ = logger.Close() }()
+			repo, err := opts.openRepository()
+			if err != nil {
+				return err
+			}
+
+			recommendation, err := changelog.RecommendNextVersion(repo)
+			if err != nil {
+				return err
+			}
+
+			since := "the first commit"
+			if recommendation.LatestTag != "" {
+				since = recommendation.LatestTag
+			}
+			fmt.Fprintf(cmd.ErrOrStderr(), "%d commits since %s, %s bump\n", len(recommendation.Commits), since, recommendation.Bump)
+			if !tag {
+				_, err := fmt.Fprintln(cmd.OutOrStdout(), recommendation.Next.Tag())
+				return err
+			}
+
+			message, err := recommendation.TagRelease(repo, time.Now(), cfg.Changelog)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "Tagged %s\n\n%s", recommendation.Next.Tag(), message)
+			return err
+		},
+	}
+
+	cmd.Flags().BoolVar(&tag, "tag", false, "create an annotated tag of the next version whose message is its changelog")
+	return cmd
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNextVersionCommand(t *testing.T) {
+	test := setupCommandTest(t)
+	test.git(t, "tag", "v1.2.3")
+	commitChanges(t, test, "docs: explain tagging")
+
+	// Documentation alone does not need a release
+	output, err := test.run(t, "next-version")
+	if err != nil || !strings.Contains(output, "none bump") || !strings.HasSuffix(output, "v1.2.3\n") {
+		t.Errorf("Unexpected recommendation %q (%v)", output, err)
+	}
+	if _, err := test.run(t, "next-version", "--tag"); err == nil || !strings.Contains(err.Error(), "no features, fixes or breaking changes") {
+		t.Errorf("Expected tagging without a release to be refused, got %v", err)
+	}
+
+	commitChanges(t, test, "feat: add next-version")
+	output, err = test.run(t, "next-version")
+	if err != nil || !strings.Contains(output, "2 commits since v1.2.3, minor bump") || !strings.HasSuffix(output, "v1.3.0\n") {
+		t.Errorf("Unexpected recommendation %q (%v)", output, err)
+	}
+
+	output, err = test.run(t, "next-version", "--tag")
+	if err != nil || !strings.Contains(output, "Tagged v1.3.0") {
+		t.Fatalf("Expected the release to be tagged, got %q (%v)", output, err)
+	}
+	if message := test.git(t, "tag", "-l", "--format=%(contents)", "v1.3.0"); !strings.Contains(message, "### Added\n\n- add next-version") {
+		t.Errorf("Expected the changelog as the tag message, got %q", message)
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mopemope/git-rovo/internal/llm"
+	"github.com/mopemope/git-rovo/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+// newPullRequestCommand prints a pull request title and description for the
+// current branch, or writes them to a file
+func newPullRequestCommand(opts *options) *cobra.Command {
+	var base, output string
+
+	cmd := &cobra.Command{
+		Use:   "pr-desc",
+		Short: "Generate a pull request title and description for the current branch",
+		Example: `  git-rovo pr-desc
+  git-rovo pr-desc --base develop --output pr.md`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := opts.loadConfig()
+			if err != nil {
+				return err
+			}
+			defer func() { _ = logger.Close() }()
+			repo, err := opts.openRepository()
+			if err != nil {
+				return err
+			}
+
+			info, err := repo.GetPullRequestInfo(base)
+			if err != nil {
+				return err
+			}
+			message, err := generate(cmd.Context(), cfg, &llm.CommitMessageRequest{
+				Mode:              llm.ModePullRequest,
+				Diff:              info.Describe(),
+				AdditionalContext: info.Template,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to generate pull request description: %w", err)
+			}
+			title, body, err := llm.ParsePullRequest(message)
+			if err != nil {
+				return fmt.Errorf("unusable pull request description: %w", err)
+			}
+
+			text := llm.FormatPullRequest(title, body)
+			if output == "" || output == "-" {
+				_, err := fmt.Fprint(cmd.OutOrStdout(), text)
+				return err
+			}
+			if err := os.WriteFile(output, []byte(text), 0644); err != nil {
+				return fmt.Errorf("failed to write %s: %w", output, err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Wrote the pull request of %s into %s to %s\n", info.Branch, info.Base, output)
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&base, "base", "main", "branch the pull request is merged into")
+	flags.StringVarP(&output, "output", "o", "", "file to write the title and description to (default stdout)")
+	return cmd
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mopemope/git-rovo/internal/llm"
+)
+
+func TestPullRequestCommand(t *testing.T) {
+	test := setupCommandTest(t)
+	if err := os.MkdirAll(filepath.Join(test.dir, ".github"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(test.dir, ".github", "pull_request_template.md"), []byte("## Why\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	test.git(t, "add", ".")
+	test.git(t, "commit", "-q", "-m", "Add template")
+	test.git(t, "checkout", "-q", "-b", "feature")
+	commitChanges(t, test, "Add search")
+	provider := useScriptedLLM(t, "<title>Add search</title>\n<body>\n## Why\n\nUsers need it.\n</body>")
+
+	output, err := test.run(t, "pr-desc")
+	if err != nil {
+		t.Fatalf("pr-desc failed: %v\n%s", err, output)
+	}
+	if output != "Add search\n\n## Why\n\nUsers need it.\n" {
+		t.Errorf("Unexpected output %q", output)
+	}
+	request := provider.requests[0]
+	if request.Mode != llm.ModePullRequest || !strings.Contains(request.Diff, "Add search") || request.AdditionalContext != "## Why\n" {
+		t.Errorf("Expected the commits and the template, got %+v", request)
+	}
+
+	file := filepath.Join(t.TempDir(), "pr.md")
+	if output, err = test.run(t, "pr-desc", "--base", "main", "--output", file); err != nil {
+		t.Fatalf("pr-desc failed: %v\n%s", err, output)
+	}
+	if content, _ := os.ReadFile(file); !strings.HasPrefix(string(content), "Add search\n\n## Why") {
+		t.Errorf("Unexpected file content %q", content)
+	}
+
+	if _, err := test.run(t, "pr-desc", "--base", "missing"); err == nil || !strings.Contains(err.Error(), "no merge base") {
+		t.Errorf("Expected an unknown base to be refused, got %v", err)
+	}
+}
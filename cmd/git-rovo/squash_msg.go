@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/mopemope/git-rovo/internal/llm"
+	"github.com/mopemope/git-rovo/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+// newSquashMessageCommand prints a squash or merge commit message for the
+// commits of a revision range
+func newSquashMessageCommand(opts *options) *cobra.Command {
+	var merge bool
+
+	cmd := &cobra.Command{
+		Use:   "squash-msg <range>",
+		Short: "Generate a squash or merge commit message for a range of commits",
+		Example: `  git-rovo squash-msg main..feature
+  git-rovo squash-msg main..feature --merge`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := opts.loadConfig()
+			if err != nil {
+				return err
+			}
+			defer func() { _ = logger.Close() }()
+			repo, err := opts.openRepository()
+			if err != nil {
+				return err
+			}
+
+			summary, err := repo.GetRangeSummary(args[0])
+			if err != nil {
+				return err
+			}
+
+			mode := llm.ModeSquash
+			if merge {
+				mode = llm.ModeMerge
+			}
+			message, err := generate(cmd.Context(), cfg, &llm.CommitMessageRequest{Mode: mode, Diff: summary.Describe()})
+			if err != nil {
+				return fmt.Errorf("failed to generate %s message: %w", mode, err)
+			}
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), message)
+			return err
+		},
+	}
+
+	cmd.Flags().BoolVar(&merge, "merge", false, "write a merge commit message instead of a squash message")
+	return cmd
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mopemope/git-rovo/internal/llm"
+)
+
+func TestSquashMessageCommand(t *testing.T) {
+	test := setupCommandTest(t)
+	test.git(t, "checkout", "-q", "-b", "feature")
+	commitChanges(t, test, "Add parser", "Fix parser typo")
+	provider := useScriptedLLM(t, "feat: add the parser\n\n- Parse the input")
+
+	output, err := test.run(t, "squash-msg", "main..feature")
+	if err != nil {
+		t.Fatalf("squash-msg failed: %v\n%s", err, output)
+	}
+	if output != "feat: add the parser\n\n- Parse the input\n" {
+		t.Errorf("Unexpected output %q", output)
+	}
+	request := provider.requests[0]
+	if request.Mode != llm.ModeSquash || !strings.Contains(request.Diff, "Add parser") || !strings.Contains(request.Diff, "Fix parser typo") {
+		t.Errorf("Expected the commits of the range, got %+v", request)
+	}
+
+	if _, err := test.run(t, "squash-msg", "main..feature", "--merge"); err != nil || provider.requests[1].Mode != llm.ModeMerge {
+		t.Errorf("Expected a merge message request, got %v", err)
+	}
+	if _, err := test.run(t, "squash-msg", "feature"); err == nil || !strings.Contains(err.Error(), "expected a range") {
+		t.Errorf("Expected a single revision to be refused, got %v", err)
+	}
+}
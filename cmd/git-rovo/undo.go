@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/mopemope/git-rovo/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+// newUndoCommand restores the last journaled discard, reset, amend or reword
+// operations
+func newUndoCommand(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "undo [n]",
+		Short: "Undo the last n discard, reset, amend or reword operations (default 1)",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			n := 1
+			if len(args) == 1 {
+				var err error
+				if n, err = strconv.Atoi(args[0]); err != nil || n < 1 {
+					return fmt.Errorf("expected a positive number of operations, got %q", args[0])
+				}
+			}
+
+			if _, err := opts.loadConfig(); err != nil {
+				return err
+			}
+			defer func() { _ = logger.Close() }()
+			repo, err := opts.openRepository()
+			if err != nil {
+				return err
+			}
+
+			// Operations undone before a failure are still reported
+			undone, err := repo.Undo(n)
+			for _, entry := range undone {
+				fmt.Fprintf(cmd.OutOrStdout(), "Undid %s\n", entry.Description())
+			}
+			return err
+		},
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mopemope/git-rovo/internal/git"
+)
+
+func TestUndoCommand(t *testing.T) {
+	test := setupCommandTest(t)
+	for _, name := range []string{"a.txt", "b.txt"} {
+		if err := os.WriteFile(filepath.Join(test.dir, name), []byte("committed\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	test.git(t, "add", ".")
+	test.git(t, "commit", "-q", "-m", "Add files")
+
+	repo, err := git.New(test.dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"a.txt", "b.txt"} {
+		if err := os.WriteFile(filepath.Join(test.dir, name), []byte("edited "+name+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.DiscardChanges(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := test.run(t, "undo", "0"); err == nil || !strings.Contains(err.Error(), "positive number") {
+		t.Errorf("Expected 0 to be refused, got %v", err)
+	}
+
+	output, err := test.run(t, "undo", "2")
+	if err != nil {
+		t.Fatalf("undo failed: %v\n%s", err, output)
+	}
+	if output != "Undid discard of b.txt\nUndid discard of a.txt\n" {
+		t.Errorf("Unexpected output %q", output)
+	}
+	for _, name := range []string{"a.txt", "b.txt"} {
+		if content, _ := os.ReadFile(filepath.Join(test.dir, name)); string(content) != "edited "+name+"\n" {
+			t.Errorf("Expected %s to be restored, got %q", name, content)
+		}
+	}
+
+	if _, err := test.run(t, "undo"); err == nil || !strings.Contains(err.Error(), "nothing to undo") {
+		t.Errorf("Expected an empty journal to be reported, got %v", err)
+	}
+}
@@ -0,0 +1,218 @@
+package changelog
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/mopemope/git-rovo/internal/config"
+	"github.com/mopemope/git-rovo/internal/git"
+	"github.com/mopemope/git-rovo/internal/llm"
+)
+
+// Unreleased is the version heading of changes not released yet
+const Unreleased = "Unreleased"
+
+// Entry is a change listed in a changelog section
+type Entry struct {
+	Hash        string // Abbreviated commit hash
+	Type        string
+	Scope       string
+	Description string
+	Breaking    bool
+	Note        string // Breaking change note
+	Footers     []git.Trailer
+}
+
+// Section is a group of entries such as "Added"
+type Section struct {
+	Title   string
+	Entries []Entry
+	Prose   string // Release notes written by the LLM, shown instead of the entries
+}
+
+// Release is the changelog of one version
+type Release struct {
+	Version  string
+	Date     time.Time
+	Breaking []Entry // Breaking changes, also listed in their sections
+	Sections []Section
+}
+
+// Build groups the Conventional Commits among commits into the configured
+// sections. Commits that do not follow the specification and types without a
+// section are left out unless they are breaking changes.
+func Build(version string, date time.Time, commits []git.CommitInfo, sections []config.ChangelogSection) *Release {
+	if version == "" {
+		version = Unreleased
+	}
+	release := &Release{Version: version, Date: date}
+	for _, section := range sections {
+		release.Sections = append(release.Sections, Section{Title: section.Title})
+	}
+
+	// Commits are listed oldest first
+	for i := len(commits) - 1; i >= 0; i-- {
+		commit := commits[i]
+		parsed := commit.Conventional()
+		if parsed.Type == "" {
+			continue
+		}
+		entry := Entry{
+			Hash:        commit.ShortHash,
+			Type:        parsed.Type,
+			Scope:       parsed.Scope,
+			Description: parsed.Description,
+			Breaking:    parsed.Breaking,
+			Note:        parsed.BreakingNote,
+			Footers:     parsed.Footers,
+		}
+		if entry.Breaking {
+			release.Breaking = append(release.Breaking, entry)
+		}
+		for j, section := range sections {
+			if slices.Contains(section.Types, entry.Type) {
+				release.Sections[j].Entries = append(release.Sections[j].Entries, entry)
+				break
+			}
+		}
+	}
+	return release
+}
+
+// Empty reports whether the release lists no changes
+func (r *Release) Empty() bool {
+	if len(r.Breaking) > 0 {
+		return false
+	}
+	for _, section := range r.Sections {
+		if len(section.Entries) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Heading returns the Keep a Changelog heading of the release
+func (r *Release) Heading() string {
+	if r.Version == Unreleased || r.Date.IsZero() {
+		return fmt.Sprintf("## [%s]", r.Version)
+	}
+	return fmt.Sprintf("## [%s] - %s", r.Version, r.Date.Format("2006-01-02"))
+}
+
+// Markdown renders the release in the Keep a Changelog format
+func (r *Release) Markdown() string {
+	var text strings.Builder
+	text.WriteString(r.Heading() + "\n")
+
+	if len(r.Breaking) > 0 {
+		text.WriteString("\n### Breaking Changes\n\n")
+		for _, entry := range r.Breaking {
+			text.WriteString(formatEntry(entry.Scope, entry.Note, entry.Hash))
+		}
+	}
+
+	for _, section := range r.Sections {
+		if len(section.Entries) == 0 {
+			continue
+		}
+		fmt.Fprintf(&text, "\n### %s\n\n", section.Title)
+		if section.Prose != "" {
+			text.WriteString(strings.TrimRight(section.Prose, "\n") + "\n")
+			continue
+		}
+		for _, entry := range section.Entries {
+			text.WriteString(formatEntry(entry.Scope, entry.Description, entry.Hash))
+		}
+	}
+	return text.String()
+}
+
+// formatEntry renders a bullet list item of a changelog section
+func formatEntry(scope, description, hash string) string {
+	description = strings.ReplaceAll(description, "\n", "\n  ")
+	if scope != "" {
+		return fmt.Sprintf("- **%s:** %s (%s)\n", scope, description, hash)
+	}
+	return fmt.Sprintf("- %s (%s)\n", description, hash)
+}
+
+// Render renders the release with a text/template. The template is executed
+// with the Release, so it can use .Version, .Date, .Breaking and .Sections.
+func (r *Release) Render(text string) (string, error) {
+	tmpl, err := template.New("changelog").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid changelog template: %w", err)
+	}
+	var output strings.Builder
+	if err := tmpl.Execute(&output, r); err != nil {
+		return "", fmt.Errorf("failed to render changelog: %w", err)
+	}
+	return output.String(), nil
+}
+
+// RenderWith renders the release with the configured template file, or in
+// the Keep a Changelog format when none is configured
+func (r *Release) RenderWith(cfg config.ChangelogConfig) (string, error) {
+	if cfg.Template == "" {
+		return r.Markdown(), nil
+	}
+	text, err := os.ReadFile(cfg.Template)
+	if err != nil {
+		return "", fmt.Errorf("failed to read changelog template: %w", err)
+	}
+	return r.Render(string(text))
+}
+
+// Polish has the LLM rewrite every non-empty section as user-facing release
+// notes. The request provides the language and generation settings.
+func (r *Release) Polish(ctx context.Context, client *llm.Client, request llm.CommitMessageRequest) error {
+	for i, section := range r.Sections {
+		if len(section.Entries) == 0 {
+			continue
+		}
+
+		var entries strings.Builder
+		fmt.Fprintf(&entries, "### %s\n\n", section.Title)
+		for _, entry := range section.Entries {
+			entries.WriteString(formatEntry(entry.Scope, entry.Description, entry.Hash))
+		}
+
+		sectionRequest := request
+		sectionRequest.Mode = llm.ModeChangelog
+		sectionRequest.Diff = entries.String()
+		sectionRequest.AdditionalContext = "Release " + r.Version
+		response, err := client.GenerateCommitMessage(ctx, &sectionRequest, "")
+		if err != nil {
+			return fmt.Errorf("failed to polish %s: %w", section.Title, err)
+		}
+
+		prose := strings.TrimSpace(response.Message)
+		if heading, rest, found := strings.Cut(prose, "\n"); found && strings.HasPrefix(heading, "#") {
+			prose = strings.TrimSpace(rest)
+		}
+		if prose == "" {
+			return fmt.Errorf("empty release notes for %s", section.Title)
+		}
+		r.Sections[i].Prose = prose
+	}
+	return nil
+}
+
+// Generate builds the release of the commits in revRange, such as
+// "v1.0.0..HEAD"
+func Generate(repo *git.Repository, revRange, version string, date time.Time, cfg config.ChangelogConfig) (*Release, error) {
+	if !strings.Contains(revRange, "..") {
+		return nil, fmt.Errorf("expected a range such as v1.0.0..HEAD, got %q", revRange)
+	}
+	commits, err := repo.QueryCommits(git.CommitQuery{Range: revRange})
+	if err != nil {
+		return nil, err
+	}
+	return Build(version, date, commits, cfg.Sections), nil
+}
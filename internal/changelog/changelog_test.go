@@ -0,0 +1,172 @@
+package changelog
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mopemope/git-rovo/internal/config"
+	"github.com/mopemope/git-rovo/internal/git"
+	"github.com/mopemope/git-rovo/internal/llm"
+)
+
+// testCommits returns a history newest first, as returned by QueryCommits
+func testCommits() []git.CommitInfo {
+	return []git.CommitInfo{
+		{ShortHash: "5555555", Subject: "feat(api)!: remove v1 endpoints", Body: "BREAKING CHANGE: clients must use /v2"},
+		{ShortHash: "4444444", Subject: "Merge branch 'feature'"},
+		{ShortHash: "3333333", Subject: "docs: update readme"},
+		{ShortHash: "2222222", Subject: "fix: handle empty diff"},
+		{ShortHash: "1111111", Subject: "feat(ui): add branches view"},
+	}
+}
+
+func TestBuild(t *testing.T) {
+	release := Build("1.2.0", time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), testCommits(), config.DefaultChangelogSections())
+
+	if len(release.Breaking) != 1 || release.Breaking[0].Note != "clients must use /v2" {
+		t.Errorf("Expected one breaking change, got %+v", release.Breaking)
+	}
+	added := release.Sections[0]
+	if added.Title != "Added" || len(added.Entries) != 2 || added.Entries[0].Hash != "1111111" {
+		t.Errorf("Expected features oldest first, got %+v", added)
+	}
+
+	expected := `## [1.2.0] - 2024-05-01
+
+### Breaking Changes
+
+- **api:** clients must use /v2 (5555555)
+
+### Added
+
+- **ui:** add branches view (1111111)
+- **api:** remove v1 endpoints (5555555)
+
+### Fixed
+
+- handle empty diff (2222222)
+`
+	if got := release.Markdown(); got != expected {
+		t.Errorf("Markdown() = %q, want %q", got, expected)
+	}
+
+	if release := Build("", time.Time{}, testCommits()[2:4], config.DefaultChangelogSections()); release.Heading() != "## [Unreleased]" || release.Empty() {
+		t.Errorf("Unexpected unreleased release %+v", release)
+	}
+	if release := Build("1.0.1", time.Time{}, testCommits()[2:3], config.DefaultChangelogSections()); !release.Empty() {
+		t.Errorf("Expected docs changes to be left out, got %+v", release)
+	}
+}
+
+func TestRender(t *testing.T) {
+	release := Build("1.2.0", time.Time{}, testCommits(), []config.ChangelogSection{{Title: "Features", Types: []string{"feat"}}})
+
+	output, err := release.Render("v{{.Version}}\n{{range .Sections}}{{.Title}}:{{range .Entries}} {{.Description}};{{end}}{{end}}")
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if output != "v1.2.0\nFeatures: add branches view; remove v1 endpoints;" {
+		t.Errorf("Render() = %q", output)
+	}
+
+	if _, err := release.Render("{{.Missing"); err == nil {
+		t.Error("Expected error for an invalid template")
+	}
+
+	path := filepath.Join(t.TempDir(), "changelog.tmpl")
+	if err := os.WriteFile(path, []byte("{{.Version}}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if output, err := release.RenderWith(config.ChangelogConfig{Template: path}); err != nil || output != "1.2.0" {
+		t.Errorf("RenderWith() = %q, %v", output, err)
+	}
+}
+
+// scriptedProvider returns the same response for every request
+type scriptedProvider struct {
+	message  string
+	requests []*llm.CommitMessageRequest
+}
+
+func (p *scriptedProvider) GenerateCommitMessage(_ context.Context, request *llm.CommitMessageRequest) (*llm.CommitMessageResponse, error) {
+	p.requests = append(p.requests, request)
+	return &llm.CommitMessageResponse{Message: p.message}, nil
+}
+
+func (p *scriptedProvider) GetProviderName() string { return "scripted" }
+
+func (p *scriptedProvider) Close() error { return nil }
+
+func TestPolish(t *testing.T) {
+	provider := &scriptedProvider{message: "### Added\n\n- You can now browse branches"}
+	client := llm.NewClient()
+	if err := client.RegisterProvider("scripted", provider); err != nil {
+		t.Fatal(err)
+	}
+
+	release := Build("1.2.0", time.Time{}, testCommits()[3:], config.DefaultChangelogSections())
+	if err := release.Polish(context.Background(), client, llm.CommitMessageRequest{Language: "english"}); err != nil {
+		t.Fatalf("Polish() error = %v", err)
+	}
+
+	// Only the sections with entries are sent
+	if len(provider.requests) != 2 || provider.requests[0].Mode != llm.ModeChangelog || !strings.Contains(provider.requests[0].Diff, "**ui:** add branches view") {
+		t.Fatalf("Unexpected requests %+v", provider.requests)
+	}
+	if release.Sections[0].Prose != "- You can now browse branches" {
+		t.Errorf("Expected the heading to be dropped, got %q", release.Sections[0].Prose)
+	}
+	if !strings.Contains(release.Markdown(), "### Added\n\n- You can now browse branches\n") {
+		t.Errorf("Expected the polished section in %q", release.Markdown())
+	}
+}
+
+// setupTestRepo creates a repository with a test identity and returns it
+// with a function that runs git in it
+func setupTestRepo(t *testing.T) (*git.Repository, func(args ...string)) {
+	t.Helper()
+
+	dir := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+	run("init", "-q")
+	run("config", "user.name", "Test User")
+	run("config", "user.email", "test@example.com")
+
+	repo, err := git.New(dir)
+	if err != nil {
+		t.Fatalf("Failed to open repository: %v", err)
+	}
+	return repo, run
+}
+
+func TestGenerate(t *testing.T) {
+	repo, run := setupTestRepo(t)
+	run("commit", "-q", "--allow-empty", "-m", "chore: initial commit")
+	run("tag", "v1.0.0")
+	run("commit", "-q", "--allow-empty", "-m", "feat: add changelog")
+	run("commit", "-q", "--allow-empty", "-m", "fix: keep trailers")
+
+	release, err := Generate(repo, "v1.0.0..HEAD", "1.1.0", time.Time{}, config.Default().Changelog)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if len(release.Sections[0].Entries) != 1 || release.Sections[0].Entries[0].Description != "add changelog" || len(release.Sections[2].Entries) != 1 {
+		t.Errorf("Unexpected release %+v", release)
+	}
+
+	if _, err := Generate(repo, "v1.0.0", "1.1.0", time.Time{}, config.Default().Changelog); err == nil {
+		t.Error("Expected error for a single revision")
+	}
+}
@@ -0,0 +1,115 @@
+package changelog
+
+import (
+	"os"
+	"strings"
+)
+
+// header starts a changelog file created by UpdateFile
+const header = `# Changelog
+
+All notable changes to this project will be documented in this file.
+
+The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.1.0/),
+and this project adheres to [Semantic Versioning](https://semver.org/spec/v2.0.0.html).
+`
+
+// headingVersion returns the version of a "## [1.2.0] - 2024-01-02" or
+// "## 1.2.0" line, or false when the line is not a version heading
+func headingVersion(line string) (string, bool) {
+	rest, found := strings.CutPrefix(strings.TrimRight(line, "\r"), "## ")
+	if !found {
+		return "", false
+	}
+	rest = strings.TrimSpace(rest)
+	if inner, ok := strings.CutPrefix(rest, "["); ok {
+		version, _, _ := strings.Cut(inner, "]")
+		return strings.TrimSpace(version), true
+	}
+	version, _, _ := strings.Cut(rest, " ")
+	return version, true
+}
+
+// Update replaces the section of version in a changelog with text. The
+// section runs from its "## " heading to the next one. A new version is
+// inserted above the newest released version, below an Unreleased section.
+// An Unreleased section is emptied when a version is released.
+func Update(content, version, text string) string {
+	if strings.TrimSpace(content) == "" {
+		content = header
+	}
+	lines := strings.Split(content, "\n")
+	text = strings.TrimRight(text, "\n") + "\n"
+
+	start, end, insert := -1, -1, -1
+	for i, line := range lines {
+		heading, ok := headingVersion(line)
+		if !ok {
+			continue
+		}
+		if start >= 0 && end < 0 {
+			end = i
+		}
+		if heading == version {
+			start = i
+		} else if insert < 0 && !strings.EqualFold(heading, Unreleased) {
+			insert = i
+		}
+	}
+
+	if start < 0 {
+		if insert < 0 {
+			insert = len(lines)
+		}
+		before := joinLines(lines[:insert])
+		if version != Unreleased {
+			before = clearUnreleased(before)
+		} else if before != "" {
+			before = strings.TrimRight(before, "\n") + "\n\n"
+		}
+		if insert == len(lines) {
+			return before + text
+		}
+		return before + text + "\n" + strings.Join(lines[insert:], "\n")
+	}
+
+	if end < 0 {
+		return joinLines(lines[:start]) + text
+	}
+	return joinLines(lines[:start]) + text + "\n" + strings.Join(lines[end:], "\n")
+}
+
+// joinLines joins lines into text ending with a newline, or an empty string
+func joinLines(lines []string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// clearUnreleased removes the entries of the Unreleased section, leaving its
+// heading, and makes sure the text ends with an empty line
+func clearUnreleased(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if heading, ok := headingVersion(line); ok && strings.EqualFold(heading, Unreleased) {
+			lines = lines[:i+1]
+			break
+		}
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n") + "\n\n"
+}
+
+// UpdateFile updates the section of version in the changelog at path,
+// creating the file when it does not exist
+func UpdateFile(path, version, text string) error {
+	content, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	return os.WriteFile(path, []byte(Update(string(content), version, text)), mode)
+}
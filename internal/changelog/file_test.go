@@ -0,0 +1,138 @@
+package changelog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUpdate(t *testing.T) {
+	existing := `# Changelog
+
+## [Unreleased]
+
+### Added
+
+- pending work (aaaaaaa)
+
+## [1.0.0] - 2024-01-01
+
+### Added
+
+- first release (bbbbbbb)
+`
+	release := "## [1.1.0] - 2024-02-01\n\n### Fixed\n\n- a fix (ccccccc)\n"
+
+	tests := []struct {
+		name     string
+		content  string
+		version  string
+		text     string
+		expected string
+	}{
+		{
+			name:    "new version below unreleased",
+			content: existing,
+			version: "1.1.0",
+			text:    release,
+			expected: `# Changelog
+
+## [Unreleased]
+
+## [1.1.0] - 2024-02-01
+
+### Fixed
+
+- a fix (ccccccc)
+
+## [1.0.0] - 2024-01-01
+
+### Added
+
+- first release (bbbbbbb)
+`,
+		},
+		{
+			name:    "existing version replaced",
+			content: existing,
+			version: "1.0.0",
+			text:    "## [1.0.0] - 2024-01-01\n\n### Added\n\n- rewritten (bbbbbbb)\n",
+			expected: `# Changelog
+
+## [Unreleased]
+
+### Added
+
+- pending work (aaaaaaa)
+
+## [1.0.0] - 2024-01-01
+
+### Added
+
+- rewritten (bbbbbbb)
+`,
+		},
+		{
+			name:    "unreleased section replaced",
+			content: existing,
+			version: "Unreleased",
+			text:    "## [Unreleased]\n\n### Fixed\n\n- new fix (ddddddd)\n",
+			expected: `# Changelog
+
+## [Unreleased]
+
+### Fixed
+
+- new fix (ddddddd)
+
+## [1.0.0] - 2024-01-01
+
+### Added
+
+- first release (bbbbbbb)
+`,
+		},
+		{
+			name:     "first version appended",
+			content:  "# Changelog\n\nNotes about this file.\n",
+			version:  "1.1.0",
+			text:     release,
+			expected: "# Changelog\n\nNotes about this file.\n\n" + release,
+		},
+		{
+			name:     "empty file gets a header",
+			content:  "",
+			version:  "1.1.0",
+			text:     release,
+			expected: header + "\n" + release,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Update(tt.content, tt.version, tt.text); got != tt.expected {
+				t.Errorf("Update() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestUpdateFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "CHANGELOG.md")
+	release := "## [1.0.0]\n\n- first (aaaaaaa)\n"
+
+	if err := UpdateFile(path, "1.0.0", release); err != nil {
+		t.Fatalf("UpdateFile() error = %v", err)
+	}
+	if err := UpdateFile(path, "1.0.0", release); err != nil {
+		t.Fatalf("UpdateFile() error = %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != header+"\n"+release {
+		t.Errorf("Expected updating twice to keep one section, got %q", content)
+	}
+}
@@ -0,0 +1,218 @@
+package changelog
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/mopemope/git-rovo/internal/config"
+	"github.com/mopemope/git-rovo/internal/git"
+)
+
+// Bump is the part of a semantic version to increment
+type Bump int
+
+const (
+	BumpNone Bump = iota
+	BumpPatch
+	BumpMinor
+	BumpMajor
+)
+
+// String returns the name of the bump
+func (b Bump) String() string {
+	switch b {
+	case BumpPatch:
+		return "patch"
+	case BumpMinor:
+		return "minor"
+	case BumpMajor:
+		return "major"
+	}
+	return "none"
+}
+
+// Version is a semantic version, as found in tags such as "v1.2.3"
+type Version struct {
+	Prefix     string // "v" or empty, kept when tagging the next version
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string
+}
+
+// semverTag matches "1.2.3" or "v1.2.3-rc.1", with optional build metadata
+var semverTag = regexp.MustCompile(`^(v?)(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-([0-9A-Za-z.-]+))?(?:\+[0-9A-Za-z.-]+)?$`)
+
+// ParseVersion parses a semantic version tag
+func ParseVersion(tag string) (Version, bool) {
+	match := semverTag.FindStringSubmatch(tag)
+	if match == nil {
+		return Version{}, false
+	}
+	major, _ := strconv.Atoi(match[2])
+	minor, _ := strconv.Atoi(match[3])
+	patch, _ := strconv.Atoi(match[4])
+	return Version{Prefix: match[1], Major: major, Minor: minor, Patch: patch, Prerelease: match[5]}, true
+}
+
+// String returns the version without its prefix, e.g. "1.2.3"
+func (v Version) String() string {
+	if v.Prerelease != "" {
+		return fmt.Sprintf("%d.%d.%d-%s", v.Major, v.Minor, v.Patch, v.Prerelease)
+	}
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// Tag returns the version as a tag name, e.g. "v1.2.3"
+func (v Version) Tag() string {
+	return v.Prefix + v.String()
+}
+
+// Less reports whether v has a lower precedence than other. Prereleases are
+// compared as strings.
+func (v Version) Less(other Version) bool {
+	if v.Major != other.Major {
+		return v.Major < other.Major
+	}
+	if v.Minor != other.Minor {
+		return v.Minor < other.Minor
+	}
+	if v.Patch != other.Patch {
+		return v.Patch < other.Patch
+	}
+	if v.Prerelease == "" || other.Prerelease == "" {
+		return v.Prerelease != "" && other.Prerelease == ""
+	}
+	return v.Prerelease < other.Prerelease
+}
+
+// Next returns the version after v for the bump. A prerelease is released
+// as its own version unless the bump requires a higher one.
+func (v Version) Next(bump Bump) Version {
+	if bump == BumpNone {
+		return v
+	}
+	next := Version{Prefix: v.Prefix, Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+	if v.Prerelease != "" {
+		switch {
+		case bump == BumpMajor && (v.Minor > 0 || v.Patch > 0):
+			return Version{Prefix: v.Prefix, Major: v.Major + 1}
+		case bump == BumpMinor && v.Patch > 0:
+			return Version{Prefix: v.Prefix, Major: v.Major, Minor: v.Minor + 1}
+		}
+		return next
+	}
+
+	switch bump {
+	case BumpMajor:
+		return Version{Prefix: v.Prefix, Major: v.Major + 1}
+	case BumpMinor:
+		return Version{Prefix: v.Prefix, Major: v.Major, Minor: v.Minor + 1}
+	case BumpPatch:
+		next.Patch++
+	}
+	return next
+}
+
+// RecommendBump classifies commits: a breaking change requires a major
+// bump, a feature a minor one and a fix or performance improvement a patch
+func RecommendBump(commits []git.CommitInfo) Bump {
+	bump := BumpNone
+	for _, commit := range commits {
+		parsed := commit.Conventional()
+		switch {
+		case parsed.Breaking:
+			return BumpMajor
+		case parsed.Type == "feat":
+			bump = max(bump, BumpMinor)
+		case parsed.Type == "fix" || parsed.Type == "perf":
+			bump = max(bump, BumpPatch)
+		}
+	}
+	return bump
+}
+
+// VersionRecommendation is the next version recommended from the commits
+// since the latest release
+type VersionRecommendation struct {
+	LatestTag string // Empty when no semantic version tag is reachable from HEAD
+	Current   Version
+	Next      Version
+	Bump      Bump
+	Range     string // Revision range of the commits since the latest tag
+	Commits   []git.CommitInfo
+}
+
+// LatestVersion returns the highest semantic version among the tags
+// reachable from HEAD, with false when there is none
+func LatestVersion(repo *git.Repository) (string, Version, bool, error) {
+	tags, err := repo.GetMergedTags()
+	if err != nil {
+		return "", Version{}, false, err
+	}
+
+	var latestTag string
+	var latest Version
+	found := false
+	for _, tag := range tags {
+		version, ok := ParseVersion(tag)
+		if !ok {
+			continue
+		}
+		if !found || latest.Less(version) {
+			latestTag, latest, found = tag, version, true
+		}
+	}
+	return latestTag, latest, found, nil
+}
+
+// RecommendNextVersion finds the latest release tag and recommends the next
+// version from the commits since. Without a tag the whole history is used
+// and versions start at 0.1.0.
+func RecommendNextVersion(repo *git.Repository) (*VersionRecommendation, error) {
+	tag, current, found, err := LatestVersion(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	recommendation := &VersionRecommendation{LatestTag: tag, Current: current, Range: "HEAD"}
+	if found {
+		recommendation.Range = tag + "..HEAD"
+	} else {
+		recommendation.Current = Version{Prefix: "v"}
+	}
+
+	recommendation.Commits, err = repo.QueryCommits(git.CommitQuery{Range: recommendation.Range})
+	if err != nil {
+		return nil, err
+	}
+	recommendation.Bump = RecommendBump(recommendation.Commits)
+	recommendation.Next = recommendation.Current.Next(recommendation.Bump)
+	if !found && recommendation.Bump != BumpNone {
+		recommendation.Next = Version{Prefix: "v", Minor: 1}
+	}
+	return recommendation, nil
+}
+
+// Release builds the changelog of the recommended version
+func (v *VersionRecommendation) Release(date time.Time, sections []config.ChangelogSection) *Release {
+	return Build(v.Next.String(), date, v.Commits, sections)
+}
+
+// TagRelease creates an annotated tag for the recommended version whose
+// message is its changelog, and returns the message
+func (v *VersionRecommendation) TagRelease(repo *git.Repository, date time.Time, cfg config.ChangelogConfig) (string, error) {
+	if v.Bump == BumpNone {
+		return "", fmt.Errorf("no features, fixes or breaking changes in %s", v.Range)
+	}
+	message, err := v.Release(date, cfg.Sections).RenderWith(cfg)
+	if err != nil {
+		return "", err
+	}
+	if err := repo.CreateAnnotatedTag(v.Next.Tag(), message); err != nil {
+		return "", err
+	}
+	return message, nil
+}
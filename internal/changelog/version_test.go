@@ -0,0 +1,137 @@
+package changelog
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mopemope/git-rovo/internal/config"
+	"github.com/mopemope/git-rovo/internal/git"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want string
+		ok   bool
+	}{
+		{tag: "v1.2.3", want: "v1.2.3", ok: true},
+		{tag: "0.10.0", want: "0.10.0", ok: true},
+		{tag: "v2.0.0-rc.1+build.5", want: "v2.0.0-rc.1", ok: true},
+		{tag: "v1.2", ok: false},
+		{tag: "release-1.2.3", ok: false},
+		{tag: "v01.2.3", ok: false},
+	}
+
+	for _, tt := range tests {
+		version, ok := ParseVersion(tt.tag)
+		if ok != tt.ok || (ok && version.Tag() != tt.want) {
+			t.Errorf("ParseVersion(%q) = %+v, %v, want %q, %v", tt.tag, version, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestVersionNext(t *testing.T) {
+	tests := []struct {
+		version string
+		bump    Bump
+		want    string
+	}{
+		{version: "v1.2.3", bump: BumpPatch, want: "v1.2.4"},
+		{version: "v1.2.3", bump: BumpMinor, want: "v1.3.0"},
+		{version: "v1.2.3", bump: BumpMajor, want: "v2.0.0"},
+		{version: "v1.2.3", bump: BumpNone, want: "v1.2.3"},
+		{version: "v2.0.0-rc.1", bump: BumpMajor, want: "v2.0.0"},
+		{version: "v1.2.1-rc.1", bump: BumpMinor, want: "v1.3.0"},
+		{version: "v1.2.0-rc.1", bump: BumpPatch, want: "v1.2.0"},
+	}
+
+	for _, tt := range tests {
+		version, _ := ParseVersion(tt.version)
+		if got := version.Next(tt.bump).Tag(); got != tt.want {
+			t.Errorf("%s.Next(%s) = %s, want %s", tt.version, tt.bump, got, tt.want)
+		}
+	}
+
+	older, _ := ParseVersion("v1.10.0-rc.1")
+	newer, _ := ParseVersion("v1.10.0")
+	if !older.Less(newer) || newer.Less(older) {
+		t.Error("Expected a prerelease to precede its release")
+	}
+}
+
+func TestRecommendBump(t *testing.T) {
+	tests := []struct {
+		subjects []string
+		want     Bump
+	}{
+		{subjects: []string{"docs: update readme", "chore: tidy"}, want: BumpNone},
+		{subjects: []string{"perf: cache status", "docs: update"}, want: BumpPatch},
+		{subjects: []string{"fix: crash", "feat(ui): add view"}, want: BumpMinor},
+		{subjects: []string{"fix: crash", "refactor!: drop option"}, want: BumpMajor},
+	}
+
+	for _, tt := range tests {
+		var commits []git.CommitInfo
+		for _, subject := range tt.subjects {
+			commits = append(commits, git.CommitInfo{Subject: subject})
+		}
+		if got := RecommendBump(commits); got != tt.want {
+			t.Errorf("RecommendBump(%v) = %s, want %s", tt.subjects, got, tt.want)
+		}
+	}
+
+	footer := []git.CommitInfo{{Subject: "fix: rename flag", Body: "BREAKING CHANGE: --all is now --everything"}}
+	if got := RecommendBump(footer); got != BumpMajor {
+		t.Errorf("Expected a BREAKING CHANGE footer to require a major bump, got %s", got)
+	}
+}
+
+func TestRecommendNextVersion(t *testing.T) {
+	repo, run := setupTestRepo(t)
+	run("commit", "-q", "--allow-empty", "-m", "feat: initial feature")
+
+	// Without a tag the first release is 0.1.0
+	recommendation, err := RecommendNextVersion(repo)
+	if err != nil {
+		t.Fatalf("RecommendNextVersion() error = %v", err)
+	}
+	if recommendation.LatestTag != "" || recommendation.Next.Tag() != "v0.1.0" {
+		t.Errorf("Unexpected first recommendation %+v", recommendation)
+	}
+
+	run("tag", "v1.4.2")
+	run("tag", "v1.10.0")
+	run("tag", "nightly")
+	run("commit", "-q", "--allow-empty", "-m", "docs: explain tagging")
+
+	if recommendation, err = RecommendNextVersion(repo); err != nil {
+		t.Fatal(err)
+	}
+	if recommendation.LatestTag != "v1.10.0" || recommendation.Bump != BumpNone || len(recommendation.Commits) != 1 {
+		t.Errorf("Expected no bump since v1.10.0, got %+v", recommendation)
+	}
+	if _, err := recommendation.TagRelease(repo, time.Time{}, config.Default().Changelog); err == nil {
+		t.Error("Expected no tag without releasable changes")
+	}
+
+	run("commit", "-q", "--allow-empty", "-m", "fix(git): keep tag messages")
+	run("commit", "-q", "--allow-empty", "-m", "feat: add next-version")
+	if recommendation, err = RecommendNextVersion(repo); err != nil {
+		t.Fatal(err)
+	}
+	if recommendation.Bump != BumpMinor || recommendation.Next.Tag() != "v1.11.0" || recommendation.Range != "v1.10.0..HEAD" {
+		t.Errorf("Expected a minor bump to v1.11.0, got %+v", recommendation)
+	}
+
+	message, err := recommendation.TagRelease(repo, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), config.Default().Changelog)
+	if err != nil {
+		t.Fatalf("TagRelease() error = %v", err)
+	}
+	if !strings.HasPrefix(message, "## [1.11.0] - 2024-06-01") || !strings.Contains(message, "- add next-version") || !strings.Contains(message, "- **git:** keep tag messages") {
+		t.Errorf("Unexpected tag message %q", message)
+	}
+	if tag, _, _, err := LatestVersion(repo); err != nil || tag != "v1.11.0" {
+		t.Errorf("Expected v1.11.0 to be the latest tag, got %q (%v)", tag, err)
+	}
+}
@@ -0,0 +1,153 @@
+package clipboard
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/aymanbagabas/go-osc52/v2"
+)
+
+// Clipboard methods
+const (
+	MethodOSC52  = "osc52"
+	MethodWlCopy = "wl-copy"
+	MethodXclip  = "xclip"
+	MethodXsel   = "xsel"
+)
+
+// ErrUnavailable is returned when no clipboard method can be used
+var ErrUnavailable = errors.New("no clipboard available: terminal does not support OSC 52 and none of wl-copy, xclip or xsel was found")
+
+// nativeTool is an external command that reads the clipboard content from stdin
+type nativeTool struct {
+	method  string
+	display string // Environment variable that must be set for the tool to work
+	args    []string
+}
+
+// nativeTools lists the external commands in order of preference
+var nativeTools = []nativeTool{
+	{MethodWlCopy, "WAYLAND_DISPLAY", nil},
+	{MethodXclip, "DISPLAY", []string{"-selection", "clipboard"}},
+	{MethodXsel, "DISPLAY", []string{"--clipboard", "--input"}},
+}
+
+// Clipboard writes text to the system clipboard
+type Clipboard struct {
+	out        io.Writer // Terminal receiving OSC 52 sequences
+	isTerminal bool
+	getenv     func(string) string
+	lookPath   func(string) (string, error)
+	run        func(path string, args []string, input string) error
+}
+
+// New creates a clipboard that writes OSC 52 sequences to stderr and falls
+// back to wl-copy, xclip or xsel
+func New() *Clipboard {
+	return &Clipboard{
+		out:        os.Stderr,
+		isTerminal: isTerminal(os.Stderr),
+		getenv:     os.Getenv,
+		lookPath:   exec.LookPath,
+		run:        runWithInput,
+	}
+}
+
+// Copy writes text to the clipboard using the default clipboard
+func Copy(text string) (string, error) {
+	return New().Copy(text)
+}
+
+// Copy writes text to the clipboard and returns the method that was used.
+// OSC 52 is preferred since it also works over SSH and inside tmux, except
+// in a local graphical session: terminals there may ignore the sequence, so
+// wl-copy, xclip or xsel is tried first. The native tools are also used when
+// the terminal cannot receive the sequence.
+func (c *Clipboard) Copy(text string) (string, error) {
+	if c.localDisplay() {
+		if method, err := c.copyNative(text); err == nil || !c.supportsOSC52() {
+			return method, err
+		}
+	}
+
+	if c.supportsOSC52() {
+		seq := osc52.New(text)
+		term := c.getenv("TERM")
+		switch {
+		case c.getenv("TMUX") != "" || strings.HasPrefix(term, "tmux"):
+			seq = seq.Tmux()
+		case strings.HasPrefix(term, "screen"):
+			seq = seq.Screen()
+		}
+		if _, err := seq.WriteTo(c.out); err != nil {
+			return "", fmt.Errorf("failed to write OSC 52 sequence: %w", err)
+		}
+		return MethodOSC52, nil
+	}
+
+	return c.copyNative(text)
+}
+
+// copyNative writes text with the first native tool that is installed and
+// has a display
+func (c *Clipboard) copyNative(text string) (string, error) {
+	for _, tool := range nativeTools {
+		if c.getenv(tool.display) == "" {
+			continue
+		}
+		path, err := c.lookPath(tool.method)
+		if err != nil {
+			continue
+		}
+		if err := c.run(path, tool.args, text); err != nil {
+			return "", fmt.Errorf("%s failed: %w", tool.method, err)
+		}
+		return tool.method, nil
+	}
+
+	return "", ErrUnavailable
+}
+
+// localDisplay reports whether git-rovo runs in a local Wayland or X11
+// session. Over SSH the display is usually forwarded and OSC 52 reaches the
+// clipboard of the machine the user sits at.
+func (c *Clipboard) localDisplay() bool {
+	if c.getenv("SSH_TTY") != "" || c.getenv("SSH_CONNECTION") != "" {
+		return false
+	}
+	return c.getenv("WAYLAND_DISPLAY") != "" || c.getenv("DISPLAY") != ""
+}
+
+// supportsOSC52 reports whether the terminal can receive OSC 52 sequences
+func (c *Clipboard) supportsOSC52() bool {
+	if !c.isTerminal {
+		return false
+	}
+	switch c.getenv("TERM") {
+	case "", "dumb", "linux":
+		return false
+	}
+	return true
+}
+
+// isTerminal reports whether f is a character device
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// runWithInput runs a command with input on stdin. Output is not captured
+// because xclip and xsel keep running in the background to serve the
+// selection, which would block reading from their pipes.
+func runWithInput(path string, args []string, input string) error {
+	cmd := exec.Command(path, args...)
+	cmd.Stdin = strings.NewReader(input)
+	return cmd.Run()
+}
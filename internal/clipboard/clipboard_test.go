@@ -0,0 +1,156 @@
+package clipboard
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type fakeRun struct {
+	path  string
+	args  []string
+	input string
+}
+
+func newTestClipboard(env map[string]string, tools []string, terminal bool) (*Clipboard, *bytes.Buffer, *fakeRun) {
+	out := &bytes.Buffer{}
+	ran := &fakeRun{}
+	c := &Clipboard{
+		out:        out,
+		isTerminal: terminal,
+		getenv:     func(key string) string { return env[key] },
+		lookPath: func(name string) (string, error) {
+			for _, tool := range tools {
+				if tool == name {
+					return "/usr/bin/" + name, nil
+				}
+			}
+			return "", errors.New("not found")
+		},
+		run: func(path string, args []string, input string) error {
+			ran.path = path
+			ran.args = args
+			ran.input = input
+			return nil
+		},
+	}
+	return c, out, ran
+}
+
+func TestCopyOSC52(t *testing.T) {
+	c, out, ran := newTestClipboard(map[string]string{"TERM": "xterm-256color"}, []string{"xclip"}, true)
+
+	method, err := c.Copy("abc123")
+	if err != nil {
+		t.Fatalf("Copy failed: %v", err)
+	}
+	if method != MethodOSC52 {
+		t.Errorf("Expected OSC 52, got %s", method)
+	}
+
+	encoded := base64.StdEncoding.EncodeToString([]byte("abc123"))
+	if !strings.Contains(out.String(), "\x1b]52;c;"+encoded) {
+		t.Errorf("Expected OSC 52 sequence, got %q", out.String())
+	}
+	if ran.path != "" {
+		t.Error("Expected no native tool to run")
+	}
+}
+
+func TestCopyOSC52Tmux(t *testing.T) {
+	c, out, _ := newTestClipboard(map[string]string{"TERM": "screen-256color", "TMUX": "/tmp/tmux-1000/default,1,0"}, nil, true)
+
+	if _, err := c.Copy("abc123"); err != nil {
+		t.Fatalf("Copy failed: %v", err)
+	}
+	if !strings.HasPrefix(out.String(), "\x1bPtmux;") {
+		t.Errorf("Expected tmux passthrough sequence, got %q", out.String())
+	}
+}
+
+func TestCopyNativeFallback(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		tools    []string
+		expected string
+		args     []string
+	}{
+		{"wayland", map[string]string{"WAYLAND_DISPLAY": "wayland-0", "DISPLAY": ":0"}, []string{"wl-copy", "xclip"}, MethodWlCopy, nil},
+		{"xclip", map[string]string{"DISPLAY": ":0"}, []string{"wl-copy", "xclip", "xsel"}, MethodXclip, []string{"-selection", "clipboard"}},
+		{"xsel", map[string]string{"DISPLAY": ":0"}, []string{"xsel"}, MethodXsel, []string{"--clipboard", "--input"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Not a terminal, so OSC 52 cannot be used
+			c, out, ran := newTestClipboard(tt.env, tt.tools, false)
+
+			method, err := c.Copy("hello")
+			if err != nil {
+				t.Fatalf("Copy failed: %v", err)
+			}
+			if method != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, method)
+			}
+			if ran.input != "hello" || strings.Join(ran.args, " ") != strings.Join(tt.args, " ") {
+				t.Errorf("Unexpected invocation %+v", ran)
+			}
+			if out.Len() != 0 {
+				t.Error("Expected no OSC 52 output")
+			}
+		})
+	}
+}
+
+func TestCopyLocalDisplay(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		tools    []string
+		expected string
+	}{
+		{"native tool first", map[string]string{"TERM": "xterm-256color", "WAYLAND_DISPLAY": "wayland-0"}, []string{"wl-copy"}, MethodWlCopy},
+		{"no native tool", map[string]string{"TERM": "xterm-256color", "DISPLAY": ":0"}, nil, MethodOSC52},
+		{"forwarded display", map[string]string{"TERM": "xterm-256color", "DISPLAY": "localhost:10.0", "SSH_CONNECTION": "10.0.0.1 52000 10.0.0.2 22"}, []string{"xclip"}, MethodOSC52},
+		{"ssh tty", map[string]string{"TERM": "xterm-256color", "DISPLAY": "localhost:10.0", "SSH_TTY": "/dev/pts/1"}, []string{"xclip"}, MethodOSC52},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, out, ran := newTestClipboard(tt.env, tt.tools, true)
+
+			method, err := c.Copy("hello")
+			if err != nil {
+				t.Fatalf("Copy failed: %v", err)
+			}
+			if method != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, method)
+			}
+			if (ran.path != "") != (method != MethodOSC52) {
+				t.Errorf("Expected a native tool to run only when reported, got %+v", ran)
+			}
+			if written := out.Len() != 0; written != (method == MethodOSC52) {
+				t.Errorf("Expected OSC 52 output only when reported, got %q", out.String())
+			}
+		})
+	}
+
+	// A failing native tool falls back to OSC 52
+	c, out, _ := newTestClipboard(map[string]string{"TERM": "xterm-256color", "DISPLAY": ":0"}, []string{"xclip"}, true)
+	c.run = func(path string, args []string, input string) error { return errors.New("cannot open display") }
+	if method, err := c.Copy("hello"); err != nil || method != MethodOSC52 || out.Len() == 0 {
+		t.Errorf("Expected OSC 52 after xclip failed, got %s (%v)", method, err)
+	}
+}
+
+func TestCopyUnavailable(t *testing.T) {
+	// Dumb terminal, and xclip is installed but there is no display
+	c, _, _ := newTestClipboard(map[string]string{"TERM": "dumb"}, []string{"xclip"}, true)
+
+	if _, err := c.Copy("hello"); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("Expected ErrUnavailable, got %v", err)
+	}
+}
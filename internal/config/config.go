@@ -4,16 +4,18 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 
 	"github.com/BurntSushi/toml"
 )
 
 // Config represents the application configuration
 type Config struct {
-	LLM    LLMConfig    `toml:"llm"`
-	Git    GitConfig    `toml:"git"`
-	UI     UIConfig     `toml:"ui"`
-	Logger LoggerConfig `toml:"logger"`
+	LLM       LLMConfig       `toml:"llm"`
+	Git       GitConfig       `toml:"git"`
+	UI        UIConfig        `toml:"ui"`
+	Logger    LoggerConfig    `toml:"logger"`
+	Changelog ChangelogConfig `toml:"changelog"`
 }
 
 // LLMConfig represents LLM provider configuration
@@ -34,13 +36,62 @@ type OpenAIConfig struct {
 
 // GitConfig represents Git related configuration
 type GitConfig struct {
-	ShowUntracked bool `toml:"show_untracked"`
+	ShowUntracked bool         `toml:"show_untracked"`
+	Signoff       bool         `toml:"signoff"`      // Add a Signed-off-by trailer to commits by default
+	TicketRules   []TicketRule `toml:"ticket_rules"` // Extract ticket IDs from the current branch name
+}
+
+// TicketRule extracts a ticket ID from a branch name and appends it to
+// commit messages as a trailer
+type TicketRule struct {
+	Pattern string `toml:"pattern"` // Regular expression; the first group, or else the whole match, is the ID
+	Trailer string `toml:"trailer"` // Trailer key, "Refs" by default
+
+	re *regexp.Regexp // Pattern compiled by Validate
+}
+
+// Match returns the ticket ID in branch, or an empty string when the
+// pattern does not match
+func (r TicketRule) Match(branch string) string {
+	if branch == "" {
+		return ""
+	}
+	re := r.re
+	if re == nil {
+		var err error
+		if re, err = regexp.Compile(r.Pattern); err != nil {
+			return ""
+		}
+	}
+	match := re.FindStringSubmatch(branch)
+	if match == nil {
+		return ""
+	}
+	if len(match) > 1 {
+		return match[1]
+	}
+	return match[0]
 }
 
 // UIConfig represents UI related configuration
 type UIConfig struct {
-	Theme       string            `toml:"theme"`
-	KeyBindings map[string]string `toml:"key_bindings"`
+	Theme          string            `toml:"theme"`
+	KeyBindings    map[string]string `toml:"key_bindings"`
+	ConfirmActions []string          `toml:"confirm_actions"` // Actions that require y/N confirmation
+}
+
+// ChangelogConfig represents changelog generation configuration
+type ChangelogConfig struct {
+	File     string             `toml:"file"`     // Changelog updated in place
+	Template string             `toml:"template"` // text/template file replacing the Keep a Changelog layout
+	Polish   bool               `toml:"polish"`   // Rewrite each section as user-facing prose with the LLM
+	Sections []ChangelogSection `toml:"sections"` // Sections in order, with the commit types they list
+}
+
+// ChangelogSection groups the commits of the given Conventional Commits types
+type ChangelogSection struct {
+	Title string   `toml:"title"`
+	Types []string `toml:"types"`
 }
 
 // LoggerConfig represents logging configuration
@@ -79,16 +130,58 @@ func DefaultConfig() *Config {
 				"log":          "l",
 				"generate_msg": "g",
 			},
+			ConfirmActions: DefaultConfirmActions(),
 		},
 		Logger: LoggerConfig{
 			Level:    "info",
 			FilePath: filepath.Join(homeDir, ".git-rovo.log"),
 		},
+		Changelog: ChangelogConfig{
+			File:     "CHANGELOG.md",
+			Sections: DefaultChangelogSections(),
+		},
+	}
+}
+
+// DefaultChangelogSections returns the Keep a Changelog sections and the
+// commit types listed in them
+func DefaultChangelogSections() []ChangelogSection {
+	return []ChangelogSection{
+		{Title: "Added", Types: []string{"feat"}},
+		{Title: "Changed", Types: []string{"perf", "refactor", "revert"}},
+		{Title: "Fixed", Types: []string{"fix"}},
+	}
+}
+
+// DefaultConfirmActions returns the destructive actions that require confirmation by default
+func DefaultConfirmActions() []string {
+	return []string{"abort_operation", "amend_commit", "autosquash", "commit_no_verify", "delete_branch", "discard_changes", "drop_stash", "reset_file", "run_rebase", "unstage_all"}
+}
+
+// RequiresConfirmation reports whether the given UI action must be confirmed before running
+func (c *UIConfig) RequiresConfirmation(action string) bool {
+	for _, a := range c.ConfirmActions {
+		if a == action {
+			return true
+		}
 	}
+	return false
 }
 
 // Load loads configuration from the specified file path
 func Load(configPath string) (*Config, error) {
+	return load(configPath, (*Config).Validate)
+}
+
+// LoadSettings loads configuration like Load without requiring the LLM
+// provider settings, for commands that never call the LLM. Call ValidateLLM
+// before creating a client.
+func LoadSettings(configPath string) (*Config, error) {
+	return load(configPath, (*Config).validateSettings)
+}
+
+// load loads configuration from the specified file path and checks it with validate
+func load(configPath string, validate func(*Config) error) (*Config, error) {
 	config := Default()
 
 	if configPath == "" {
@@ -132,7 +225,7 @@ func Load(configPath string) (*Config, error) {
 	loadFromEnvironment(config)
 
 	// Validate configuration
-	if err := config.Validate(); err != nil {
+	if err := validate(config); err != nil {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
 
@@ -169,7 +262,14 @@ func loadFromEnvironment(config *Config) {
 
 // Validate validates the configuration
 func (c *Config) Validate() error {
-	// Validate LLM configuration
+	if err := c.ValidateLLM(); err != nil {
+		return err
+	}
+	return c.validateSettings()
+}
+
+// ValidateLLM validates the LLM provider configuration
+func (c *Config) ValidateLLM() error {
 	if c.LLM.Provider == "" {
 		return fmt.Errorf("llm.provider is required")
 	}
@@ -183,7 +283,12 @@ func (c *Config) Validate() error {
 			return fmt.Errorf("openai.model is required")
 		}
 	}
+	return nil
+}
 
+// validateSettings validates everything but the LLM provider and fills in
+// the defaults
+func (c *Config) validateSettings() error {
 	// Validate logger configuration
 	if c.Logger.Level == "" {
 		c.Logger.Level = "info"
@@ -194,6 +299,28 @@ func (c *Config) Validate() error {
 		c.Logger.FilePath = filepath.Join(homeDir, ".git-rovo.log")
 	}
 
+	// Validate changelog configuration
+	if c.Changelog.File == "" {
+		c.Changelog.File = "CHANGELOG.md"
+	}
+
+	if len(c.Changelog.Sections) == 0 {
+		c.Changelog.Sections = DefaultChangelogSections()
+	}
+
+	// Validate ticket rules
+	for i := range c.Git.TicketRules {
+		rule := &c.Git.TicketRules[i]
+		re, err := regexp.Compile(rule.Pattern)
+		if err != nil || rule.Pattern == "" {
+			return fmt.Errorf("git.ticket_rules[%d].pattern is not a valid regular expression: %q", i, rule.Pattern)
+		}
+		rule.re = re
+		if rule.Trailer == "" {
+			rule.Trailer = "Refs"
+		}
+	}
+
 	return nil
 }
 
@@ -251,13 +378,18 @@ func Default() *Config {
 			ShowUntracked: true,
 		},
 		UI: UIConfig{
-			Theme:       "default",
-			KeyBindings: make(map[string]string),
+			Theme:          "default",
+			KeyBindings:    make(map[string]string),
+			ConfirmActions: DefaultConfirmActions(),
 		},
 		Logger: LoggerConfig{
 			Level:    "info",
 			FilePath: filepath.Join(homeDir, ".local", "share", "git-rovo", "git-rovo.log"),
 		},
+		Changelog: ChangelogConfig{
+			File:     "CHANGELOG.md",
+			Sections: DefaultChangelogSections(),
+		},
 	}
 }
 
@@ -189,3 +189,108 @@ func TestSaveAndLoadConfig(t *testing.T) {
 		t.Errorf("Expected language to be 'japanese', got %s", loadedConfig.LLM.Language)
 	}
 }
+
+func TestRequiresConfirmation(t *testing.T) {
+	config := Default()
+
+	for _, action := range []string{"abort_operation", "amend_commit", "autosquash", "commit_no_verify", "delete_branch", "discard_changes", "drop_stash", "reset_file", "run_rebase", "unstage_all"} {
+		if !config.UI.RequiresConfirmation(action) {
+			t.Errorf("Expected %s to require confirmation by default", action)
+		}
+	}
+
+	if config.UI.RequiresConfirmation("stage_file") {
+		t.Error("Expected stage_file not to require confirmation")
+	}
+
+	config.UI.ConfirmActions = []string{}
+	if config.UI.RequiresConfirmation("discard_changes") {
+		t.Error("Expected no confirmation when confirm_actions is empty")
+	}
+}
+
+func TestChangelogConfig(t *testing.T) {
+	config := Default()
+	if config.Changelog.File != "CHANGELOG.md" || len(config.Changelog.Sections) == 0 || config.Changelog.Sections[0].Title != "Added" {
+		t.Errorf("Unexpected default changelog config %+v", config.Changelog)
+	}
+
+	configPath := filepath.Join(t.TempDir(), "changelog.toml")
+	content := `[llm.openai]
+api_key = "test-api-key"
+
+[changelog]
+polish = true
+
+[[changelog.sections]]
+title = "Features"
+types = ["feat"]
+
+[[changelog.sections]]
+title = "Bug Fixes"
+types = ["fix", "perf"]
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	sections := loaded.Changelog.Sections
+	if !loaded.Changelog.Polish || loaded.Changelog.File != "CHANGELOG.md" || len(sections) != 2 || sections[1].Title != "Bug Fixes" || len(sections[1].Types) != 2 {
+		t.Errorf("Expected the configured sections to replace the defaults, got %+v", loaded.Changelog)
+	}
+}
+
+func TestTicketRules(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "tickets.toml")
+	content := `[llm.openai]
+api_key = "test-api-key"
+
+[[git.ticket_rules]]
+pattern = '([A-Z][A-Z0-9]+-\d+)'
+
+[[git.ticket_rules]]
+pattern = '#(\d+)'
+trailer = "Fixes"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	rules := loaded.Git.TicketRules
+	if len(rules) != 2 || rules[0].Trailer != "Refs" || rules[1].Trailer != "Fixes" {
+		t.Fatalf("Expected the default trailer to be filled in, got %+v", rules)
+	}
+	if rules[0].re == nil || rules[1].re == nil {
+		t.Error("Expected the patterns to be compiled once by Validate")
+	}
+
+	tests := []struct {
+		rule   TicketRule
+		branch string
+		want   string
+	}{
+		{rules[0], "feature/PROJ-1234-foo", "PROJ-1234"},
+		{rules[0], "main", ""},
+		{rules[1], "fix/#42-crash", "42"},
+		{TicketRule{Pattern: `[A-Z]+-\d+`}, "ABC-7", "ABC-7"},
+		{rules[0], "", ""},
+	}
+	for _, test := range tests {
+		if got := test.rule.Match(test.branch); got != test.want {
+			t.Errorf("%q.Match(%q) = %q, want %q", test.rule.Pattern, test.branch, got, test.want)
+		}
+	}
+
+	loaded.Git.TicketRules = []TicketRule{{Pattern: "("}}
+	if err := loaded.Validate(); err == nil {
+		t.Error("Expected an invalid pattern to be rejected")
+	}
+}
@@ -0,0 +1,194 @@
+package git
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BranchInfo represents a local or remote-tracking branch
+type BranchInfo struct {
+	Name         string // Short name, e.g. "main" or "origin/main"
+	FullName     string // Full ref name, e.g. "refs/heads/main"
+	Remote       bool
+	Current      bool
+	Upstream     string // Upstream branch of a local branch, e.g. "origin/main"
+	UpstreamGone bool   // The configured upstream no longer exists
+	Ahead        int    // Commits not in the upstream
+	Behind       int    // Upstream commits not in the branch
+	Hash         string // Last commit
+	Subject      string
+	Author       string
+	Date         time.Time
+}
+
+// branchRefFields lists the for-each-ref placeholders used by GetBranches
+var branchRefFields = []string{
+	"%(refname)", "%(refname:short)", "%(HEAD)", "%(upstream:short)", "%(upstream:track,nobracket)",
+	"%(objectname)", "%(authorname)", "%(committerdate:iso-strict)", "%(contents:subject)",
+}
+
+// GetBranches returns the local branches followed by the remote-tracking branches
+func (r *Repository) GetBranches() ([]BranchInfo, error) {
+	output, err := r.runGitCommandRaw("for-each-ref", "--format="+strings.Join(branchRefFields, "%00"), "refs/heads", "refs/remotes")
+	if err != nil {
+		return nil, err
+	}
+
+	var local, remote []BranchInfo
+	for _, line := range strings.Split(string(output), "\n") {
+		parts := strings.Split(line, "\x00")
+		if len(parts) != len(branchRefFields) {
+			continue
+		}
+
+		// Skip symbolic refs such as origin/HEAD
+		if strings.HasPrefix(parts[0], "refs/remotes/") && strings.HasSuffix(parts[0], "/HEAD") {
+			continue
+		}
+
+		branch := BranchInfo{
+			FullName: parts[0],
+			Name:     parts[1],
+			Remote:   strings.HasPrefix(parts[0], "refs/remotes/"),
+			Current:  parts[2] == "*",
+			Upstream: parts[3],
+			Hash:     parts[5],
+			Author:   parts[6],
+			Subject:  parts[8],
+		}
+		branch.Ahead, branch.Behind, branch.UpstreamGone = parseTrack(parts[4])
+		if date, err := time.Parse(time.RFC3339, parts[7]); err == nil {
+			branch.Date = date
+		}
+
+		if branch.Remote {
+			remote = append(remote, branch)
+		} else {
+			local = append(local, branch)
+		}
+	}
+
+	return append(local, remote...), nil
+}
+
+// parseTrack parses %(upstream:track,nobracket), e.g. "ahead 1, behind 2" or "gone"
+func parseTrack(track string) (ahead, behind int, gone bool) {
+	if track == "gone" {
+		return 0, 0, true
+	}
+	for _, part := range strings.Split(track, ",") {
+		fields := strings.Fields(part)
+		if len(fields) != 2 {
+			continue
+		}
+		count, err := strconv.Atoi(fields[1])
+		if err != nil {
+			continue
+		}
+		switch fields[0] {
+		case "ahead":
+			ahead = count
+		case "behind":
+			behind = count
+		}
+	}
+	return ahead, behind, false
+}
+
+// CheckoutBranch switches to a local branch
+func (r *Repository) CheckoutBranch(name string) error {
+	if name == "" {
+		return fmt.Errorf("branch name cannot be empty")
+	}
+	_, err := r.runGitCommand("switch", name)
+	return err
+}
+
+// TrackRemoteBranch creates a local branch tracking a remote branch such as
+// "origin/feature" and switches to it
+func (r *Repository) TrackRemoteBranch(remoteBranch string) error {
+	if remoteBranch == "" {
+		return fmt.Errorf("branch name cannot be empty")
+	}
+	_, err := r.runGitCommand("switch", "--track", remoteBranch)
+	return err
+}
+
+// CreateBranch creates a new branch at startPoint, or at HEAD when startPoint is empty
+func (r *Repository) CreateBranch(name, startPoint string) error {
+	if name == "" {
+		return fmt.Errorf("branch name cannot be empty")
+	}
+
+	args := []string{"branch", "--no-track", name}
+	if startPoint != "" {
+		args = append(args, startPoint)
+	}
+	_, err := r.runGitCommand(args...)
+	return err
+}
+
+// RenameBranch renames a local branch
+func (r *Repository) RenameBranch(oldName, newName string) error {
+	if oldName == "" || newName == "" {
+		return fmt.Errorf("branch name cannot be empty")
+	}
+	_, err := r.runGitCommand("branch", "-m", oldName, newName)
+	return err
+}
+
+// IsBranchMerged reports whether all commits of a branch are reachable from HEAD
+func (r *Repository) IsBranchMerged(name string) (bool, error) {
+	output, err := r.runGitCommand("branch", "--format=%(refname:short)", "--merged", "HEAD")
+	if err != nil {
+		return false, err
+	}
+	for _, merged := range strings.Split(output, "\n") {
+		if strings.TrimSpace(merged) == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// DeleteBranch deletes a local branch. Without force, Git refuses to delete
+// branches that are not merged.
+func (r *Repository) DeleteBranch(name string, force bool) error {
+	if name == "" {
+		return fmt.Errorf("branch name cannot be empty")
+	}
+
+	flag := "-d"
+	if force {
+		flag = "-D"
+	}
+	_, err := r.runGitCommand("branch", flag, name)
+	return err
+}
+
+// SetUpstream sets the upstream of a local branch, e.g. to "origin/main"
+func (r *Repository) SetUpstream(branch, upstream string) error {
+	if branch == "" || upstream == "" {
+		return fmt.Errorf("branch and upstream cannot be empty")
+	}
+	_, err := r.runGitCommand("branch", "--set-upstream-to="+upstream, branch)
+	return err
+}
+
+// GetAheadBehind returns how many commits ref has that base lacks, and vice versa
+func (r *Repository) GetAheadBehind(ref, base string) (ahead, behind int, err error) {
+	output, err := r.runGitCommand("rev-list", "--left-right", "--count", base+"..."+ref)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	fields := strings.Fields(output)
+	if len(fields) != 2 {
+		return 0, 0, fmt.Errorf("unexpected rev-list output: %q", output)
+	}
+	behind, _ = strconv.Atoi(fields[0])
+	ahead, _ = strconv.Atoi(fields[1])
+	return ahead, behind, nil
+}
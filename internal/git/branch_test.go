@@ -0,0 +1,196 @@
+package git
+
+import (
+	"testing"
+)
+
+func findBranch(t *testing.T, branches []BranchInfo, name string) BranchInfo {
+	t.Helper()
+	for _, branch := range branches {
+		if branch.Name == name {
+			return branch
+		}
+	}
+	t.Fatalf("Branch %s not found in %+v", name, branches)
+	return BranchInfo{}
+}
+
+func commitTestFile(t *testing.T, repo *Repository, dir, name, content, message string) {
+	t.Helper()
+	writeTestFile(t, dir, name, content)
+	if err := repo.StageFiles(name); err != nil {
+		t.Fatalf("Failed to stage file: %v", err)
+	}
+	if err := repo.Commit(message); err != nil {
+		t.Fatalf("Failed to commit: %v", err)
+	}
+}
+
+func TestBranchLifecycle(t *testing.T) {
+	repo, tempDir := setupCommittedTestRepo(t)
+
+	mainBranch, err := repo.GetCurrentBranch()
+	if err != nil {
+		t.Fatalf("Failed to get current branch: %v", err)
+	}
+	root, _ := repo.GetLastCommitHash()
+
+	commitTestFile(t, repo, tempDir, "second.txt", "second\n", "Second commit")
+
+	// Create a branch at the root commit and switch to it
+	if err := repo.CreateBranch("feature", root); err != nil {
+		t.Fatalf("Failed to create branch: %v", err)
+	}
+	if err := repo.CheckoutBranch("feature"); err != nil {
+		t.Fatalf("Failed to checkout branch: %v", err)
+	}
+	if current, _ := repo.GetCurrentBranch(); current != "feature" {
+		t.Errorf("Expected current branch feature, got %s", current)
+	}
+
+	branches, err := repo.GetBranches()
+	if err != nil {
+		t.Fatalf("Failed to get branches: %v", err)
+	}
+	if len(branches) != 2 {
+		t.Fatalf("Expected 2 branches, got %+v", branches)
+	}
+	feature := findBranch(t, branches, "feature")
+	if !feature.Current || feature.Hash != root || feature.Subject != "Initial commit" || feature.Author != "Test User" {
+		t.Errorf("Unexpected feature branch %+v", feature)
+	}
+	if feature.Date.IsZero() || feature.FullName != "refs/heads/feature" || feature.Remote {
+		t.Errorf("Unexpected feature branch %+v", feature)
+	}
+	if findBranch(t, branches, mainBranch).Current {
+		t.Error("Expected main branch not to be current")
+	}
+
+	// Rename
+	if err := repo.RenameBranch("feature", "topic"); err != nil {
+		t.Fatalf("Failed to rename branch: %v", err)
+	}
+	if current, _ := repo.GetCurrentBranch(); current != "topic" {
+		t.Errorf("Expected renamed current branch topic, got %s", current)
+	}
+
+	// Unmerged branches need force to be deleted
+	commitTestFile(t, repo, tempDir, "topic.txt", "topic\n", "Topic commit")
+	if err := repo.CheckoutBranch(mainBranch); err != nil {
+		t.Fatalf("Failed to checkout main: %v", err)
+	}
+
+	merged, err := repo.IsBranchMerged("topic")
+	if err != nil {
+		t.Fatalf("Failed to check merge status: %v", err)
+	}
+	if merged {
+		t.Error("Expected topic not to be merged")
+	}
+	ahead, behind, err := repo.GetAheadBehind("topic", "HEAD")
+	if err != nil {
+		t.Fatalf("Failed to count commits: %v", err)
+	}
+	if ahead != 1 || behind != 1 {
+		t.Errorf("Expected topic 1 ahead and 1 behind, got %d/%d", ahead, behind)
+	}
+
+	if err := repo.DeleteBranch("topic", false); err == nil {
+		t.Error("Expected deleting an unmerged branch without force to fail")
+	}
+	if err := repo.DeleteBranch("topic", true); err != nil {
+		t.Fatalf("Failed to force delete branch: %v", err)
+	}
+
+	// Merged branches can be deleted normally
+	if err := repo.CreateBranch("merged", ""); err != nil {
+		t.Fatalf("Failed to create branch: %v", err)
+	}
+	if merged, _ := repo.IsBranchMerged("merged"); !merged {
+		t.Error("Expected branch at HEAD to be merged")
+	}
+	if err := repo.DeleteBranch("merged", false); err != nil {
+		t.Fatalf("Failed to delete merged branch: %v", err)
+	}
+
+	branches, _ = repo.GetBranches()
+	if len(branches) != 1 {
+		t.Errorf("Expected only the main branch to remain, got %+v", branches)
+	}
+
+	if err := repo.CreateBranch("", ""); err == nil {
+		t.Error("Expected error for empty branch name")
+	}
+}
+
+func TestBranchUpstream(t *testing.T) {
+	repo, tempDir := setupCommittedTestRepo(t)
+	mainBranch, _ := repo.GetCurrentBranch()
+
+	remoteDir := t.TempDir()
+	if err := runCommand(remoteDir, "git", "init", "-q", "--bare"); err != nil {
+		t.Fatalf("Failed to create bare remote: %v", err)
+	}
+	if err := runCommand(tempDir, "git", "remote", "add", "origin", remoteDir); err != nil {
+		t.Fatalf("Failed to add remote: %v", err)
+	}
+	if err := runCommand(tempDir, "git", "push", "-q", "origin", mainBranch); err != nil {
+		t.Fatalf("Failed to push: %v", err)
+	}
+	if err := runCommand(tempDir, "git", "push", "-q", "origin", mainBranch+":shared"); err != nil {
+		t.Fatalf("Failed to push shared branch: %v", err)
+	}
+
+	if err := repo.SetUpstream(mainBranch, "origin/"+mainBranch); err != nil {
+		t.Fatalf("Failed to set upstream: %v", err)
+	}
+	commitTestFile(t, repo, tempDir, "local.txt", "local\n", "Local commit")
+
+	branches, err := repo.GetBranches()
+	if err != nil {
+		t.Fatalf("Failed to get branches: %v", err)
+	}
+
+	local := findBranch(t, branches, mainBranch)
+	if local.Upstream != "origin/"+mainBranch || local.Ahead != 1 || local.Behind != 0 {
+		t.Errorf("Unexpected upstream tracking %+v", local)
+	}
+	remote := findBranch(t, branches, "origin/shared")
+	if !remote.Remote || remote.FullName != "refs/remotes/origin/shared" {
+		t.Errorf("Unexpected remote branch %+v", remote)
+	}
+	if branches[0].Remote {
+		t.Error("Expected local branches to be listed first")
+	}
+
+	// Checking out a remote branch creates a tracking branch
+	if err := repo.TrackRemoteBranch("origin/shared"); err != nil {
+		t.Fatalf("Failed to track remote branch: %v", err)
+	}
+	branches, _ = repo.GetBranches()
+	shared := findBranch(t, branches, "shared")
+	if !shared.Current || shared.Upstream != "origin/shared" {
+		t.Errorf("Unexpected tracking branch %+v", shared)
+	}
+}
+
+func TestParseTrack(t *testing.T) {
+	tests := []struct {
+		track         string
+		ahead, behind int
+		gone          bool
+	}{
+		{"", 0, 0, false},
+		{"ahead 3", 3, 0, false},
+		{"behind 2", 0, 2, false},
+		{"ahead 1, behind 4", 1, 4, false},
+		{"gone", 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		ahead, behind, gone := parseTrack(tt.track)
+		if ahead != tt.ahead || behind != tt.behind || gone != tt.gone {
+			t.Errorf("parseTrack(%q) = %d, %d, %v", tt.track, ahead, behind, gone)
+		}
+	}
+}
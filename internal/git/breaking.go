@@ -0,0 +1,347 @@
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"path"
+	"slices"
+	"strings"
+)
+
+// BreakingChangeKind describes how a change breaks users of a package
+type BreakingChangeKind string
+
+const (
+	BreakingRemoved     BreakingChangeKind = "removed"
+	BreakingRenamed     BreakingChangeKind = "renamed"
+	BreakingChanged     BreakingChangeKind = "changed"
+	BreakingMethodAdded BreakingChangeKind = "interface method added"
+	BreakingFileDeleted BreakingChangeKind = "deleted file"
+)
+
+// BreakingChange is a staged change that likely breaks users of an
+// exported Go API
+type BreakingChange struct {
+	Kind BreakingChangeKind
+	Path string // File declaring the identifier, before the change for removals
+	Name string // Identifier such as "Parse" or "Config.Load"; the new name for renames
+	Old  string // Declaration before the change, or the old name for renames
+	New  string // Declaration after the change
+}
+
+// String describes the change in one line
+func (c BreakingChange) String() string {
+	switch c.Kind {
+	case BreakingRemoved:
+		return fmt.Sprintf("%s: removed exported %s (%s)", c.Path, c.Name, c.Old)
+	case BreakingRenamed:
+		return fmt.Sprintf("%s: renamed exported %s to %s", c.Path, c.Old, c.Name)
+	case BreakingChanged:
+		return fmt.Sprintf("%s: changed exported %s from %s to %s", c.Path, c.Name, c.Old, c.New)
+	case BreakingMethodAdded:
+		return fmt.Sprintf("%s: added method %s to exported interface %s", c.Path, c.Name, scopeOf(c.Name))
+	case BreakingFileDeleted:
+		return fmt.Sprintf("%s: deleted file declaring exported %s", c.Path, c.Old)
+	}
+	return fmt.Sprintf("%s: %s %s", c.Path, c.Kind, c.Name)
+}
+
+// goDecl is an exported declaration of a Go file
+type goDecl struct {
+	path      string
+	signature string // e.g. "func(data []byte) error", "struct" or "field int"
+}
+
+// goAPI maps exported identifiers such as "Parse", "Config.Load" or
+// "Config.Name" to their declarations
+type goAPI map[string]goDecl
+
+// AnalyzeBreakingChanges compares the exported API of the Go packages
+// touched by staged diffs before and after the change. Test files, package
+// main and packages under an internal directory are not public and are
+// skipped. Identifiers are compared per package directory, so moving a
+// declaration between files of the same package is not reported.
+func (r *Repository) AnalyzeBreakingChanges(diffs []DiffInfo) ([]BreakingChange, error) {
+	oldAPIs := map[string]goAPI{}
+	newAPIs := map[string]goAPI{}
+	deleted := map[string]bool{}
+
+	for _, diff := range diffs {
+		oldPath, newPath := diff.OldPath, diff.NewPath
+		if oldPath == "" {
+			oldPath = diff.FilePath
+		}
+		if newPath == "" {
+			newPath = diff.FilePath
+		}
+
+		if diff.Status != "A" && isPublicGoFile(oldPath) {
+			content, err := r.runGitCommandRaw("show", "HEAD:"+oldPath)
+			if err == nil {
+				if err := addGoAPI(oldAPIs, oldPath, content); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if diff.Status == "D" {
+			deleted[oldPath] = true
+			continue
+		}
+		if isPublicGoFile(newPath) {
+			content, err := r.runGitCommandRaw("show", ":"+newPath)
+			if err != nil {
+				return nil, err
+			}
+			if err := addGoAPI(newAPIs, newPath, content); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var changes []BreakingChange
+	for _, dir := range sortedKeys(oldAPIs) {
+		changes = append(changes, compareGoAPI(oldAPIs[dir], newAPIs[dir], deleted)...)
+	}
+	return changes, nil
+}
+
+// isPublicGoFile reports whether path is a non-test Go file outside of
+// internal, testdata and vendor directories
+func isPublicGoFile(file string) bool {
+	if !strings.HasSuffix(file, ".go") || strings.HasSuffix(file, "_test.go") {
+		return false
+	}
+	for _, dir := range strings.Split(path.Dir(file), "/") {
+		if dir == "internal" || dir == "testdata" || dir == "vendor" {
+			return false
+		}
+	}
+	return true
+}
+
+// addGoAPI adds the exported declarations of a Go file to the API of its
+// directory. Files of package main declare no public API.
+func addGoAPI(apis map[string]goAPI, file string, content []byte) error {
+	fset := token.NewFileSet()
+	parsed, err := parser.ParseFile(fset, file, content, parser.SkipObjectResolution)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+	if parsed.Name.Name == "main" {
+		return nil
+	}
+
+	dir := path.Dir(file)
+	if apis[dir] == nil {
+		apis[dir] = goAPI{}
+	}
+	api := apis[dir]
+	add := func(name, signature string) {
+		api[name] = goDecl{path: file, signature: signature}
+	}
+
+	for _, decl := range parsed.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if !decl.Name.IsExported() {
+				continue
+			}
+			if decl.Recv == nil {
+				add(decl.Name.Name, nodeString(fset, decl.Type))
+			} else if receiver := receiverName(decl.Recv); ast.IsExported(receiver) {
+				add(receiver+"."+decl.Name.Name, nodeString(fset, decl.Type))
+			}
+
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					if spec.Name.IsExported() {
+						addGoType(add, fset, spec)
+					}
+				case *ast.ValueSpec:
+					for _, name := range spec.Names {
+						if !name.IsExported() {
+							continue
+						}
+						signature := decl.Tok.String()
+						if spec.Type != nil {
+							signature += " " + nodeString(fset, spec.Type)
+						}
+						add(name.Name, signature)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// addGoType adds an exported type with its exported struct fields or
+// interface methods
+func addGoType(add func(name, signature string), fset *token.FileSet, spec *ast.TypeSpec) {
+	name := spec.Name.Name
+	prefix := "type"
+	if spec.TypeParams != nil {
+		prefix += nodeString(fset, spec.TypeParams)
+	}
+	if spec.Assign.IsValid() {
+		prefix += " ="
+	}
+
+	switch typ := spec.Type.(type) {
+	case *ast.StructType:
+		add(name, prefix+" struct")
+		for _, field := range typ.Fields.List {
+			signature := "field " + nodeString(fset, field.Type)
+			if len(field.Names) == 0 {
+				if embedded := embeddedName(field.Type); ast.IsExported(embedded) {
+					add(name+"."+embedded, signature)
+				}
+				continue
+			}
+			for _, fieldName := range field.Names {
+				if fieldName.IsExported() {
+					add(name+"."+fieldName.Name, signature)
+				}
+			}
+		}
+	case *ast.InterfaceType:
+		add(name, prefix+" interface")
+		for _, method := range typ.Methods.List {
+			if len(method.Names) == 0 {
+				// Embedded interfaces and type constraints
+				add(name+"."+nodeString(fset, method.Type), "interface method embedded")
+				continue
+			}
+			for _, methodName := range method.Names {
+				add(name+"."+methodName.Name, "interface method "+nodeString(fset, method.Type))
+			}
+		}
+	default:
+		add(name, prefix+" "+nodeString(fset, spec.Type))
+	}
+}
+
+// compareGoAPI reports the breaking differences between the exported API of
+// a package directory before and after the change
+func compareGoAPI(before, after goAPI, deleted map[string]bool) []BreakingChange {
+	var removed, added []string
+	var changes []BreakingChange
+	for _, name := range sortedKeys(before) {
+		old := before[name]
+		current, exists := after[name]
+		switch {
+		case !exists:
+			// Members of removed types are covered by the type itself
+			if parent, _, member := strings.Cut(name, "."); member {
+				_, parentExisted := before[parent]
+				if _, parentExists := after[parent]; parentExisted && !parentExists {
+					continue
+				}
+			}
+			removed = append(removed, name)
+		case current.signature != old.signature:
+			changes = append(changes, BreakingChange{Kind: BreakingChanged, Path: current.path, Name: name, Old: old.signature, New: current.signature})
+		}
+	}
+	for _, name := range sortedKeys(after) {
+		if _, exists := before[name]; !exists {
+			added = append(added, name)
+		}
+	}
+
+	// A removed identifier with the same declaration as an added one in the
+	// same scope was most likely renamed
+	deletedFiles := map[string][]string{}
+	for _, name := range removed {
+		old := before[name]
+		renamed := slices.IndexFunc(added, func(candidate string) bool {
+			return after[candidate].signature == old.signature && scopeOf(candidate) == scopeOf(name)
+		})
+		if renamed >= 0 {
+			changes = append(changes, BreakingChange{Kind: BreakingRenamed, Path: after[added[renamed]].path, Name: added[renamed], Old: name})
+			added = slices.Delete(added, renamed, renamed+1)
+			continue
+		}
+		if deleted[old.path] {
+			deletedFiles[old.path] = append(deletedFiles[old.path], name)
+			continue
+		}
+		changes = append(changes, BreakingChange{Kind: BreakingRemoved, Path: old.path, Name: name, Old: old.signature})
+	}
+	for _, file := range sortedKeys(deletedFiles) {
+		changes = append(changes, BreakingChange{Kind: BreakingFileDeleted, Path: file, Old: strings.Join(deletedFiles[file], ", ")})
+	}
+
+	// New methods must be implemented by every implementation of an interface
+	for _, name := range added {
+		parent, _, member := strings.Cut(name, ".")
+		if _, existed := before[parent]; member && existed && strings.HasPrefix(after[name].signature, "interface method") {
+			changes = append(changes, BreakingChange{Kind: BreakingMethodAdded, Path: after[name].path, Name: name, New: after[name].signature})
+		}
+	}
+	return changes
+}
+
+// scopeOf returns the type of a member such as "Config.Load", or an empty
+// string for package level identifiers
+func scopeOf(name string) string {
+	scope, _, _ := strings.Cut(name, ".")
+	if scope == name {
+		return ""
+	}
+	return scope
+}
+
+// receiverName returns the type name of a method receiver
+func receiverName(recv *ast.FieldList) string {
+	if len(recv.List) == 0 {
+		return ""
+	}
+	return embeddedName(recv.List[0].Type)
+}
+
+// embeddedName returns the type name of a receiver or embedded field, such
+// as "Config" for "*Config", "pkg.Config" or "Config[T]"
+func embeddedName(expr ast.Expr) string {
+	for {
+		switch e := expr.(type) {
+		case *ast.StarExpr:
+			expr = e.X
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.IndexListExpr:
+			expr = e.X
+		case *ast.SelectorExpr:
+			return e.Sel.Name
+		case *ast.Ident:
+			return e.Name
+		default:
+			return ""
+		}
+	}
+}
+
+// nodeString prints a syntax node on a single line
+func nodeString(fset *token.FileSet, node ast.Node) string {
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, fset, node); err != nil {
+		return ""
+	}
+	return strings.Join(strings.Fields(buf.String()), " ")
+}
+
+// sortedKeys returns the keys of a map in order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,116 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestAnalyzeBreakingChanges(t *testing.T) {
+	repo, dir := setupCommittedTestRepo(t)
+
+	write := func(name, content string) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("api/api.go", `package api
+
+type Config struct {
+	Name  string
+	Debug bool
+	path  string
+}
+
+type Store interface {
+	Get(key string) (string, error)
+}
+
+const Version = "1"
+
+func Parse(data []byte) (*Config, error) { return nil, nil }
+
+func Load(path string) error { return nil }
+
+func (c *Config) Validate() error { return nil }
+
+func helper() {}
+`)
+	write("api/legacy.go", "package api\n\nfunc Legacy() {}\n")
+	write("api/moved.go", "package api\n\nfunc Moved() {}\n")
+	write("internal/impl/impl.go", "package impl\n\nfunc Internal() {}\n")
+	write("cmd/tool/main.go", "package main\n\nfunc Run() {}\n")
+	for _, args := range [][]string{{"add", "."}, {"commit", "-q", "-m", "Add api"}} {
+		if err := runCommand(dir, "git", args...); err != nil {
+			t.Fatalf("git %v failed: %v", args, err)
+		}
+	}
+
+	write("api/api.go", `package api
+
+type Config struct {
+	Name  string
+	Debug int
+}
+
+type Store interface {
+	Get(key string) (string, error)
+	Delete(key string) error
+}
+
+const Version = "2"
+
+func Parse(data []byte, strict bool) (*Config, error) { return nil, nil }
+
+func LoadFile(path string) error { return nil }
+
+func Moved() {}
+
+func helper(n int) {}
+`)
+	write("api/extra.go", "package api\n\nfunc Extra(n int) {}\n")
+	write("internal/impl/impl.go", "package impl\n")
+	write("cmd/tool/main.go", "package main\n")
+	for _, args := range [][]string{{"rm", "-q", "api/legacy.go", "api/moved.go"}, {"add", "."}} {
+		if err := runCommand(dir, "git", args...); err != nil {
+			t.Fatalf("git %v failed: %v", args, err)
+		}
+	}
+
+	diffs, err := repo.GetDiff(true)
+	if err != nil {
+		t.Fatalf("Failed to get diff: %v", err)
+	}
+	changes, err := repo.AnalyzeBreakingChanges(diffs)
+	if err != nil {
+		t.Fatalf("AnalyzeBreakingChanges() error = %v", err)
+	}
+
+	var descriptions []string
+	for _, change := range changes {
+		descriptions = append(descriptions, change.String())
+	}
+	expected := []string{
+		"api/api.go: changed exported Config.Debug from field bool to field int",
+		"api/api.go: changed exported Parse from func(data []byte) (*Config, error) to func(data []byte, strict bool) (*Config, error)",
+		"api/api.go: removed exported Config.Validate (func() error)",
+		"api/api.go: renamed exported Load to LoadFile",
+		"api/legacy.go: deleted file declaring exported Legacy",
+		"api/api.go: added method Store.Delete to exported interface Store",
+	}
+	if !slices.Equal(descriptions, expected) {
+		t.Errorf("AnalyzeBreakingChanges() =\n%q\nwant\n%q", descriptions, expected)
+	}
+
+	// Unchanged APIs report nothing
+	if changes, err := repo.AnalyzeBreakingChanges(nil); err != nil || len(changes) != 0 {
+		t.Errorf("Expected no changes without diffs, got %v (%v)", changes, err)
+	}
+}
@@ -0,0 +1,122 @@
+package git
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Trailer represents a "Key: value" trailer at the end of a commit message
+type Trailer struct {
+	Key   string
+	Value string
+}
+
+// FileStat represents the number of changed lines of a file in a commit
+type FileStat struct {
+	Path      string
+	OldPath   string // Previous path for renames and copies
+	Additions int
+	Deletions int
+	Binary    bool
+}
+
+// CommitDetails represents a commit with its trailers and per-file statistics
+type CommitDetails struct {
+	CommitInfo
+	Trailers []Trailer
+	Files    []FileStat // Changes against the first parent
+}
+
+// GetCommitDetails returns the full metadata and per-file statistics of a commit
+func (r *Repository) GetCommitDetails(hash string) (*CommitDetails, error) {
+	if hash == "" {
+		return nil, fmt.Errorf("commit hash cannot be empty")
+	}
+
+	output, err := r.runGitCommandRaw("log", "-z", "-1", "--no-show-signature", "--pretty="+commitLogFormat(true), hash, "--")
+	if err != nil {
+		return nil, err
+	}
+	commits := parseCommitLog(string(output))
+	if len(commits) == 0 {
+		return nil, fmt.Errorf("commit not found: %s", hash)
+	}
+
+	details := &CommitDetails{CommitInfo: commits[0]}
+
+	trailers, err := r.runGitCommandRaw("log", "-1", "--format=%(trailers:only,unfold)", hash, "--")
+	if err != nil {
+		return nil, err
+	}
+	details.Trailers = parseTrailers(string(trailers))
+
+	// Merges are compared with their first parent, root commits with the empty tree
+	var numstat []byte
+	if len(details.Parents) > 1 {
+		numstat, err = r.runGitCommandRaw("diff", "--numstat", "-z", "-M", details.Parents[0], details.Hash)
+	} else {
+		numstat, err = r.runGitCommandRaw("diff-tree", "--numstat", "-z", "-M", "-r", "--root", "--no-commit-id", details.Hash)
+	}
+	if err != nil {
+		return nil, err
+	}
+	details.Files = parseNumstat(string(numstat))
+
+	return details, nil
+}
+
+// GetCommitMessage returns the full message of a commit
+func (r *Repository) GetCommitMessage(hash string) (string, error) {
+	output, err := r.runGitCommandRaw("log", "-1", "--format=%B", hash, "--")
+	if err != nil {
+		return "", fmt.Errorf("failed to read message of %s: %w", hash, err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// parseTrailers parses "Key: value" lines as printed by %(trailers:only,unfold)
+func parseTrailers(output string) []Trailer {
+	var trailers []Trailer
+	for _, line := range strings.Split(output, "\n") {
+		key, value, found := strings.Cut(line, ":")
+		if !found || strings.TrimSpace(key) == "" {
+			continue
+		}
+		trailers = append(trailers, Trailer{Key: strings.TrimSpace(key), Value: strings.TrimSpace(value)})
+	}
+	return trailers
+}
+
+// parseNumstat parses the output of --numstat -z. Each entry is
+// "<added>\t<deleted>\t<path>\0", renames leave the path empty and follow it
+// with "<old>\0<new>\0". Binary files report "-" for both counts.
+func parseNumstat(output string) []FileStat {
+	var stats []FileStat
+	fields := strings.Split(output, "\x00")
+
+	for i := 0; i < len(fields); i++ {
+		parts := strings.SplitN(strings.TrimLeft(fields[i], "\n"), "\t", 3)
+		if len(parts) != 3 {
+			continue
+		}
+
+		stat := FileStat{Path: parts[2]}
+		if parts[0] == "-" && parts[1] == "-" {
+			stat.Binary = true
+		} else {
+			stat.Additions, _ = strconv.Atoi(parts[0])
+			stat.Deletions, _ = strconv.Atoi(parts[1])
+		}
+
+		if stat.Path == "" && i+2 < len(fields) {
+			stat.OldPath = fields[i+1]
+			stat.Path = fields[i+2]
+			i += 2
+		}
+
+		stats = append(stats, stat)
+	}
+
+	return stats
+}
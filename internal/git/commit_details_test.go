@@ -0,0 +1,135 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetCommitDetails(t *testing.T) {
+	repo, tempDir := setupCommittedTestRepo(t)
+
+	writeTestFile(t, tempDir, "tracked.txt", "original\nchanged\n")
+	writeTestFile(t, tempDir, "new.txt", "one\ntwo\nthree\n")
+	if err := os.WriteFile(filepath.Join(tempDir, "image.bin"), []byte{0, 1, 2, 3, 0}, 0644); err != nil {
+		t.Fatalf("Failed to write binary file: %v", err)
+	}
+	if err := repo.StageAll(); err != nil {
+		t.Fatalf("Failed to stage files: %v", err)
+	}
+
+	message := "feat: add files\n\nLonger explanation | with pipes.\n\nRefs: #42\nCo-authored-by: Other <other@example.com>"
+	if err := repo.Commit(message); err != nil {
+		t.Fatalf("Failed to commit: %v", err)
+	}
+
+	hash, err := repo.GetLastCommitHash()
+	if err != nil {
+		t.Fatalf("Failed to get HEAD: %v", err)
+	}
+
+	details, err := repo.GetCommitDetails(hash)
+	if err != nil {
+		t.Fatalf("Failed to get commit details: %v", err)
+	}
+	if full, err := repo.GetCommitMessage(hash); err != nil || full != message {
+		t.Errorf("Expected the full message, got %q (%v)", full, err)
+	}
+
+	if details.Subject != "feat: add files" {
+		t.Errorf("Unexpected subject %q", details.Subject)
+	}
+	if details.Committer != "Test User" || details.CommitDate.IsZero() {
+		t.Errorf("Unexpected committer %q at %v", details.Committer, details.CommitDate)
+	}
+	if len(details.Parents) != 1 {
+		t.Errorf("Expected one parent, got %v", details.Parents)
+	}
+
+	if len(details.Trailers) != 2 {
+		t.Fatalf("Expected 2 trailers, got %v", details.Trailers)
+	}
+	if details.Trailers[0] != (Trailer{Key: "Refs", Value: "#42"}) {
+		t.Errorf("Unexpected trailer %+v", details.Trailers[0])
+	}
+	if details.Trailers[1].Key != "Co-authored-by" {
+		t.Errorf("Unexpected trailer %+v", details.Trailers[1])
+	}
+
+	stats := make(map[string]FileStat)
+	for _, stat := range details.Files {
+		stats[stat.Path] = stat
+	}
+	if len(stats) != 3 {
+		t.Fatalf("Expected 3 changed files, got %v", details.Files)
+	}
+	if stat := stats["new.txt"]; stat.Additions != 3 || stat.Deletions != 0 {
+		t.Errorf("Unexpected stats for new.txt: %+v", stat)
+	}
+	if stat := stats["tracked.txt"]; stat.Additions != 1 || stat.Deletions != 0 {
+		t.Errorf("Unexpected stats for tracked.txt: %+v", stat)
+	}
+	if !stats["image.bin"].Binary {
+		t.Error("Expected image.bin to be reported as binary")
+	}
+}
+
+func TestGetCommitDetailsRootAndRename(t *testing.T) {
+	repo, tempDir := setupCommittedTestRepo(t)
+
+	root, err := repo.GetLastCommitHash()
+	if err != nil {
+		t.Fatalf("Failed to get HEAD: %v", err)
+	}
+
+	details, err := repo.GetCommitDetails(root)
+	if err != nil {
+		t.Fatalf("Failed to get root commit details: %v", err)
+	}
+	if len(details.Files) != 1 || details.Files[0].Path != "tracked.txt" || details.Files[0].Additions != 1 {
+		t.Errorf("Unexpected root commit files %+v", details.Files)
+	}
+
+	if err := runCommand(tempDir, "git", "mv", "tracked.txt", "renamed.txt"); err != nil {
+		t.Fatalf("Failed to rename: %v", err)
+	}
+	if err := repo.Commit("refactor: rename"); err != nil {
+		t.Fatalf("Failed to commit: %v", err)
+	}
+
+	details, err = repo.GetCommitDetails("HEAD")
+	if err != nil {
+		t.Fatalf("Failed to get commit details: %v", err)
+	}
+	if len(details.Files) != 1 {
+		t.Fatalf("Expected one renamed file, got %+v", details.Files)
+	}
+	if details.Files[0].OldPath != "tracked.txt" || details.Files[0].Path != "renamed.txt" {
+		t.Errorf("Unexpected rename %+v", details.Files[0])
+	}
+	if len(details.Trailers) != 0 {
+		t.Errorf("Expected no trailers, got %v", details.Trailers)
+	}
+
+	if _, err := repo.GetCommitDetails("does-not-exist"); err == nil {
+		t.Error("Expected error for unknown commit")
+	}
+}
+
+func TestParseNumstat(t *testing.T) {
+	output := "3\t1\tmain.go\x00-\t-\tlogo.png\x000\t0\t\x00old name.txt\x00new name.txt\x00"
+
+	stats := parseNumstat(output)
+	if len(stats) != 3 {
+		t.Fatalf("Expected 3 entries, got %+v", stats)
+	}
+	if stats[0] != (FileStat{Path: "main.go", Additions: 3, Deletions: 1}) {
+		t.Errorf("Unexpected entry %+v", stats[0])
+	}
+	if !stats[1].Binary || stats[1].Path != "logo.png" {
+		t.Errorf("Unexpected binary entry %+v", stats[1])
+	}
+	if stats[2].OldPath != "old name.txt" || stats[2].Path != "new name.txt" {
+		t.Errorf("Unexpected rename entry %+v", stats[2])
+	}
+}
@@ -0,0 +1,511 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Operation is a multi-step Git operation that can stop with conflicts
+type Operation string
+
+const (
+	OperationNone       Operation = ""
+	OperationMerge      Operation = "merge"
+	OperationRebase     Operation = "rebase"
+	OperationCherryPick Operation = "cherry-pick"
+	OperationRevert     Operation = "revert"
+)
+
+// RepositoryState describes the operation in progress, if any
+type RepositoryState struct {
+	Operation Operation
+	Head      string // Commit being merged, picked or reverted, or the commit a rebase is onto
+	Branch    string // Branch being rebased
+	Step      int    // Current step of a rebase, 0 if unknown
+	Total     int    // Number of steps of a rebase
+}
+
+// InProgress reports whether an operation is in progress
+func (s RepositoryState) InProgress() bool {
+	return s.Operation != OperationNone
+}
+
+// ConflictSide selects which version resolves a conflict
+type ConflictSide string
+
+const (
+	ConflictOurs   ConflictSide = "ours"
+	ConflictTheirs ConflictSide = "theirs"
+	ConflictBoth   ConflictSide = "both" // Keep the lines of both sides
+)
+
+// ConflictRegion is a block delimited by conflict markers. The fields are
+// zero-based line numbers; Base is -1 unless the diff3 style is used.
+type ConflictRegion struct {
+	Start     int // "<<<<<<<" line
+	Base      int // "|||||||" line
+	Separator int // "=======" line
+	End       int // ">>>>>>>" line
+}
+
+// Unmerged reports whether the file has an unresolved conflict
+func (f FileStatus) Unmerged() bool {
+	switch f.Status {
+	case "DD", "AU", "UD", "UA", "DU", "AA", "UU":
+		return true
+	}
+	return false
+}
+
+// ConflictDescription describes the kind of conflict of an unmerged file
+func (f FileStatus) ConflictDescription() string {
+	switch f.Status {
+	case "DD":
+		return "both deleted"
+	case "AU":
+		return "added by us"
+	case "UD":
+		return "deleted by them"
+	case "UA":
+		return "added by them"
+	case "DU":
+		return "deleted by us"
+	case "AA":
+		return "both added"
+	case "UU":
+		return "both modified"
+	}
+	return ""
+}
+
+// GetRepositoryState detects a merge, rebase, cherry-pick or revert in
+// progress from the files Git keeps in the .git directory
+func (r *Repository) GetRepositoryState() (RepositoryState, error) {
+	gitDir, err := r.GetGitDir()
+	if err != nil {
+		return RepositoryState{}, err
+	}
+
+	readFile := func(name string) string {
+		content, err := os.ReadFile(filepath.Join(gitDir, name))
+		if err != nil {
+			return ""
+		}
+		return strings.TrimSpace(string(content))
+	}
+	exists := func(name string) bool {
+		_, err := os.Stat(filepath.Join(gitDir, name))
+		return err == nil
+	}
+
+	// Rebases are checked first as they may leave CHERRY_PICK_HEAD behind
+	for _, dir := range []struct{ name, step, total string }{
+		{"rebase-merge", "msgnum", "end"},
+		{"rebase-apply", "next", "last"},
+	} {
+		if !exists(dir.name) || (dir.name == "rebase-apply" && exists("rebase-apply/applying")) {
+			continue
+		}
+		state := RepositoryState{
+			Operation: OperationRebase,
+			Head:      readFile(dir.name + "/onto"),
+			Branch:    strings.TrimPrefix(readFile(dir.name+"/head-name"), "refs/heads/"),
+		}
+		state.Step, _ = strconv.Atoi(readFile(dir.name + "/" + dir.step))
+		state.Total, _ = strconv.Atoi(readFile(dir.name + "/" + dir.total))
+		return state, nil
+	}
+
+	for _, head := range []struct {
+		file      string
+		operation Operation
+	}{
+		{"MERGE_HEAD", OperationMerge},
+		{"CHERRY_PICK_HEAD", OperationCherryPick},
+		{"REVERT_HEAD", OperationRevert},
+	} {
+		if exists(head.file) {
+			// MERGE_HEAD lists one commit per line for octopus merges
+			first, _, _ := strings.Cut(readFile(head.file), "\n")
+			return RepositoryState{Operation: head.operation, Head: first}, nil
+		}
+	}
+
+	return RepositoryState{}, nil
+}
+
+// ResolveConflict resolves the conflict of an unmerged file with our version,
+// their version or the lines of both, and marks it as resolved. Taking the
+// side that deleted the file removes it.
+func (r *Repository) ResolveConflict(path string, side ConflictSide) error {
+	if path == "" {
+		return fmt.Errorf("file path cannot be empty")
+	}
+
+	stages, err := r.conflictStages(path)
+	if err != nil {
+		return err
+	}
+	if len(stages) == 0 {
+		return fmt.Errorf("%s has no conflict", path)
+	}
+
+	switch side {
+	case ConflictOurs, ConflictTheirs:
+		stage := 2
+		if side == ConflictTheirs {
+			stage = 3
+		}
+		if stages[stage] == "" {
+			_, err := r.runGitCommand("rm", "--quiet", "--", path)
+			return err
+		}
+		if _, err := r.runGitCommand("checkout", "--"+string(side), "--", path); err != nil {
+			return err
+		}
+	case ConflictBoth:
+		if stages[2] == "" || stages[3] == "" {
+			return fmt.Errorf("%s was deleted on one side; choose ours or theirs", path)
+		}
+		merged, err := r.mergeUnion(stages[1], stages[2], stages[3])
+		if err != nil {
+			return err
+		}
+		fullPath := filepath.Join(r.workDir, path)
+		mode := os.FileMode(0644)
+		if info, err := os.Stat(fullPath); err == nil {
+			mode = info.Mode().Perm()
+		}
+		if err := os.WriteFile(fullPath, merged, mode); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	default:
+		return fmt.Errorf("unknown conflict side: %s", side)
+	}
+
+	_, err = r.runGitCommand("add", "--", path)
+	return err
+}
+
+// conflictStages returns the blob of each index stage of an unmerged path:
+// 1 is the common ancestor, 2 ours and 3 theirs
+func (r *Repository) conflictStages(path string) (map[int]string, error) {
+	output, err := r.runGitCommandRaw("ls-files", "-u", "-z", "--", path)
+	if err != nil {
+		return nil, err
+	}
+
+	stages := make(map[int]string)
+	for _, entry := range strings.Split(string(output), "\x00") {
+		// "<mode> <blob> <stage>\t<path>"
+		info, _, found := strings.Cut(entry, "\t")
+		fields := strings.Fields(info)
+		if !found || len(fields) != 3 {
+			continue
+		}
+		if stage, err := strconv.Atoi(fields[2]); err == nil {
+			stages[stage] = fields[1]
+		}
+	}
+	return stages, nil
+}
+
+// mergeUnion merges two blobs keeping the lines of both sides. The base may
+// be empty when both sides added the file.
+func (r *Repository) mergeUnion(base, ours, theirs string) ([]byte, error) {
+	tempDir, err := os.MkdirTemp("", "git-rovo-merge-")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = os.RemoveAll(tempDir) }()
+
+	var files []string
+	for i, blob := range []string{ours, base, theirs} {
+		var content []byte
+		if blob != "" {
+			if content, err = r.runGitCommandRaw("cat-file", "blob", blob); err != nil {
+				return nil, err
+			}
+		}
+		file := filepath.Join(tempDir, strconv.Itoa(i))
+		if err := os.WriteFile(file, content, 0600); err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+
+	return r.runGitCommandRaw(append([]string{"merge-file", "-p", "--union"}, files...)...)
+}
+
+// ContinueOperation continues the operation in progress once all conflicts
+// are resolved, keeping the prepared commit messages
+func (r *Repository) ContinueOperation() error {
+	state, err := r.GetRepositoryState()
+	if err != nil {
+		return err
+	}
+
+	unmerged, err := r.runGitCommand("diff", "--name-only", "--diff-filter=U")
+	if err != nil {
+		return err
+	}
+	if unmerged != "" {
+		return fmt.Errorf("resolve the conflicts in %d files first", len(strings.Split(unmerged, "\n")))
+	}
+
+	env := []string{"GIT_EDITOR=true"}
+	switch state.Operation {
+	case OperationMerge:
+		_, err = r.runGitCommandWithEnv(env, "commit", "--no-edit")
+	case OperationRebase, OperationCherryPick, OperationRevert:
+		_, err = r.runGitCommandWithEnv(env, string(state.Operation), "--continue")
+	default:
+		return fmt.Errorf("no operation in progress")
+	}
+	if state.Operation == OperationRebase {
+		r.removeRebaseFiles()
+	}
+	return err
+}
+
+// AbortOperation aborts the operation in progress and restores the state
+// before it started
+func (r *Repository) AbortOperation() error {
+	state, err := r.GetRepositoryState()
+	if err != nil {
+		return err
+	}
+	if !state.InProgress() {
+		return fmt.Errorf("no operation in progress")
+	}
+	_, err = r.runGitCommand(string(state.Operation), "--abort")
+	if state.Operation == OperationRebase {
+		r.removeRebaseFiles()
+	}
+	return err
+}
+
+// FindConflictMarkers returns the conflict regions in the content of a file.
+// Lines may carry a leading diff column ("+" or " ") so that the markers are
+// also found in the diff of a file.
+func FindConflictMarkers(content string) []ConflictRegion {
+	var regions []ConflictRegion
+	current := ConflictRegion{Start: -1, Base: -1, Separator: -1}
+
+	for i, line := range strings.Split(content, "\n") {
+		if len(line) > 0 && (line[0] == '+' || line[0] == ' ') && conflictMarker(line[1:]) != "" {
+			line = line[1:]
+		}
+
+		switch conflictMarker(line) {
+		case "<":
+			current = ConflictRegion{Start: i, Base: -1, Separator: -1}
+		case "|":
+			if current.Start >= 0 && current.Separator < 0 {
+				current.Base = i
+			}
+		case "=":
+			if current.Start >= 0 {
+				current.Separator = i
+			}
+		case ">":
+			if current.Start >= 0 && current.Separator >= 0 {
+				current.End = i
+				regions = append(regions, current)
+			}
+			current = ConflictRegion{Start: -1, Base: -1, Separator: -1}
+		}
+	}
+	return regions
+}
+
+// conflictMarker returns "<", "|", "=" or ">" for conflict marker lines
+func conflictMarker(line string) string {
+	for _, marker := range []string{"<", "|", "=", ">"} {
+		prefix := strings.Repeat(marker, 7)
+		if line == prefix || strings.HasPrefix(line, prefix+" ") {
+			return marker
+		}
+	}
+	return ""
+}
+
+// GetConflictDiff renders the working tree copy of an unmerged file as a
+// diff: lines of our side are shown as removed and lines of their side as
+// added, so the conflict markers can be navigated with FindConflictMarkers
+func (r *Repository) GetConflictDiff(path string) (*DiffInfo, error) {
+	content, err := os.ReadFile(filepath.Join(r.workDir, path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if isBinaryContent(content) {
+		return &DiffInfo{FilePath: path, Status: "U", IsBinary: true, Content: fmt.Sprintf("Binary file %s", path)}, nil
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	diff := &DiffInfo{FilePath: path, OldPath: path, NewPath: path, Status: "U"}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "diff --git a/%s b/%s\n", path, path)
+	fmt.Fprintf(&body, "--- a/%s\n+++ b/%s\n", path, path)
+	fmt.Fprintf(&body, "@@ -1,%d +1,%d @@\n", len(lines), len(lines))
+
+	side := ""
+	for _, line := range lines {
+		prefix := " "
+		switch conflictMarker(line) {
+		case "<":
+			side = "-"
+		case "|":
+			side = " "
+		case "=":
+			side = "+"
+		case ">":
+			side = ""
+		default:
+			if side != "" {
+				prefix = side
+			}
+		}
+		switch prefix {
+		case "-":
+			diff.Deletions++
+		case "+":
+			diff.Additions++
+		}
+		body.WriteString(prefix + line + "\n")
+	}
+	diff.Content = body.String()
+	return diff, nil
+}
+
+// ConflictHunk is one conflict region of an unmerged file together with its
+// surrounding lines and the versions of the file on each side
+type ConflictHunk struct {
+	Path   string
+	Index  int            // Position of the region among the regions of the file
+	Region ConflictRegion // Line numbers in the working tree file
+	Lines  []string       // Lines of the region including the markers
+	Before []string       // Context lines before the region
+	After  []string       // Context lines after the region
+	Base   string         // File at the common ancestor (stage 1), empty if missing
+	Ours   string         // Our version of the file (stage 2), empty if missing
+	Theirs string         // Their version of the file (stage 3), empty if missing
+}
+
+// GetConflictHunk returns the index-th conflict region of an unmerged file
+// with up to context lines around it and the base, ours and theirs versions
+// of the file from the index
+func (r *Repository) GetConflictHunk(path string, index, context int) (*ConflictHunk, error) {
+	content, err := os.ReadFile(filepath.Join(r.workDir, path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	regions := FindConflictMarkers(string(content))
+	if index < 0 || index >= len(regions) {
+		return nil, fmt.Errorf("%s has no conflict #%d", path, index+1)
+	}
+	region := regions[index]
+
+	hunk := &ConflictHunk{
+		Path:   path,
+		Index:  index,
+		Region: region,
+		Lines:  lines[region.Start : region.End+1],
+		Before: lines[max(region.Start-context, 0):region.Start],
+		After:  lines[region.End+1 : min(region.End+1+context, len(lines))],
+	}
+
+	stages, err := r.conflictStages(path)
+	if err != nil {
+		return nil, err
+	}
+	for stage, version := range map[int]*string{1: &hunk.Base, 2: &hunk.Ours, 3: &hunk.Theirs} {
+		if stages[stage] == "" {
+			continue
+		}
+		output, err := r.runGitCommandRaw("show", fmt.Sprintf(":%d:%s", stage, path))
+		if err != nil {
+			return nil, err
+		}
+		*version = string(output)
+	}
+	return hunk, nil
+}
+
+// resolutionLines splits a resolution into lines; an empty resolution
+// removes the region
+func resolutionLines(resolution string) []string {
+	if resolution == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(resolution, "\n"), "\n")
+}
+
+// ResolutionDiff renders replacing the conflict region with resolution as a
+// diff against the working tree file
+func (h *ConflictHunk) ResolutionDiff(resolution string) *DiffInfo {
+	replacement := resolutionLines(resolution)
+	diff := &DiffInfo{
+		FilePath:  h.Path,
+		OldPath:   h.Path,
+		NewPath:   h.Path,
+		Status:    "U",
+		Additions: len(replacement),
+		Deletions: len(h.Lines),
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "diff --git a/%s b/%s\n", h.Path, h.Path)
+	fmt.Fprintf(&body, "--- a/%s\n+++ b/%s\n", h.Path, h.Path)
+	start := h.Region.Start - len(h.Before) + 1
+	fmt.Fprintf(&body, "@@ -%d,%d +%d,%d @@\n", start, len(h.Before)+len(h.Lines)+len(h.After),
+		start, len(h.Before)+len(replacement)+len(h.After))
+	for _, line := range h.Before {
+		body.WriteString(" " + line + "\n")
+	}
+	for _, line := range h.Lines {
+		body.WriteString("-" + line + "\n")
+	}
+	for _, line := range replacement {
+		body.WriteString("+" + line + "\n")
+	}
+	for _, line := range h.After {
+		body.WriteString(" " + line + "\n")
+	}
+	diff.Content = body.String()
+	return diff
+}
+
+// ApplyConflictResolution replaces the conflict region of the hunk with
+// resolution in the working tree file. It fails if the region changed since
+// the hunk was read. The file is not staged.
+func (r *Repository) ApplyConflictResolution(hunk *ConflictHunk, resolution string) error {
+	fullPath := filepath.Join(r.workDir, hunk.Path)
+	info, err := os.Stat(fullPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", hunk.Path, err)
+	}
+	content, err := os.ReadFile(fullPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", hunk.Path, err)
+	}
+
+	lines := strings.Split(string(content), "\n")
+	region := hunk.Region
+	if region.End >= len(lines) || !slices.Equal(lines[region.Start:region.End+1], hunk.Lines) {
+		return fmt.Errorf("the conflict in %s changed; reload it and try again", hunk.Path)
+	}
+
+	updated := slices.Concat(lines[:region.Start], resolutionLines(resolution), lines[region.End+1:])
+	if err := os.WriteFile(fullPath, []byte(strings.Join(updated, "\n")), info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write %s: %w", hunk.Path, err)
+	}
+	return nil
+}
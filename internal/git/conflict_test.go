@@ -0,0 +1,280 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setupConflictTestRepo creates a repository where merging the "feature"
+// branch into the current branch conflicts in tracked.txt
+func setupConflictTestRepo(t *testing.T) (*Repository, string) {
+	t.Helper()
+
+	repo, dir := setupCommittedTestRepo(t)
+	mainBranch, _ := repo.GetCurrentBranch()
+
+	if err := repo.CreateBranch("feature", ""); err != nil {
+		t.Fatalf("Failed to create branch: %v", err)
+	}
+	commitTestFile(t, repo, dir, "tracked.txt", "ours\n", "Change on main")
+	if err := repo.CheckoutBranch("feature"); err != nil {
+		t.Fatalf("Failed to checkout feature: %v", err)
+	}
+	commitTestFile(t, repo, dir, "tracked.txt", "theirs\n", "Change on feature")
+	if err := repo.CheckoutBranch(mainBranch); err != nil {
+		t.Fatalf("Failed to checkout main: %v", err)
+	}
+	return repo, dir
+}
+
+func startConflictingMerge(t *testing.T, repo *Repository) {
+	t.Helper()
+	if _, err := repo.RunGitCommand("merge", "feature"); err == nil {
+		t.Fatal("Expected merge to conflict")
+	}
+}
+
+func TestMergeConflictResolution(t *testing.T) {
+	repo, dir := setupConflictTestRepo(t)
+
+	state, err := repo.GetRepositoryState()
+	if err != nil || state.InProgress() {
+		t.Fatalf("Expected no operation in progress, got %+v (%v)", state, err)
+	}
+	if err := repo.ContinueOperation(); err == nil {
+		t.Error("Expected continue to fail without an operation")
+	}
+
+	startConflictingMerge(t, repo)
+	featureHash, _ := repo.RunGitCommand("rev-parse", "feature")
+
+	state, err = repo.GetRepositoryState()
+	if err != nil {
+		t.Fatalf("Failed to get state: %v", err)
+	}
+	if state.Operation != OperationMerge || state.Head != featureHash {
+		t.Errorf("Unexpected merge state %+v", state)
+	}
+
+	files, _ := repo.GetStatus()
+	if len(files) != 1 || !files[0].Unmerged() || files[0].ConflictDescription() != "both modified" {
+		t.Fatalf("Expected one unmerged file, got %+v", files)
+	}
+	if files[0].Staged || !files[0].Modified {
+		t.Errorf("Expected unmerged file to be unstaged, got %+v", files[0])
+	}
+
+	regions := FindConflictMarkers(readTestFile(t, dir, "tracked.txt"))
+	if len(regions) != 1 || regions[0].Start != 0 || regions[0].Separator != 2 || regions[0].End != 4 {
+		t.Errorf("Unexpected conflict regions %+v", regions)
+	}
+
+	if err := repo.ContinueOperation(); err == nil || !strings.Contains(err.Error(), "1 files") {
+		t.Errorf("Expected continue to refuse with unresolved conflicts, got %v", err)
+	}
+
+	if err := repo.ResolveConflict("tracked.txt", ConflictBoth); err != nil {
+		t.Fatalf("Failed to resolve with both sides: %v", err)
+	}
+	if content := readTestFile(t, dir, "tracked.txt"); content != "ours\ntheirs\n" {
+		t.Errorf("Expected both sides, got %q", content)
+	}
+	if err := repo.ResolveConflict("tracked.txt", ConflictOurs); err == nil {
+		t.Error("Expected error for a resolved file")
+	}
+
+	if err := repo.ContinueOperation(); err != nil {
+		t.Fatalf("Failed to continue merge: %v", err)
+	}
+	if state, _ = repo.GetRepositoryState(); state.InProgress() {
+		t.Errorf("Expected merge to be concluded, got %+v", state)
+	}
+	if parents, _ := repo.RunGitCommand("log", "-1", "--format=%P"); len(strings.Fields(parents)) != 2 {
+		t.Errorf("Expected a merge commit, got parents %q", parents)
+	}
+}
+
+func TestResolveConflictSidesAndAbort(t *testing.T) {
+	repo, dir := setupConflictTestRepo(t)
+	startConflictingMerge(t, repo)
+
+	if err := repo.ResolveConflict("tracked.txt", ConflictTheirs); err != nil {
+		t.Fatalf("Failed to resolve with theirs: %v", err)
+	}
+	if content := readTestFile(t, dir, "tracked.txt"); content != "theirs\n" {
+		t.Errorf("Expected their version, got %q", content)
+	}
+
+	if err := repo.AbortOperation(); err != nil {
+		t.Fatalf("Failed to abort merge: %v", err)
+	}
+	if content := readTestFile(t, dir, "tracked.txt"); content != "ours\n" {
+		t.Errorf("Expected our version after abort, got %q", content)
+	}
+	if err := repo.AbortOperation(); err == nil {
+		t.Error("Expected abort to fail without an operation")
+	}
+
+	// A file deleted on our side is removed when our side is taken
+	if err := runCommand(dir, "git", "rm", "-q", "tracked.txt"); err != nil {
+		t.Fatalf("Failed to remove file: %v", err)
+	}
+	if err := runCommand(dir, "git", "commit", "-q", "-m", "Remove tracked"); err != nil {
+		t.Fatalf("Failed to commit: %v", err)
+	}
+	startConflictingMerge(t, repo)
+
+	files, _ := repo.GetStatus()
+	if len(files) != 1 || files[0].ConflictDescription() != "deleted by us" {
+		t.Fatalf("Expected delete conflict, got %+v", files)
+	}
+	if err := repo.ResolveConflict("tracked.txt", ConflictBoth); err == nil {
+		t.Error("Expected both sides to be refused for a delete conflict")
+	}
+	if err := repo.ResolveConflict("tracked.txt", ConflictOurs); err != nil {
+		t.Fatalf("Failed to resolve with ours: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "tracked.txt")); !os.IsNotExist(err) {
+		t.Error("Expected file to be removed")
+	}
+}
+
+func TestRebaseAndCherryPickState(t *testing.T) {
+	repo, _ := setupConflictTestRepo(t)
+	mainBranch, _ := repo.GetCurrentBranch()
+
+	if _, err := repo.RunGitCommand("cherry-pick", "feature"); err == nil {
+		t.Fatal("Expected cherry-pick to conflict")
+	}
+	if state, _ := repo.GetRepositoryState(); state.Operation != OperationCherryPick {
+		t.Errorf("Expected cherry-pick state, got %+v", state)
+	}
+	if err := repo.AbortOperation(); err != nil {
+		t.Fatalf("Failed to abort cherry-pick: %v", err)
+	}
+
+	if err := repo.CheckoutBranch("feature"); err != nil {
+		t.Fatalf("Failed to checkout feature: %v", err)
+	}
+	if _, err := repo.RunGitCommand("rebase", mainBranch); err == nil {
+		t.Fatal("Expected rebase to conflict")
+	}
+
+	state, err := repo.GetRepositoryState()
+	if err != nil {
+		t.Fatalf("Failed to get state: %v", err)
+	}
+	mainHash, _ := repo.RunGitCommand("rev-parse", mainBranch)
+	if state.Operation != OperationRebase || state.Branch != "feature" || state.Head != mainHash || state.Step != 1 || state.Total != 1 {
+		t.Errorf("Unexpected rebase state %+v", state)
+	}
+
+	if err := repo.ResolveConflict("tracked.txt", ConflictTheirs); err != nil {
+		t.Fatalf("Failed to resolve: %v", err)
+	}
+	if err := repo.ContinueOperation(); err != nil {
+		t.Fatalf("Failed to continue rebase: %v", err)
+	}
+	if state, _ = repo.GetRepositoryState(); state.InProgress() {
+		t.Errorf("Expected rebase to be finished, got %+v", state)
+	}
+	if subject, _ := repo.RunGitCommand("log", "-1", "--format=%s"); subject != "Change on feature" {
+		t.Errorf("Expected rebased commit to keep its message, got %q", subject)
+	}
+}
+
+func TestFindConflictMarkers(t *testing.T) {
+	content := strings.Join([]string{
+		"start",
+		"<<<<<<< HEAD",
+		"ours",
+		"||||||| base",
+		"base",
+		"=======",
+		"theirs",
+		">>>>>>> feature",
+		"=======",
+		"+<<<<<<< HEAD",
+		"+a",
+		"+=======",
+		"+b",
+		"+>>>>>>> other",
+	}, "\n")
+
+	regions := FindConflictMarkers(content)
+	expected := []ConflictRegion{
+		{Start: 1, Base: 3, Separator: 5, End: 7},
+		{Start: 9, Base: -1, Separator: 11, End: 13},
+	}
+	if len(regions) != len(expected) {
+		t.Fatalf("Expected %d regions, got %+v", len(expected), regions)
+	}
+	for i := range expected {
+		if regions[i] != expected[i] {
+			t.Errorf("Region %d = %+v, want %+v", i, regions[i], expected[i])
+		}
+	}
+}
+
+func TestGetConflictDiff(t *testing.T) {
+	repo, _ := setupConflictTestRepo(t)
+	startConflictingMerge(t, repo)
+
+	diff, err := repo.GetConflictDiff("tracked.txt")
+	if err != nil {
+		t.Fatalf("Failed to get conflict diff: %v", err)
+	}
+	if diff.Status != "U" || diff.Additions != 1 || diff.Deletions != 1 {
+		t.Errorf("Unexpected conflict diff %+v", diff)
+	}
+	for _, want := range []string{" <<<<<<< HEAD\n-ours\n =======\n+theirs\n >>>>>>> feature\n"} {
+		if !strings.Contains(diff.Content, want) {
+			t.Errorf("Expected %q in %q", want, diff.Content)
+		}
+	}
+	if regions := FindConflictMarkers(diff.Content); len(regions) != 1 || regions[0].Start != 4 {
+		t.Errorf("Expected one region after the diff header, got %+v", regions)
+	}
+}
+
+func TestConflictHunkResolution(t *testing.T) {
+	repo, dir := setupConflictTestRepo(t)
+	startConflictingMerge(t, repo)
+
+	if _, err := repo.GetConflictHunk("tracked.txt", 1, 3); err == nil {
+		t.Error("Expected error for a missing conflict")
+	}
+
+	hunk, err := repo.GetConflictHunk("tracked.txt", 0, 3)
+	if err != nil {
+		t.Fatalf("Failed to get conflict hunk: %v", err)
+	}
+	if hunk.Base != "original\n" || hunk.Ours != "ours\n" || hunk.Theirs != "theirs\n" {
+		t.Errorf("Unexpected versions base=%q ours=%q theirs=%q", hunk.Base, hunk.Ours, hunk.Theirs)
+	}
+	if len(hunk.Lines) != 5 || hunk.Lines[1] != "ours" || len(hunk.Before) != 0 || len(hunk.After) != 0 {
+		t.Errorf("Unexpected hunk %+v", hunk)
+	}
+
+	diff := hunk.ResolutionDiff("ours and theirs\n")
+	if diff.Additions != 1 || diff.Deletions != 5 || !strings.Contains(diff.Content, "->>>>>>> feature\n+ours and theirs\n") {
+		t.Errorf("Unexpected resolution diff %+v", diff)
+	}
+
+	if err := repo.ApplyConflictResolution(hunk, "ours and theirs\n"); err != nil {
+		t.Fatalf("Failed to apply resolution: %v", err)
+	}
+	if content := readTestFile(t, dir, "tracked.txt"); content != "ours and theirs\n" {
+		t.Errorf("Expected resolved content, got %q", content)
+	}
+	if err := repo.ApplyConflictResolution(hunk, "again\n"); err == nil {
+		t.Error("Expected error when the conflict changed")
+	}
+
+	// Applying a resolution does not stage the file
+	if files, _ := repo.GetStatus(); len(files) != 1 || !files[0].Unmerged() {
+		t.Errorf("Expected file to stay unmerged, got %+v", files)
+	}
+}
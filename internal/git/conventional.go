@@ -0,0 +1,83 @@
+package git
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ConventionalCommit is a commit message parsed according to the
+// Conventional Commits specification
+type ConventionalCommit struct {
+	Type         string // Lowercase type such as "feat", empty when the subject does not follow the specification
+	Scope        string
+	Description  string
+	Breaking     bool   // "!" before the colon or a BREAKING CHANGE footer
+	BreakingNote string // Text of the BREAKING CHANGE footer, the description when only "!" is used
+	Footers      []Trailer
+}
+
+var (
+	// conventionalHeader matches "type(scope)!: description"
+	conventionalHeader = regexp.MustCompile(`^([A-Za-z]+)(?:\(([^()]*)\))?(!)?: +(\S.*)$`)
+
+	// conventionalFooter matches the first line of a "Token: value" or "Token #value" footer
+	conventionalFooter = regexp.MustCompile(`^(BREAKING[ -]CHANGE|[A-Za-z][A-Za-z0-9-]*)(?:: | #)(.*)$`)
+)
+
+// ParseConventionalCommit parses the subject and body of a commit message.
+// Commits not following the specification are returned with an empty Type
+// and the subject as the description.
+func ParseConventionalCommit(subject, body string) ConventionalCommit {
+	subject = strings.TrimSpace(subject)
+	commit := ConventionalCommit{Description: subject}
+
+	match := conventionalHeader.FindStringSubmatch(subject)
+	if match == nil {
+		return commit
+	}
+	commit.Type = strings.ToLower(match[1])
+	commit.Scope = strings.TrimSpace(match[2])
+	commit.Breaking = match[3] == "!"
+	commit.Description = strings.TrimSpace(match[4])
+
+	commit.Footers = parseConventionalFooters(body)
+	for _, footer := range commit.Footers {
+		if footer.Key == "BREAKING CHANGE" || footer.Key == "BREAKING-CHANGE" {
+			commit.Breaking = true
+			commit.BreakingNote = footer.Value
+		}
+	}
+	if commit.Breaking && commit.BreakingNote == "" {
+		commit.BreakingNote = commit.Description
+	}
+	return commit
+}
+
+// Conventional parses the message of the commit as a Conventional Commit
+func (c CommitInfo) Conventional() ConventionalCommit {
+	return ParseConventionalCommit(c.Subject, c.Body)
+}
+
+// parseConventionalFooters parses the footers in the last paragraph of a
+// commit body. Lines that do not start a footer continue the previous one;
+// the paragraph is not a footer block when its first line is not a footer.
+func parseConventionalFooters(body string) []Trailer {
+	paragraphs := strings.Split(strings.TrimSpace(strings.ReplaceAll(body, "\r\n", "\n")), "\n\n")
+	last := strings.TrimSpace(paragraphs[len(paragraphs)-1])
+	if last == "" {
+		return nil
+	}
+
+	var footers []Trailer
+	for _, line := range strings.Split(last, "\n") {
+		if match := conventionalFooter.FindStringSubmatch(line); match != nil {
+			footers = append(footers, Trailer{Key: match[1], Value: strings.TrimSpace(match[2])})
+			continue
+		}
+		if len(footers) == 0 {
+			return nil
+		}
+		footers[len(footers)-1].Value += "\n" + strings.TrimSpace(line)
+	}
+	return footers
+}
@@ -0,0 +1,60 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseConventionalCommit(t *testing.T) {
+	tests := []struct {
+		name    string
+		subject string
+		body    string
+		want    ConventionalCommit
+	}{
+		{
+			name:    "type only",
+			subject: "fix: handle empty diff",
+			want:    ConventionalCommit{Type: "fix", Description: "handle empty diff"},
+		},
+		{
+			name:    "scope and breaking marker",
+			subject: "Feat(api)!: drop the v1 endpoints",
+			want:    ConventionalCommit{Type: "feat", Scope: "api", Description: "drop the v1 endpoints", Breaking: true, BreakingNote: "drop the v1 endpoints"},
+		},
+		{
+			name:    "breaking change footer",
+			subject: "refactor(config): rename keys",
+			body:    "Keys now use snake case.\n\nBREAKING CHANGE: ui.keyBindings is now ui.key_bindings\nand must be migrated.\nRefs #42\nReviewed-by: Alice",
+			want: ConventionalCommit{
+				Type: "refactor", Scope: "config", Description: "rename keys", Breaking: true,
+				BreakingNote: "ui.keyBindings is now ui.key_bindings\nand must be migrated.",
+				Footers: []Trailer{
+					{Key: "BREAKING CHANGE", Value: "ui.keyBindings is now ui.key_bindings\nand must be migrated."},
+					{Key: "Refs", Value: "42"},
+					{Key: "Reviewed-by", Value: "Alice"},
+				},
+			},
+		},
+		{
+			name:    "body without footers",
+			subject: "docs: explain setup",
+			body:    "See the README for details.\nNothing else: changes.",
+			want:    ConventionalCommit{Type: "docs", Description: "explain setup"},
+		},
+		{
+			name:    "not conventional",
+			subject: "Merge branch 'feature'",
+			body:    "Refs: 1",
+			want:    ConventionalCommit{Description: "Merge branch 'feature'"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseConventionalCommit(tt.subject, tt.body); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseConventionalCommit() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+package git
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// FixupCandidate is a commit that last touched lines changed or deleted by
+// the staged changes
+type FixupCandidate struct {
+	Commit CommitInfo
+	Lines  int // Number of staged changed or deleted lines it last touched
+}
+
+// hunkHeader matches the old line range of a unified diff hunk
+var hunkHeader = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+\d+(?:,\d+)? @@`)
+
+// blameLine matches the first header line of a line in git blame --porcelain
+var blameLine = regexp.MustCompile(`^([0-9a-f]{40,64}) \d+ \d+`)
+
+// SuggestFixupTargets blames the lines that the staged changes modify or
+// delete in HEAD and returns the commits that last touched them, most
+// recent first. Added lines have no history and are not considered.
+func (r *Repository) SuggestFixupTargets() ([]FixupCandidate, error) {
+	if _, err := r.runGitCommand("rev-parse", "--verify", "HEAD"); err != nil {
+		return nil, fmt.Errorf("no commits to fix up")
+	}
+
+	output, err := r.runGitCommandRaw("diff", "--cached", "-U0", "--no-color", "--no-ext-diff", "-M", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	// The path is only read from the header of each file, since deleted
+	// lines such as "-- comment" also start with "--- " in the hunks
+	lines := map[string]int{}
+	path := ""
+	inHeader := false
+	for _, line := range strings.Split(string(output), "\n") {
+		if strings.HasPrefix(line, "diff --git ") {
+			path, inHeader = "", true
+			continue
+		}
+		if inHeader && strings.HasPrefix(line, "--- ") {
+			path = diffPath(strings.TrimPrefix(line, "--- "))
+			continue
+		}
+		match := hunkHeader.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		inHeader = false
+		if path == "" {
+			continue
+		}
+		start, _ := strconv.Atoi(match[1])
+		count := 1
+		if match[2] != "" {
+			count, _ = strconv.Atoi(match[2])
+		}
+		if count == 0 {
+			continue
+		}
+
+		blame, err := r.runGitCommandRaw("blame", "--porcelain", "-L", fmt.Sprintf("%d,+%d", start, count), "HEAD", "--", path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to blame %s: %w", path, err)
+		}
+		for _, blameOutput := range strings.Split(string(blame), "\n") {
+			if match := blameLine.FindStringSubmatch(blameOutput); match != nil {
+				lines[match[1]]++
+			}
+		}
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("the staged changes do not change or delete committed lines")
+	}
+
+	order, err := r.topoOrder(sortedKeys(lines))
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]FixupCandidate, 0, len(order))
+	for _, hash := range order {
+		details, err := r.GetCommitDetails(hash)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, FixupCandidate{Commit: details.CommitInfo, Lines: lines[hash]})
+	}
+	return candidates, nil
+}
+
+// diffPath returns the path of a "--- a/path" diff header line, or an empty
+// string for /dev/null. Git ends the header with a tab when the path
+// contains a space.
+func diffPath(header string) string {
+	header = strings.TrimSuffix(header, "\t")
+	if header == "/dev/null" {
+		return ""
+	}
+	if strings.HasPrefix(header, `"`) {
+		if unquoted, err := strconv.Unquote(header); err == nil {
+			header = unquoted
+		}
+	}
+	return strings.TrimPrefix(header, "a/")
+}
+
+// topoOrder sorts commits reachable from HEAD so that descendants come before
+// their ancestors, listing only the history down to their common ancestor
+func (r *Repository) topoOrder(hashes []string) ([]string, error) {
+	args := []string{"rev-list", "--topo-order", "HEAD"}
+	if base, err := r.runGitCommand(append([]string{"merge-base", "--octopus"}, hashes...)...); err == nil {
+		args = append(args, "^"+base+"^@")
+	}
+	output, err := r.runGitCommandRaw(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var order []string
+	for _, hash := range strings.Fields(string(output)) {
+		if slices.Contains(hashes, hash) {
+			order = append(order, hash)
+		}
+	}
+	return order, nil
+}
+
+// CommitFixup commits the staged changes as a "fixup!" commit of target, to
+// be folded into it by an autosquash rebase
+func (r *Repository) CommitFixup(target string, options CommitOptions) error {
+	if target == "" {
+		return fmt.Errorf("fixup target cannot be empty")
+	}
+	return r.commit([]string{"commit", "--fixup=" + target}, options)
+}
+
+// fixupPrefixes are the subject prefixes of commits folded by autosquash
+var fixupPrefixes = []string{"fixup! ", "amend! ", "squash! "}
+
+// fixupTarget returns the subject, or hash prefix, that a "fixup!", "amend!"
+// or "squash!" commit refers to, with false for other commits
+func fixupTarget(subject string) (string, bool) {
+	found := false
+	for {
+		trimmed := false
+		for _, prefix := range fixupPrefixes {
+			if rest, ok := strings.CutPrefix(subject, prefix); ok {
+				subject, trimmed, found = rest, true, true
+			}
+		}
+		if !trimmed {
+			return subject, found
+		}
+	}
+}
+
+// IsFixupSubject reports whether a commit with subject is folded into an
+// earlier commit by git rebase --autosquash
+func IsFixupSubject(subject string) bool {
+	_, ok := fixupTarget(subject)
+	return ok
+}
+
+// autosquashSearch is the number of recent commits searched for fixup commits
+const autosquashSearch = 200
+
+// GetAutosquashBase returns the revision to rebase onto so that every
+// "fixup!" commit among the recent history can be folded into its target:
+// the parent of the oldest target, or "--root" when that is the root commit.
+// Merges cannot be rebased with autosquash, so targets behind one are refused.
+func (r *Repository) GetAutosquashBase() (string, error) {
+	pending := map[string]bool{}
+	base, merge := "", ""
+	for skip := 0; ; skip += autosquashSearch {
+		commits, err := r.QueryCommits(CommitQuery{Skip: skip, Limit: autosquashSearch})
+		if err != nil {
+			return "", err
+		}
+
+		for _, commit := range commits {
+			// Fixup commits newer than their targets are only searched for
+			// among the recent commits
+			if target, ok := fixupTarget(commit.Subject); ok && skip == 0 {
+				pending[target] = true
+				continue
+			}
+			if len(commit.Parents) > 1 && merge == "" {
+				merge = commit.ShortHash
+			}
+			if len(pending) == 0 {
+				continue
+			}
+			if merge != "" {
+				return "", fmt.Errorf("cannot autosquash across merge commit %s", merge)
+			}
+
+			for target := range pending {
+				if target == commit.Subject || (len(target) >= 4 && strings.HasPrefix(commit.Hash, target)) {
+					delete(pending, target)
+				}
+			}
+			if len(pending) == 0 {
+				base = "--root"
+				if len(commit.Parents) > 0 {
+					base = commit.Parents[0]
+				}
+				if skip > 0 {
+					return base, nil
+				}
+			}
+		}
+
+		switch {
+		case len(pending) == 0 && base != "":
+			return base, nil
+		case len(pending) == 0:
+			return "", fmt.Errorf("no fixup commits among the last %d commits", autosquashSearch)
+		case len(commits) < autosquashSearch:
+			return "", fmt.Errorf("no target commit found for %q", sortedKeys(pending)[0])
+		}
+	}
+}
+
+// AutosquashRebase folds every "fixup!" commit into its target with
+// git rebase -i --autosquash, accepting the todo list and messages as git
+// prepares them. When the rebase stops on a conflict, it is continued or
+// aborted like any other rebase in progress.
+func (r *Repository) AutosquashRebase() error {
+	base, err := r.GetAutosquashBase()
+	if err != nil {
+		return err
+	}
+
+	env := []string{"GIT_SEQUENCE_EDITOR=true", "GIT_EDITOR=true"}
+	if _, err := r.runGitCommandWithEnv(env, "rebase", "-i", "--autosquash", base); err != nil {
+		return fmt.Errorf("autosquash rebase stopped: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package git
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestFixupCommits(t *testing.T) {
+	repo, dir := setupCommittedTestRepo(t)
+
+	commitTestFile(t, repo, dir, "x.txt", "one\ntwo\nthree\n", "Add x")
+	commitTestFile(t, repo, dir, "x.txt", "one\ntwo\n3\n", "Change three")
+	commitTestFile(t, repo, dir, "y.txt", "y\n", "Add y")
+	addX, _ := repo.RunGitCommand("rev-parse", "HEAD~2")
+	changeThree, _ := repo.RunGitCommand("rev-parse", "HEAD~1")
+
+	if _, err := repo.GetAutosquashBase(); err == nil || !strings.Contains(err.Error(), "no fixup commits") {
+		t.Errorf("Expected no fixup commits, got %v", err)
+	}
+
+	// Added lines have no history to blame
+	writeTestFile(t, dir, "x.txt", "one\ntwo\n3\nfour\n")
+	if err := repo.StageFiles("x.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.SuggestFixupTargets(); err == nil {
+		t.Error("Expected pure additions to have no fixup target")
+	}
+
+	writeTestFile(t, dir, "x.txt", "ONE\ntwo\nTHREE\n")
+	if err := repo.StageFiles("x.txt"); err != nil {
+		t.Fatal(err)
+	}
+	candidates, err := repo.SuggestFixupTargets()
+	if err != nil {
+		t.Fatalf("Failed to suggest targets: %v", err)
+	}
+	var hashes []string
+	for _, candidate := range candidates {
+		hashes = append(hashes, candidate.Commit.Hash)
+		if candidate.Lines != 1 {
+			t.Errorf("Expected one blamed line, got %+v", candidate)
+		}
+	}
+	if !slices.Equal(hashes, []string{changeThree, addX}) {
+		t.Fatalf("Expected the most recent commit first, got %v", hashes)
+	}
+
+	if err := repo.CommitFixup(candidates[0].Commit.Hash, CommitOptions{}); err != nil {
+		t.Fatalf("Failed to commit fixup: %v", err)
+	}
+	if subject, _ := repo.RunGitCommand("log", "-1", "--format=%s"); subject != "fixup! Change three" {
+		t.Errorf("Unexpected fixup subject %q", subject)
+	}
+	commitTestFile(t, repo, dir, "z.txt", "z\n", "Add z")
+
+	if base, err := repo.GetAutosquashBase(); err != nil || base != addX {
+		t.Errorf("Expected to rebase onto the parent of the target, got %q (%v)", base, err)
+	}
+	if err := repo.AutosquashRebase(); err != nil {
+		t.Fatalf("Failed to autosquash: %v", err)
+	}
+
+	log, _ := repo.RunGitCommand("log", "--format=%s")
+	if log != "Add z\nAdd y\nChange three\nAdd x\nInitial commit" {
+		t.Errorf("Unexpected history %q", log)
+	}
+	if content, _ := repo.RunGitCommand("show", "HEAD~2:x.txt"); content != "ONE\ntwo\nTHREE" {
+		t.Errorf("Expected the fixup folded into its target, got %q", content)
+	}
+}
+
+func TestSuggestFixupTargetsHeaders(t *testing.T) {
+	repo, dir := setupCommittedTestRepo(t)
+
+	// A deleted "-- comment" line shows as "--- comment" in the hunk, and
+	// the header of a path with a space ends with a tab
+	commitTestFile(t, repo, dir, "schema.sql", "-- comment\na\nb\nc\nSELECT 1;\n", "Add schema")
+	commitTestFile(t, repo, dir, "my file.txt", "one\ntwo\n", "Add my file")
+	addSchema, _ := repo.RunGitCommand("rev-parse", "HEAD~1")
+	addMyFile, _ := repo.RunGitCommand("rev-parse", "HEAD")
+
+	writeTestFile(t, dir, "schema.sql", "a\nb\nc\nSELECT 2;\n")
+	writeTestFile(t, dir, "my file.txt", "one\nTWO\n")
+	if err := repo.StageFiles("schema.sql", "my file.txt"); err != nil {
+		t.Fatal(err)
+	}
+	candidates, err := repo.SuggestFixupTargets()
+	if err != nil {
+		t.Fatalf("Failed to suggest targets: %v", err)
+	}
+	if len(candidates) != 2 || candidates[0].Commit.Hash != addMyFile || candidates[1].Commit.Hash != addSchema || candidates[1].Lines != 2 {
+		t.Errorf("Expected both files to be blamed, got %+v", candidates)
+	}
+}
+
+func TestFixupTarget(t *testing.T) {
+	tests := []struct {
+		subject string
+		target  string
+		ok      bool
+	}{
+		{"fixup! Add x", "Add x", true},
+		{"fixup! fixup! Add x", "Add x", true},
+		{"amend! squash! Add x", "Add x", true},
+		{"Add x", "Add x", false},
+		{"fixup!Add x", "fixup!Add x", false},
+	}
+	for _, tt := range tests {
+		if target, ok := fixupTarget(tt.subject); target != tt.target || ok != tt.ok {
+			t.Errorf("fixupTarget(%q) = %q, %v", tt.subject, target, ok)
+		}
+		if IsFixupSubject(tt.subject) != tt.ok {
+			t.Errorf("IsFixupSubject(%q) != %v", tt.subject, tt.ok)
+		}
+	}
+}
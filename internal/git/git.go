@@ -3,9 +3,11 @@ package git
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -27,12 +29,19 @@ type FileStatus struct {
 
 // CommitInfo represents information about a Git commit
 type CommitInfo struct {
-	Hash      string
-	Author    string
-	Date      time.Time
-	Subject   string
-	Body      string
-	ShortHash string
+	Hash           string
+	Author         string
+	AuthorEmail    string
+	Date           time.Time // Author date
+	Committer      string
+	CommitterEmail string
+	CommitDate     time.Time
+	Subject        string
+	Body           string // Full message body without the subject line
+	ShortHash      string
+	Parents        []string // Parent commit hashes, more than one for merges
+	Refs           []string // Branch and tag names pointing at this commit
+	Signature      string   // Signature status from %G? (G, B, U, X, Y, R, E or N), empty unless queried
 }
 
 // DiffInfo represents diff information for files
@@ -90,8 +99,24 @@ func (r *Repository) GetWorkDir() string {
 
 // runGitCommand executes a Git command and returns the output
 func (r *Repository) runGitCommand(args ...string) (string, error) {
+	return r.runGitCommandWithEnv(nil, args...)
+}
+
+// runGitCommandWithEnv executes a Git command with additional environment
+// variables, e.g. "GIT_EDITOR=true", and returns the output
+func (r *Repository) runGitCommandWithEnv(env []string, args ...string) (string, error) {
+	return r.runGitCommandWithInput(env, nil, args...)
+}
+
+// runGitCommandWithInput executes a Git command reading stdin from input,
+// e.g. a message for "git interpret-trailers", and returns the output
+func (r *Repository) runGitCommandWithInput(env []string, input io.Reader, args ...string) (string, error) {
 	cmd := exec.Command("git", args...)
 	cmd.Dir = r.workDir
+	cmd.Stdin = input
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
 
 	output, err := cmd.CombinedOutput()
 	outputStr := string(output)
@@ -111,6 +136,23 @@ func (r *Repository) runGitCommand(args ...string) (string, error) {
 	return outputStr, nil
 }
 
+// runGitCommandSeparate executes a Git command and returns its stdout and
+// stderr separately, e.g. to tell the output of hooks, which Git writes to
+// stderr, from the result of the command
+func (r *Repository) runGitCommandSeparate(args ...string) (string, string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = r.workDir
+
+	var stdout, stderr strings.Builder
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+
+	logger.LogGitOperation("git", args, r.workDir, err == nil, strings.TrimRight(stdout.String()+stderr.String(), "\n"), err)
+
+	return strings.TrimRight(stdout.String(), "\n"), strings.TrimRight(stderr.String(), "\n"), err
+}
+
 // GetStatus returns the status of files in the repository
 func (r *Repository) GetStatus() ([]FileStatus, error) {
 	output, err := r.runGitCommand("status", "--porcelain=v1")
@@ -143,6 +185,12 @@ func (r *Repository) GetStatus() ([]FileStatus, error) {
 			Modified: unstagedChar != ' ' && status != "??", // Don't mark untracked files as modified
 		}
 
+		// Unmerged paths have no staged version until the conflict is resolved
+		if file.Unmerged() {
+			file.Staged = false
+			file.Modified = true
+		}
+
 		files = append(files, file)
 	}
 
@@ -277,11 +325,12 @@ func (r *Repository) generateUntrackedFileDiff(filePath string) (*DiffInfo, erro
 	}
 
 	return &DiffInfo{
-		FilePath: filePath,
-		OldPath:  "",
-		Status:   "A",
-		IsBinary: false,
-		Content:  diffContent.String(),
+		FilePath:  filePath,
+		OldPath:   "",
+		Status:    "A",
+		Additions: len(lines),
+		IsBinary:  false,
+		Content:   diffContent.String(),
 	}, nil
 }
 
@@ -418,7 +467,7 @@ func (r *Repository) AmendCommit(message string) error {
 	}
 
 	// Check if there are any commits to amend
-	_, err := r.GetLastCommitHash()
+	headBefore, err := r.GetLastCommitHash()
 	if err != nil {
 		return fmt.Errorf("no commits found to amend: %v", err)
 	}
@@ -431,6 +480,14 @@ func (r *Repository) AmendCommit(message string) error {
 		return fmt.Errorf("failed to amend commit: %v", err)
 	}
 
+	// Record the pre-amend HEAD so the amend can be undone
+	entry := newJournalEntry(OperationAmend)
+	entry.HeadBefore = headBefore
+	entry.HeadAfter, _ = r.GetLastCommitHash()
+	if err := r.appendJournal(entry); err != nil {
+		logger.Warn("Failed to record amend in journal", "error", err.Error())
+	}
+
 	logger.LogGitOperation("amend_commit", []string{"commit", "--amend", "-m", message}, r.workDir, true, "Commit amended successfully", nil)
 	return nil
 }
@@ -460,61 +517,226 @@ func (r *Repository) GetLastCommitMessage() (string, error) {
 
 // Commit creates a new commit with the specified message
 func (r *Repository) Commit(message string) error {
+	return r.CommitWithOptions(message, CommitOptions{})
+}
+
+// CommitOptions changes how a commit is created
+type CommitOptions struct {
+	Sign     SignMode
+	NoVerify bool // Skip the pre-commit and commit-msg hooks
+}
+
+// CommitWithOptions creates a commit with the specified message. Failures to
+// sign are reported as a *SigningError and commits rejected by a hook as a
+// *HookError.
+func (r *Repository) CommitWithOptions(message string, options CommitOptions) error {
 	if message == "" {
 		return fmt.Errorf("commit message cannot be empty")
 	}
+	return r.commit([]string{"commit", "-m", message}, options)
+}
 
-	_, err := r.runGitCommand("commit", "-m", message)
-	return err
+// signFlags returns the git commit or git rebase flags of a sign mode
+func signFlags(mode SignMode) []string {
+	switch mode {
+	case SignAlways:
+		return []string{"-S"}
+	case SignNever:
+		return []string{"--no-gpg-sign"}
+	}
+	return nil
 }
 
-// GetCommitHistory returns the commit history
-func (r *Repository) GetCommitHistory(limit int) ([]CommitInfo, error) {
-	args := []string{"log", "--pretty=format:%H|%an|%ad|%s|%b", "--date=iso"}
-	if limit > 0 {
-		args = append(args, fmt.Sprintf("-%d", limit))
+// commitFlags returns the git commit flags of the options
+func commitFlags(options CommitOptions) []string {
+	flags := signFlags(options.Sign)
+	if options.NoVerify {
+		flags = append(flags, "--no-verify")
 	}
+	return flags
+}
 
-	output, err := r.runGitCommand(args...)
+// commit runs git commit with args and the options
+func (r *Repository) commit(args []string, options CommitOptions) error {
+	args = append(args, commitFlags(options)...)
+
+	// Remember the state of the working tree to find files changed by hooks
+	var hooks []string
+	var before hookSnapshot
+	if !options.NoVerify {
+		var err error
+		if hooks, err = r.GetCommitHooks(); err != nil {
+			return err
+		}
+		if len(hooks) > 0 {
+			before = r.snapshotForHooks()
+		}
+	}
+
+	stdout, stderr, err := r.runGitCommandSeparate(args...)
+	if err == nil {
+		return nil
+	}
+	if signingFailed(stderr) {
+		config, configErr := r.GetSigningConfig()
+		if configErr == nil && config.Signs(options.Sign) {
+			return &SigningError{Format: config.Format, Output: stderr}
+		}
+	}
+	if len(hooks) > 0 && !failedInGit(err, stdout+stderr) {
+		return &HookError{Hooks: hooks, Output: stderr, Modified: r.filesModifiedByHooks(before)}
+	}
+	return fmt.Errorf("git command failed: %w\nOutput: %s", err, strings.TrimSpace(stdout+"\n"+stderr))
+}
+
+// commitLogFields lists the placeholders of commitLogFormat in order. Fields
+// are separated by %x1e and records are NUL terminated (git log -z). The raw
+// message comes last, so it may contain the field separator anywhere, and
+// any message content except NUL, which Git rejects, is parsed safely.
+var commitLogFields = []string{"%H", "%P", "%D", "%an", "%ae", "%aI", "%cn", "%ce", "%cI", "%G?", "%B"}
+
+// commitLogFormat returns the --pretty format used to read commit history.
+// Verifying signatures runs gpg or ssh-keygen for every commit, so without
+// signatures the %G? field is left empty.
+func commitLogFormat(signatures bool) string {
+	fields := commitLogFields
+	if !signatures {
+		fields = slices.Clone(fields)
+		fields[slices.Index(fields, "%G?")] = ""
+	}
+	return "format:" + strings.Join(fields, "%x1e")
+}
+
+// CommitQuery describes a commit history query. Empty fields are ignored.
+type CommitQuery struct {
+	Range   string // Revision range such as "main..feature", HEAD when empty
+	Author  string // --author pattern
+	Grep    string // --grep pattern matched against the message
+	Path    string // Only commits touching this path
+	Since   string // --since date, e.g. "2024-01-01" or "2 weeks ago"
+	Until   string // --until date
+	Pickaxe string // -S string whose number of occurrences changed
+	Skip    int    // Number of commits to skip, for pagination
+	Limit   int    // Maximum number of commits, 0 for no limit
+
+	Signatures bool // Verify signatures to fill CommitInfo.Signature
+}
+
+// IsFiltered reports whether the query restricts which commits are shown
+func (q CommitQuery) IsFiltered() bool {
+	return q.Range != "" || q.Author != "" || q.Grep != "" || q.Path != "" || q.Since != "" || q.Until != "" || q.Pickaxe != ""
+}
+
+// args returns the git log arguments for the query
+func (q CommitQuery) args() []string {
+	args := []string{"log", "-z", "--topo-order", "--no-show-signature", "--pretty=" + commitLogFormat(q.Signatures)}
+	if q.Author != "" {
+		args = append(args, "--author="+q.Author)
+	}
+	if q.Grep != "" {
+		args = append(args, "--grep="+q.Grep)
+	}
+	if q.Since != "" {
+		args = append(args, "--since="+q.Since)
+	}
+	if q.Until != "" {
+		args = append(args, "--until="+q.Until)
+	}
+	if q.Pickaxe != "" {
+		args = append(args, "-S"+q.Pickaxe)
+	}
+	if q.Skip > 0 {
+		args = append(args, fmt.Sprintf("--skip=%d", q.Skip))
+	}
+	if q.Limit > 0 {
+		args = append(args, fmt.Sprintf("-%d", q.Limit))
+	}
+	if q.Range != "" {
+		args = append(args, q.Range)
+	}
+	if q.Path != "" {
+		args = append(args, "--", q.Path)
+	}
+	return args
+}
+
+// GetCommitHistory returns the commit history in topological order, so that
+// children are always listed before their parents
+func (r *Repository) GetCommitHistory(limit int) ([]CommitInfo, error) {
+	return r.QueryCommits(CommitQuery{Limit: limit})
+}
+
+// QueryCommits returns the commits matching the query in topological order
+func (r *Repository) QueryCommits(query CommitQuery) ([]CommitInfo, error) {
+	// Read stdout only, signature verification may write to stderr
+	output, err := r.runGitCommandRaw(query.args()...)
 	if err != nil {
 		return nil, err
 	}
 
+	return parseCommitLog(string(output)), nil
+}
+
+// parseCommitLog parses the output of git log -z with commitLogFormat
+func parseCommitLog(output string) []CommitInfo {
 	var commits []CommitInfo
-	scanner := bufio.NewScanner(strings.NewReader(output))
 
-	for scanner.Scan() {
-		line := scanner.Text()
-		if line == "" {
+	for _, record := range strings.Split(output, "\x00") {
+		record = strings.TrimLeft(record, "\n")
+		if record == "" {
 			continue
 		}
 
-		parts := strings.SplitN(line, "|", 5)
-		if len(parts) < 4 {
+		parts := strings.SplitN(record, "\x1e", len(commitLogFields))
+		if len(parts) < len(commitLogFields) {
 			continue
 		}
 
 		commit := CommitInfo{
-			Hash:      parts[0],
-			Author:    parts[1],
-			Subject:   parts[3],
-			ShortHash: parts[0][:8],
+			Hash:           parts[0],
+			ShortHash:      ShortHash(parts[0]),
+			Parents:        strings.Fields(parts[1]),
+			Refs:           parseRefs(parts[2]),
+			Author:         parts[3],
+			AuthorEmail:    parts[4],
+			Committer:      parts[6],
+			CommitterEmail: parts[7],
+			Signature:      parts[9],
 		}
+		commit.Subject, commit.Body = splitMessage(parts[10])
 
-		// Parse date
-		if date, err := time.Parse("2006-01-02 15:04:05 -0700", parts[2]); err == nil {
+		// Parse strict ISO 8601 dates
+		if date, err := time.Parse(time.RFC3339, parts[5]); err == nil {
 			commit.Date = date
 		}
-
-		// Add body if present
-		if len(parts) == 5 {
-			commit.Body = parts[4]
+		if date, err := time.Parse(time.RFC3339, parts[8]); err == nil {
+			commit.CommitDate = date
 		}
 
 		commits = append(commits, commit)
 	}
 
-	return commits, scanner.Err()
+	return commits
+}
+
+// splitMessage splits a raw commit message into the subject and body like
+// %s and %b: the subject is the first paragraph joined into one line
+func splitMessage(message string) (string, string) {
+	subject, body, _ := strings.Cut(strings.TrimLeft(message, "\n"), "\n\n")
+	subject = strings.ReplaceAll(strings.TrimRight(subject, "\n"), "\n", " ")
+	return subject, strings.Trim(body, "\n")
+}
+
+// parseRefs splits a %D decoration ("HEAD -> main, tag: v1.0, origin/main") into ref names
+func parseRefs(decoration string) []string {
+	var refs []string
+	for _, ref := range strings.Split(decoration, ",") {
+		ref = strings.TrimSpace(ref)
+		if ref != "" {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
 }
 
 // GetCurrentBranch returns the current branch name
@@ -603,16 +825,27 @@ func (r *Repository) DiscardChanges(filePath string) error {
 
 	switch fileStatus.Status {
 	case "??": // Untracked file
-		// Remove untracked file
-		fullPath := filepath.Join(r.workDir, filePath)
-		err := os.Remove(fullPath)
+		// Move untracked file to the journal trash so it can be restored
+		entry, err := r.moveToTrash(*fileStatus)
 		if err != nil {
 			logger.LogGitOperation("discard_changes", []string{filePath}, r.workDir, false, "", fmt.Errorf("failed to remove untracked file: %v", err))
 			return fmt.Errorf("failed to remove untracked file %s: %v", filePath, err)
 		}
-		logger.LogGitOperation("discard_changes", []string{filePath}, r.workDir, true, "Untracked file removed", nil)
+		if err := r.appendJournal(entry); err != nil {
+			return fmt.Errorf("failed to record discard of %s: %v", filePath, err)
+		}
+		logger.LogGitOperation("discard_changes", []string{filePath}, r.workDir, true, "Untracked file moved to trash", nil)
 
 	default:
+		// Snapshot index and working tree content before touching them
+		entry, err := r.snapshotFile(OperationDiscard, *fileStatus)
+		if err != nil {
+			return fmt.Errorf("failed to snapshot file %s: %v", filePath, err)
+		}
+		if err := r.appendJournal(entry); err != nil {
+			return fmt.Errorf("failed to record discard of %s: %v", filePath, err)
+		}
+
 		// For tracked files, handle staged and unstaged changes
 		if fileStatus.Staged {
 			// First unstage the file
@@ -190,6 +190,239 @@ func TestCommit(t *testing.T) {
 	}
 }
 
+func TestGetCommitHistoryParentsAndRefs(t *testing.T) {
+	repo, tempDir := setupCommittedTestRepo(t)
+
+	if err := runCommand(tempDir, "git", "checkout", "-q", "-b", "feature"); err != nil {
+		t.Fatalf("Failed to create branch: %v", err)
+	}
+	writeTestFile(t, tempDir, "feature.txt", "feature\n")
+	if err := repo.StageFiles("feature.txt"); err != nil {
+		t.Fatalf("Failed to stage file: %v", err)
+	}
+	if err := repo.Commit("Add feature"); err != nil {
+		t.Fatalf("Failed to commit: %v", err)
+	}
+
+	if err := runCommand(tempDir, "git", "checkout", "-q", "-"); err != nil {
+		t.Fatalf("Failed to switch back: %v", err)
+	}
+	if err := runCommand(tempDir, "git", "merge", "-q", "--no-ff", "-m", "Merge feature", "feature"); err != nil {
+		t.Fatalf("Failed to merge: %v", err)
+	}
+	if err := runCommand(tempDir, "git", "tag", "v1.0.0"); err != nil {
+		t.Fatalf("Failed to tag: %v", err)
+	}
+
+	commits, err := repo.GetCommitHistory(10)
+	if err != nil {
+		t.Fatalf("Failed to get commit history: %v", err)
+	}
+	if len(commits) != 3 {
+		t.Fatalf("Expected 3 commits, got %d", len(commits))
+	}
+
+	merge := commits[0]
+	if len(merge.Parents) != 2 {
+		t.Fatalf("Expected merge commit with 2 parents, got %v", merge.Parents)
+	}
+	if merge.Parents[1] != commits[1].Hash {
+		t.Errorf("Expected second parent %s, got %s", commits[1].Hash, merge.Parents[1])
+	}
+
+	foundTag := false
+	for _, ref := range merge.Refs {
+		if ref == "tag: v1.0.0" {
+			foundTag = true
+		}
+	}
+	if !foundTag {
+		t.Errorf("Expected tag ref on merge commit, got %v", merge.Refs)
+	}
+
+	if len(commits[2].Parents) != 0 {
+		t.Errorf("Expected root commit without parents, got %v", commits[2].Parents)
+	}
+}
+
+func TestGetCommitHistoryPathologicalMessages(t *testing.T) {
+	repo, tempDir := setupCommittedTestRepo(t)
+
+	subject := "fix: handle a|b|c in parser"
+	body := "First paragraph | with pipes\n\nabc1234|Someone|2024-01-01\n\nSigned-off-by: Dev <dev@example.com>"
+
+	writeTestFile(t, tempDir, "pipes.txt", "pipes\n")
+	if err := repo.StageFiles("pipes.txt"); err != nil {
+		t.Fatalf("Failed to stage file: %v", err)
+	}
+	if err := repo.Commit(subject + "\n\n" + body); err != nil {
+		t.Fatalf("Failed to commit: %v", err)
+	}
+
+	commits, err := repo.GetCommitHistory(10)
+	if err != nil {
+		t.Fatalf("Failed to get commit history: %v", err)
+	}
+	if len(commits) != 2 {
+		t.Fatalf("Expected 2 commits, got %d", len(commits))
+	}
+
+	commit := commits[0]
+	if commit.Subject != subject {
+		t.Errorf("Expected subject %q, got %q", subject, commit.Subject)
+	}
+	if commit.Body != body {
+		t.Errorf("Expected body %q, got %q", body, commit.Body)
+	}
+	if commit.Author != "Test User" || commit.AuthorEmail != "test@example.com" {
+		t.Errorf("Unexpected author %q <%q>", commit.Author, commit.AuthorEmail)
+	}
+	if commit.Committer != "Test User" || commit.CommitterEmail != "test@example.com" {
+		t.Errorf("Unexpected committer %q <%q>", commit.Committer, commit.CommitterEmail)
+	}
+	if commit.Date.IsZero() || commit.CommitDate.IsZero() {
+		t.Error("Expected author and commit dates to be parsed")
+	}
+	if commit.Signature != "" {
+		t.Errorf("Expected signatures to be left unverified, got %q", commit.Signature)
+	}
+	signed, err := repo.QueryCommits(CommitQuery{Limit: 1, Signatures: true})
+	if err != nil {
+		t.Fatalf("Failed to query commits: %v", err)
+	}
+	if len(signed) != 1 || signed[0].Signature != "N" || signed[0].Subject != subject {
+		t.Errorf("Expected an unsigned commit, got %+v", signed)
+	}
+	if len(commit.Parents) != 1 || commit.Parents[0] != commits[1].Hash {
+		t.Errorf("Expected parent %s, got %v", commits[1].Hash, commit.Parents)
+	}
+	if commits[1].Subject != "Initial commit" {
+		t.Errorf("Expected second commit to be the initial commit, got %q", commits[1].Subject)
+	}
+}
+
+func TestParseCommitLog(t *testing.T) {
+	record := func(subject, body string) string {
+		fields := []string{
+			"0123456789abcdef0123456789abcdef01234567",
+			"89abcdef0123456789abcdef0123456789abcdef",
+			"HEAD -> main, tag: v1.0.0",
+			"Jane | Doe", "jane@example.com", "2024-03-01T10:20:30+09:00",
+			"John", "john@example.com", "2024-03-02T11:00:00Z",
+			"G", subject + "\n\n" + body,
+		}
+		return strings.Join(fields, "\x1e")
+	}
+
+	tests := []struct {
+		name    string
+		output  string
+		count   int
+		subject string
+		body    string
+	}{
+		{"pipes in subject", record("a | b | c", ""), 1, "a | b | c", ""},
+		{"multi-line body", record("subject", "line 1\nline 2\n\nline 4\n"), 1, "subject", "line 1\nline 2\n\nline 4"},
+		{"body that looks like a record", record("subject", "deadbeef|x|y|z\nfoo"), 1, "subject", "deadbeef|x|y|z\nfoo"},
+		{"separator in body", record("subject", "a\x1eb"), 1, "subject", "a\x1eb"},
+		{"separator in subject", record("a\x1eb", "body"), 1, "a\x1eb", "body"},
+		{"wrapped subject", record("first line\nsecond line", "body"), 1, "first line second line", "body"},
+		{"empty subject", record("", ""), 1, "", ""},
+		{"multiple records", record("one", "") + "\x00" + record("two", "body"), 2, "one", ""},
+		{"empty output", "", 0, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commits := parseCommitLog(tt.output)
+			if len(commits) != tt.count {
+				t.Fatalf("Expected %d commits, got %d", tt.count, len(commits))
+			}
+			if tt.count == 0 {
+				return
+			}
+
+			commit := commits[0]
+			if commit.Subject != tt.subject {
+				t.Errorf("Expected subject %q, got %q", tt.subject, commit.Subject)
+			}
+			if commit.Body != tt.body {
+				t.Errorf("Expected body %q, got %q", tt.body, commit.Body)
+			}
+			if commit.Author != "Jane | Doe" || commit.AuthorEmail != "jane@example.com" {
+				t.Errorf("Unexpected author %q <%s>", commit.Author, commit.AuthorEmail)
+			}
+			if commit.Committer != "John" || commit.CommitterEmail != "john@example.com" {
+				t.Errorf("Unexpected committer %q <%s>", commit.Committer, commit.CommitterEmail)
+			}
+			if commit.Date.Year() != 2024 || commit.CommitDate.Day() != 2 {
+				t.Errorf("Unexpected dates %v / %v", commit.Date, commit.CommitDate)
+			}
+			if commit.ShortHash != "01234567" || len(commit.Parents) != 1 {
+				t.Errorf("Unexpected hash %q or parents %v", commit.ShortHash, commit.Parents)
+			}
+			if len(commit.Refs) != 2 || commit.Refs[1] != "tag: v1.0.0" {
+				t.Errorf("Unexpected refs %v", commit.Refs)
+			}
+			if commit.Signature != "G" {
+				t.Errorf("Expected signature status G, got %q", commit.Signature)
+			}
+		})
+	}
+}
+
+func TestQueryCommits(t *testing.T) {
+	repo, tempDir := setupCommittedTestRepo(t)
+
+	commitFile := func(name, content, message, author string) {
+		t.Helper()
+		writeTestFile(t, tempDir, name, content)
+		if err := repo.StageFiles(name); err != nil {
+			t.Fatalf("Failed to stage file: %v", err)
+		}
+		if err := runCommand(tempDir, "git", "commit", "-q", "-m", message, "--author", author); err != nil {
+			t.Fatalf("Failed to commit: %v", err)
+		}
+	}
+
+	commitFile("docs/guide.md", "guide\n", "docs: add guide", "Alice <alice@example.com>")
+	commitFile("main.go", "package main\n\nfunc needle() {}\n", "feat: add needle", "Bob <bob@example.com>")
+	commitFile("docs/guide.md", "guide\nmore\n", "docs: extend guide", "Alice <alice@example.com>")
+
+	tests := []struct {
+		name     string
+		query    CommitQuery
+		expected []string
+	}{
+		{"no filter", CommitQuery{}, []string{"docs: extend guide", "feat: add needle", "docs: add guide", "Initial commit"}},
+		{"author", CommitQuery{Author: "alice"}, []string{"docs: extend guide", "docs: add guide"}},
+		{"grep", CommitQuery{Grep: "needle"}, []string{"feat: add needle"}},
+		{"path", CommitQuery{Path: "docs"}, []string{"docs: extend guide", "docs: add guide"}},
+		{"pickaxe", CommitQuery{Pickaxe: "func needle"}, []string{"feat: add needle"}},
+		{"until", CommitQuery{Until: "2000-01-01"}, nil},
+		{"since", CommitQuery{Since: "2000-01-01", Limit: 1}, []string{"docs: extend guide"}},
+		{"page", CommitQuery{Skip: 1, Limit: 2}, []string{"feat: add needle", "docs: add guide"}},
+		{"combined", CommitQuery{Author: "alice", Path: "docs", Skip: 1}, []string{"docs: add guide"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commits, err := repo.QueryCommits(tt.query)
+			if err != nil {
+				t.Fatalf("Failed to query commits: %v", err)
+			}
+
+			var subjects []string
+			for _, commit := range commits {
+				subjects = append(subjects, commit.Subject)
+			}
+			if strings.Join(subjects, "\n") != strings.Join(tt.expected, "\n") {
+				t.Errorf("Expected %v, got %v", tt.expected, subjects)
+			}
+		})
+	}
+}
+
 func TestGetDiff(t *testing.T) {
 	repo, tempDir := setupTestRepo(t)
 	defer func() { _ = logger.Close() }()
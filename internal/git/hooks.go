@@ -0,0 +1,110 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// commitHooks are the hooks run by git commit that --no-verify skips
+var commitHooks = []string{"pre-commit", "commit-msg"}
+
+// HookError reports a commit rejected by a pre-commit or commit-msg hook
+type HookError struct {
+	Hooks    []string // Installed hooks, one of which rejected the commit
+	Output   string   // What the hooks printed
+	Modified []string // Staged files the hooks changed in the working tree, e.g. formatters
+}
+
+func (e *HookError) Error() string {
+	return fmt.Sprintf("commit rejected by the %s hook", strings.Join(e.Hooks, " or "))
+}
+
+// GetCommitHooks returns the installed hooks that git commit runs and
+// --no-verify skips, honoring core.hooksPath
+func (r *Repository) GetCommitHooks() ([]string, error) {
+	var hooks []string
+	for _, hook := range commitHooks {
+		path, err := r.runGitCommand("rev-parse", "--git-path", "hooks/"+hook)
+		if err != nil {
+			return nil, err
+		}
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(r.workDir, path)
+		}
+		if info, err := os.Stat(path); err == nil && !info.IsDir() && info.Mode()&0111 != 0 {
+			hooks = append(hooks, hook)
+		}
+	}
+	return hooks, nil
+}
+
+// hookSnapshot records the staged files and the files with unstaged changes
+// before hooks run
+type hookSnapshot struct {
+	staged   []string
+	unstaged map[string]bool
+}
+
+// snapshotForHooks records the working tree before committing. Files that
+// cannot be listed are simply not reported as modified by hooks.
+func (r *Repository) snapshotForHooks() hookSnapshot {
+	snapshot := hookSnapshot{unstaged: map[string]bool{}}
+	snapshot.staged, _ = r.changedFiles("--cached")
+	unstaged, _ := r.changedFiles()
+	for _, file := range unstaged {
+		snapshot.unstaged[file] = true
+	}
+	return snapshot
+}
+
+// filesModifiedByHooks returns the staged files that had no unstaged changes
+// before committing but have now, so the hooks changed them
+func (r *Repository) filesModifiedByHooks(before hookSnapshot) []string {
+	unstaged, err := r.changedFiles()
+	if err != nil {
+		return nil
+	}
+	now := map[string]bool{}
+	for _, file := range unstaged {
+		now[file] = true
+	}
+
+	var modified []string
+	for _, file := range before.staged {
+		if now[file] && !before.unstaged[file] {
+			modified = append(modified, file)
+		}
+	}
+	return modified
+}
+
+// changedFiles lists the files of git diff --name-only with extra arguments
+func (r *Repository) changedFiles(args ...string) ([]string, error) {
+	output, err := r.runGitCommandRaw(append([]string{"diff", "--name-only"}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
+
+// failedInGit reports whether git commit failed on its own rather than
+// because of a hook: nothing was staged, or git died with a fatal error and
+// exit status 128. A commit rejected by a hook exits with status 1 whatever
+// the hook printed.
+func failedInGit(err error, output string) bool {
+	if strings.Contains(output, "nothing to commit") || strings.Contains(output, "nothing added to commit") || strings.Contains(output, "no changes added to commit") {
+		return true
+	}
+	var exitErr *exec.ExitError
+	return errors.As(err, &exitErr) && exitErr.ExitCode() == 128
+}
@@ -0,0 +1,89 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func writeHook(t *testing.T, dir, name, script string) {
+	t.Helper()
+	path := filepath.Join(dir, ".git", "hooks", name)
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0755); err != nil {
+		t.Fatalf("Failed to write hook: %v", err)
+	}
+}
+
+func TestCommitHooks(t *testing.T) {
+	repo, dir := setupCommittedTestRepo(t)
+
+	if hooks, err := repo.GetCommitHooks(); err != nil || len(hooks) != 0 {
+		t.Fatalf("Expected no hooks, got %v (%v)", hooks, err)
+	}
+
+	// A formatter that rewrites staged files, then rejects the commit
+	writeHook(t, dir, "pre-commit", "echo 'reformatted tracked.txt' >&2\necho formatted >> tracked.txt\nexit 1\n")
+	if hooks, err := repo.GetCommitHooks(); err != nil || !slices.Equal(hooks, []string{"pre-commit"}) {
+		t.Fatalf("Expected the pre-commit hook, got %v (%v)", hooks, err)
+	}
+
+	writeTestFile(t, dir, "tracked.txt", "changed\n")
+	writeTestFile(t, dir, "other.txt", "untouched\n")
+	if err := repo.StageFiles("tracked.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("scratch\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var hookErr *HookError
+	err := repo.Commit("Change tracked file")
+	if !errors.As(err, &hookErr) {
+		t.Fatalf("Expected a hook error, got %v", err)
+	}
+	if !strings.Contains(hookErr.Output, "reformatted tracked.txt") || !slices.Equal(hookErr.Modified, []string{"tracked.txt"}) {
+		t.Errorf("Unexpected hook error %+v", hookErr)
+	}
+	if hookErr.Error() != "commit rejected by the pre-commit hook" {
+		t.Errorf("Unexpected message %q", hookErr.Error())
+	}
+
+	if err := repo.CommitWithOptions("Change tracked file", CommitOptions{NoVerify: true}); err != nil {
+		t.Fatalf("Expected --no-verify to skip the hook: %v", err)
+	}
+
+	// A passing hook does not hide why git commit failed
+	writeHook(t, dir, "pre-commit", "exit 0\n")
+	writeHook(t, dir, "commit-msg", "exit 0\n")
+	err = repo.Commit("Nothing staged")
+	if err == nil || errors.As(err, &hookErr) {
+		t.Errorf("Expected a plain error for an empty commit, got %v", err)
+	}
+	if err := repo.StageFiles("other.txt"); err != nil {
+		t.Fatal(err)
+	}
+	lock := filepath.Join(dir, ".git", "index.lock")
+	if err := os.WriteFile(lock, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	err = repo.Commit("Add other")
+	if err == nil || errors.As(err, &hookErr) || !strings.Contains(err.Error(), "index.lock") {
+		t.Errorf("Expected a plain error for a locked index, got %v", err)
+	}
+	if err := os.Remove(lock); err != nil {
+		t.Fatal(err)
+	}
+
+	writeHook(t, dir, "commit-msg", "echo 'error: subject must be lower case' >&2\nexit 1\n")
+	if err := repo.StageFiles("other.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Commit("Add other"); !errors.As(err, &hookErr) || !slices.Equal(hookErr.Hooks, []string{"pre-commit", "commit-msg"}) || len(hookErr.Modified) != 0 {
+		t.Errorf("Expected a commit-msg rejection without modified files, got %v", err)
+	} else if !strings.Contains(hookErr.Output, "error: subject must be lower case") {
+		t.Errorf("Expected the hook output, got %q", hookErr.Output)
+	}
+}
@@ -0,0 +1,376 @@
+package git
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mopemope/git-rovo/internal/logger"
+)
+
+// Journal operation types
+const (
+	OperationDiscard = "discard"
+	OperationReset   = "reset"
+	OperationAmend   = "amend"
+	OperationReword  = "reword"
+)
+
+// maxJournalEntries limits how many operations are kept for undo
+const maxJournalEntries = 100
+
+// JournalEntry records the state destroyed by an operation so it can be undone
+type JournalEntry struct {
+	ID        string    `json:"id"`
+	Operation string    `json:"operation"`
+	Time      time.Time `json:"time"`
+
+	// File operations (discard, reset)
+	Path         string      `json:"path,omitempty"`
+	Status       string      `json:"status,omitempty"`
+	IndexMode    string      `json:"index_mode,omitempty"`
+	IndexBlob    string      `json:"index_blob,omitempty"`
+	WorktreeBlob string      `json:"worktree_blob,omitempty"`
+	WorktreeMode os.FileMode `json:"worktree_mode,omitempty"`
+	TrashPath    string      `json:"trash_path,omitempty"`
+
+	// Commit operations (amend, reword)
+	HeadBefore string `json:"head_before,omitempty"`
+	HeadAfter  string `json:"head_after,omitempty"`
+	Commit     string `json:"commit,omitempty"` // Reworded commit
+}
+
+// Description returns a short human readable description of the entry
+func (e JournalEntry) Description() string {
+	switch e.Operation {
+	case OperationAmend:
+		return fmt.Sprintf("amend of %s", ShortHash(e.HeadBefore))
+	case OperationReword:
+		return fmt.Sprintf("reword of %s", ShortHash(e.Commit))
+	default:
+		return fmt.Sprintf("%s of %s", e.Operation, e.Path)
+	}
+}
+
+// GetGitDir returns the absolute path of the .git directory
+func (r *Repository) GetGitDir() (string, error) {
+	output, err := r.runGitCommand("rev-parse", "--absolute-git-dir")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(output), nil
+}
+
+// journalDir returns the directory holding the undo journal and trash
+func (r *Repository) journalDir() (string, error) {
+	gitDir, err := r.GetGitDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(gitDir, "rovo"), nil
+}
+
+// GetJournal returns the recorded operations, oldest first
+func (r *Repository) GetJournal() ([]JournalEntry, error) {
+	dir, err := r.journalDir()
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(filepath.Join(dir, "journal.jsonl"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open journal: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	var entries []JournalEntry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var entry JournalEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			return nil, fmt.Errorf("failed to parse journal entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, scanner.Err()
+}
+
+// writeJournal replaces the journal with the given entries
+func (r *Repository) writeJournal(entries []JournalEntry) error {
+	dir, err := r.journalDir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create journal directory: %w", err)
+	}
+
+	var content strings.Builder
+	for _, entry := range entries {
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return fmt.Errorf("failed to encode journal entry: %w", err)
+		}
+		content.Write(data)
+		content.WriteString("\n")
+	}
+
+	path := filepath.Join(dir, "journal.jsonl")
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, []byte(content.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// appendJournal records a new entry, dropping the oldest entries beyond the limit
+func (r *Repository) appendJournal(entry JournalEntry) error {
+	entries, err := r.GetJournal()
+	if err != nil {
+		return err
+	}
+
+	entries = append(entries, entry)
+	if len(entries) > maxJournalEntries {
+		for _, dropped := range entries[:len(entries)-maxJournalEntries] {
+			_ = r.removeTrash(dropped)
+		}
+		entries = entries[len(entries)-maxJournalEntries:]
+	}
+
+	logger.LogGitOperation("journal_append", []string{entry.Operation, entry.Path}, r.workDir, true, entry.Description(), nil)
+	return r.writeJournal(entries)
+}
+
+// newJournalEntry creates an entry with a unique ID for the given operation
+func newJournalEntry(operation string) JournalEntry {
+	now := time.Now()
+	return JournalEntry{
+		ID:        fmt.Sprintf("%d", now.UnixNano()),
+		Operation: operation,
+		Time:      now,
+	}
+}
+
+// snapshotFile records the index and working tree content of a tracked file
+func (r *Repository) snapshotFile(operation string, file FileStatus) (JournalEntry, error) {
+	entry := newJournalEntry(operation)
+	entry.Path = file.Path
+	entry.Status = file.Status
+
+	// Index entry: "<mode> <blob> <stage>\t<path>"
+	output, err := r.runGitCommand("ls-files", "-s", "--", file.Path)
+	if err != nil {
+		return entry, fmt.Errorf("failed to read index entry: %w", err)
+	}
+	if fields := strings.Fields(output); len(fields) >= 2 {
+		entry.IndexMode = fields[0]
+		entry.IndexBlob = fields[1]
+	}
+
+	// Working tree content, stored as a blob in the object database
+	fullPath := filepath.Join(r.workDir, file.Path)
+	if info, err := os.Stat(fullPath); err == nil && !info.IsDir() {
+		blob, err := r.runGitCommandRaw("hash-object", "-w", "--no-filters", "--", file.Path)
+		if err != nil {
+			return entry, fmt.Errorf("failed to snapshot %s: %w", file.Path, err)
+		}
+		entry.WorktreeBlob = strings.TrimSpace(string(blob))
+		entry.WorktreeMode = info.Mode().Perm()
+	}
+
+	return entry, nil
+}
+
+// moveToTrash moves an untracked path into the journal trash directory
+func (r *Repository) moveToTrash(file FileStatus) (JournalEntry, error) {
+	entry := newJournalEntry(OperationDiscard)
+	entry.Path = file.Path
+	entry.Status = file.Status
+
+	dir, err := r.journalDir()
+	if err != nil {
+		return entry, err
+	}
+
+	trashPath := filepath.Join(dir, "trash", entry.ID, filepath.FromSlash(strings.TrimSuffix(file.Path, "/")))
+	if err := os.MkdirAll(filepath.Dir(trashPath), 0755); err != nil {
+		return entry, fmt.Errorf("failed to create trash directory: %w", err)
+	}
+
+	if err := os.Rename(filepath.Join(r.workDir, file.Path), trashPath); err != nil {
+		return entry, fmt.Errorf("failed to move %s to trash: %w", file.Path, err)
+	}
+	entry.TrashPath = trashPath
+
+	return entry, nil
+}
+
+// removeTrash deletes the trash directory belonging to an entry
+func (r *Repository) removeTrash(entry JournalEntry) error {
+	if entry.TrashPath == "" {
+		return nil
+	}
+	dir, err := r.journalDir()
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(filepath.Join(dir, "trash", entry.ID))
+}
+
+// ResetFile replaces a tracked file in the index and working tree with its HEAD version
+func (r *Repository) ResetFile(filePath string) error {
+	if filePath == "" {
+		return fmt.Errorf("file path cannot be empty")
+	}
+
+	entry, err := r.snapshotFile(OperationReset, FileStatus{Path: filePath})
+	if err != nil {
+		return err
+	}
+	if err := r.appendJournal(entry); err != nil {
+		return fmt.Errorf("failed to record reset: %w", err)
+	}
+
+	_, err = r.runGitCommand("checkout", "HEAD", "--", filePath)
+	return err
+}
+
+// Undo restores the last n journaled operations, most recent first
+func (r *Repository) Undo(n int) ([]JournalEntry, error) {
+	entries, err := r.GetJournal()
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("nothing to undo")
+	}
+
+	if n <= 0 {
+		n = 1
+	}
+	if n > len(entries) {
+		n = len(entries)
+	}
+
+	var undone []JournalEntry
+	for i := len(entries) - 1; i >= len(entries)-n; i-- {
+		if err := r.restoreEntry(entries[i]); err != nil {
+			_ = r.writeJournal(entries[:i+1])
+			logger.LogGitOperation("undo", []string{entries[i].Operation, entries[i].Path}, r.workDir, false, "", err)
+			return undone, fmt.Errorf("failed to undo %s: %w", entries[i].Description(), err)
+		}
+		logger.LogGitOperation("undo", []string{entries[i].Operation, entries[i].Path}, r.workDir, true, entries[i].Description(), nil)
+		undone = append(undone, entries[i])
+	}
+
+	return undone, r.writeJournal(entries[:len(entries)-n])
+}
+
+// restoreEntry reverts a single journaled operation
+func (r *Repository) restoreEntry(entry JournalEntry) error {
+	switch {
+	case entry.Operation == OperationAmend || entry.Operation == OperationReword:
+		head, err := r.GetLastCommitHash()
+		if err != nil {
+			return err
+		}
+		if entry.HeadAfter != "" && head != entry.HeadAfter {
+			return fmt.Errorf("HEAD has moved since the %s (expected %s, found %s)", entry.Operation, ShortHash(entry.HeadAfter), ShortHash(head))
+		}
+		_, err = r.runGitCommand("reset", "--soft", entry.HeadBefore)
+		return err
+
+	case entry.TrashPath != "":
+		target := filepath.Join(r.workDir, entry.Path)
+		if _, err := os.Lstat(target); err == nil {
+			return fmt.Errorf("%s already exists", entry.Path)
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		if err := os.Rename(entry.TrashPath, target); err != nil {
+			return err
+		}
+		return r.removeTrash(entry)
+
+	default:
+		return r.restoreFileSnapshot(entry)
+	}
+}
+
+// restoreFileSnapshot writes back the index and working tree content of a snapshot
+func (r *Repository) restoreFileSnapshot(entry JournalEntry) error {
+	if entry.IndexBlob != "" {
+		cacheInfo := fmt.Sprintf("%s,%s,%s", entry.IndexMode, entry.IndexBlob, entry.Path)
+		if _, err := r.runGitCommand("update-index", "--add", "--cacheinfo", cacheInfo); err != nil {
+			return err
+		}
+	} else {
+		if _, err := r.runGitCommand("update-index", "--force-remove", "--", entry.Path); err != nil {
+			return err
+		}
+	}
+
+	fullPath := filepath.Join(r.workDir, entry.Path)
+	if entry.WorktreeBlob == "" {
+		if err := os.Remove(fullPath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	content, err := r.runGitCommandRaw("cat-file", "blob", entry.WorktreeBlob)
+	if err != nil {
+		return err
+	}
+	mode := entry.WorktreeMode
+	if mode == 0 {
+		mode = 0644
+	}
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(fullPath, content, mode); err != nil {
+		return err
+	}
+	return os.Chmod(fullPath, mode)
+}
+
+// runGitCommandRaw executes a Git command and returns its unmodified stdout
+func (r *Repository) runGitCommandRaw(args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = r.workDir
+
+	output, err := cmd.Output()
+	logger.LogGitOperation("git", args, r.workDir, err == nil, fmt.Sprintf("%d bytes", len(output)), err)
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("git command failed: %w\nOutput: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("git command failed: %w", err)
+	}
+
+	return output, nil
+}
+
+// ShortHash abbreviates a commit hash for display
+func ShortHash(hash string) string {
+	if len(hash) > 8 {
+		return hash[:8]
+	}
+	return hash
+}
@@ -0,0 +1,223 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mopemope/git-rovo/internal/logger"
+)
+
+// setupCommittedTestRepo creates a repository with one committed file and
+// keeps the test log outside the working tree
+func setupCommittedTestRepo(t *testing.T) (*Repository, string) {
+	t.Helper()
+
+	logPath := filepath.Join(t.TempDir(), "test.log")
+	if err := logger.Init(logPath, "info"); err != nil {
+		t.Fatalf("Failed to initialize logger: %v", err)
+	}
+	t.Cleanup(func() { _ = logger.Close() })
+
+	tempDir := t.TempDir()
+	repo, err := initGitRepo(tempDir)
+	if err != nil {
+		t.Fatalf("Failed to initialize git repository: %v", err)
+	}
+
+	writeTestFile(t, tempDir, "tracked.txt", "original\n")
+	if err := repo.StageFiles("tracked.txt"); err != nil {
+		t.Fatalf("Failed to stage file: %v", err)
+	}
+	if err := repo.Commit("Initial commit"); err != nil {
+		t.Fatalf("Failed to commit: %v", err)
+	}
+
+	return repo, tempDir
+}
+
+func writeTestFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+}
+
+func readTestFile(t *testing.T, dir, name string) string {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", name, err)
+	}
+	return string(content)
+}
+
+func TestUndoDiscardModifiedFile(t *testing.T) {
+	repo, tempDir := setupCommittedTestRepo(t)
+
+	writeTestFile(t, tempDir, "tracked.txt", "staged change\n")
+	if err := repo.StageFiles("tracked.txt"); err != nil {
+		t.Fatalf("Failed to stage file: %v", err)
+	}
+	writeTestFile(t, tempDir, "tracked.txt", "unstaged change\n")
+
+	if err := repo.DiscardChanges("tracked.txt"); err != nil {
+		t.Fatalf("Failed to discard changes: %v", err)
+	}
+	if got := readTestFile(t, tempDir, "tracked.txt"); got != "original\n" {
+		t.Fatalf("Expected file to be reverted, got %q", got)
+	}
+
+	undone, err := repo.Undo(1)
+	if err != nil {
+		t.Fatalf("Failed to undo: %v", err)
+	}
+	if len(undone) != 1 || undone[0].Operation != OperationDiscard {
+		t.Fatalf("Expected one discard to be undone, got %+v", undone)
+	}
+
+	if got := readTestFile(t, tempDir, "tracked.txt"); got != "unstaged change\n" {
+		t.Errorf("Expected working tree content to be restored, got %q", got)
+	}
+
+	staged, err := repo.RunGitCommand("show", ":tracked.txt")
+	if err != nil {
+		t.Fatalf("Failed to read index: %v", err)
+	}
+	if staged != "staged change" {
+		t.Errorf("Expected staged content to be restored, got %q", staged)
+	}
+
+	journal, err := repo.GetJournal()
+	if err != nil {
+		t.Fatalf("Failed to read journal: %v", err)
+	}
+	if len(journal) != 0 {
+		t.Errorf("Expected journal to be empty after undo, got %d entries", len(journal))
+	}
+}
+
+func TestUndoDiscardUntrackedFile(t *testing.T) {
+	repo, tempDir := setupCommittedTestRepo(t)
+
+	writeTestFile(t, tempDir, "scratch.txt", "precious notes\n")
+
+	if err := repo.DiscardChanges("scratch.txt"); err != nil {
+		t.Fatalf("Failed to discard untracked file: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, "scratch.txt")); !os.IsNotExist(err) {
+		t.Fatal("Expected untracked file to be removed from the working tree")
+	}
+
+	journal, err := repo.GetJournal()
+	if err != nil {
+		t.Fatalf("Failed to read journal: %v", err)
+	}
+	if len(journal) != 1 || journal[0].TrashPath == "" {
+		t.Fatalf("Expected trash entry in journal, got %+v", journal)
+	}
+
+	if _, err := repo.Undo(1); err != nil {
+		t.Fatalf("Failed to undo: %v", err)
+	}
+	if got := readTestFile(t, tempDir, "scratch.txt"); got != "precious notes\n" {
+		t.Errorf("Expected untracked file to be restored, got %q", got)
+	}
+	if _, err := os.Stat(journal[0].TrashPath); !os.IsNotExist(err) {
+		t.Error("Expected trash copy to be removed after restore")
+	}
+}
+
+func TestUndoResetFile(t *testing.T) {
+	repo, tempDir := setupCommittedTestRepo(t)
+
+	writeTestFile(t, tempDir, "tracked.txt", "work in progress\n")
+
+	if err := repo.ResetFile("tracked.txt"); err != nil {
+		t.Fatalf("Failed to reset file: %v", err)
+	}
+	if got := readTestFile(t, tempDir, "tracked.txt"); got != "original\n" {
+		t.Fatalf("Expected file to be reset, got %q", got)
+	}
+
+	if _, err := repo.Undo(1); err != nil {
+		t.Fatalf("Failed to undo: %v", err)
+	}
+	if got := readTestFile(t, tempDir, "tracked.txt"); got != "work in progress\n" {
+		t.Errorf("Expected reset to be undone, got %q", got)
+	}
+}
+
+func TestUndoAmendCommit(t *testing.T) {
+	repo, tempDir := setupCommittedTestRepo(t)
+
+	headBefore, err := repo.GetLastCommitHash()
+	if err != nil {
+		t.Fatalf("Failed to get HEAD: %v", err)
+	}
+
+	writeTestFile(t, tempDir, "tracked.txt", "amended\n")
+	if err := repo.StageFiles("tracked.txt"); err != nil {
+		t.Fatalf("Failed to stage file: %v", err)
+	}
+	if err := repo.AmendCommit("Amended commit"); err != nil {
+		t.Fatalf("Failed to amend: %v", err)
+	}
+
+	undone, err := repo.Undo(1)
+	if err != nil {
+		t.Fatalf("Failed to undo amend: %v", err)
+	}
+	if undone[0].HeadBefore != headBefore {
+		t.Errorf("Expected journal to record pre-amend HEAD %s, got %s", headBefore, undone[0].HeadBefore)
+	}
+
+	head, err := repo.GetLastCommitHash()
+	if err != nil {
+		t.Fatalf("Failed to get HEAD: %v", err)
+	}
+	if head != headBefore {
+		t.Errorf("Expected HEAD to be restored to %s, got %s", headBefore, head)
+	}
+
+	// The amended content stays staged after a soft reset
+	hasStaged, err := repo.HasStagedChanges()
+	if err != nil {
+		t.Fatalf("Failed to check staged changes: %v", err)
+	}
+	if !hasStaged {
+		t.Error("Expected amended changes to remain staged")
+	}
+}
+
+func TestUndoMultipleOperations(t *testing.T) {
+	repo, tempDir := setupCommittedTestRepo(t)
+
+	writeTestFile(t, tempDir, "a.txt", "a\n")
+	writeTestFile(t, tempDir, "b.txt", "b\n")
+	for _, name := range []string{"a.txt", "b.txt"} {
+		if err := repo.DiscardChanges(name); err != nil {
+			t.Fatalf("Failed to discard %s: %v", name, err)
+		}
+	}
+
+	undone, err := repo.Undo(5)
+	if err != nil {
+		t.Fatalf("Failed to undo: %v", err)
+	}
+	if len(undone) != 2 {
+		t.Fatalf("Expected 2 operations undone, got %d", len(undone))
+	}
+	if undone[0].Path != "b.txt" || undone[1].Path != "a.txt" {
+		t.Errorf("Expected most recent operation to be undone first, got %s then %s", undone[0].Path, undone[1].Path)
+	}
+
+	if _, err := repo.Undo(1); err == nil || !strings.Contains(err.Error(), "nothing to undo") {
+		t.Errorf("Expected 'nothing to undo' error, got %v", err)
+	}
+}
@@ -0,0 +1,106 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// maxPullRequestDiffLines limits the diff included in a pull request description
+const maxPullRequestDiffLines = 2000
+
+// pullRequestTemplatePaths are the locations of a pull request template
+// relative to the repository root, in lookup order
+var pullRequestTemplatePaths = []string{
+	".github/pull_request_template.md",
+	".github/PULL_REQUEST_TEMPLATE.md",
+	"PULL_REQUEST_TEMPLATE.md",
+	"pull_request_template.md",
+	"docs/pull_request_template.md",
+	"docs/PULL_REQUEST_TEMPLATE.md",
+}
+
+// PullRequestInfo describes the changes of the current branch against a base
+// branch, used to generate a pull request title and description
+type PullRequestInfo struct {
+	Base         string
+	Branch       string
+	MergeBase    string
+	Summary      *RangeSummary
+	Diff         string // Diff from the merge base to HEAD
+	Template     string // Content of the repository's pull request template
+	TemplatePath string
+}
+
+// GetPullRequestInfo collects the commits and the diff of HEAD since its
+// merge base with base, and the repository's pull request template if any
+func (r *Repository) GetPullRequestInfo(base string) (*PullRequestInfo, error) {
+	if base == "" {
+		base = "main"
+	}
+
+	mergeBase, err := r.runGitCommand("merge-base", base, "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("no merge base between %s and HEAD: %w", base, err)
+	}
+
+	summary, err := r.GetRangeSummary(mergeBase + "..HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := r.runGitCommandRaw("diff", mergeBase, "HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	branch, err := r.GetCurrentBranch()
+	if err != nil {
+		return nil, err
+	}
+	if branch == "" {
+		branch = "HEAD"
+	}
+
+	info := &PullRequestInfo{
+		Base:      base,
+		Branch:    branch,
+		MergeBase: mergeBase,
+		Summary:   summary,
+		Diff:      string(diff),
+	}
+	info.TemplatePath, info.Template = r.findPullRequestTemplate()
+	return info, nil
+}
+
+// findPullRequestTemplate returns the path and content of the first pull
+// request template found in the working tree
+func (r *Repository) findPullRequestTemplate() (string, string) {
+	root, err := r.runGitCommand("rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", ""
+	}
+	for _, path := range pullRequestTemplatePaths {
+		content, err := os.ReadFile(filepath.Join(root, path))
+		if err == nil && strings.TrimSpace(string(content)) != "" {
+			return path, string(content)
+		}
+	}
+	return "", ""
+}
+
+// Describe renders the branch, its commits, the diffstat and the diff as
+// plain text. Long diffs are cut after maxPullRequestDiffLines lines.
+func (p *PullRequestInfo) Describe() string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "Branch %s into %s (merge base %s)\n\n", p.Branch, p.Base, ShortHash(p.MergeBase))
+	text.WriteString(strings.ReplaceAll(p.Summary.Describe(), p.Summary.Range, p.Base+"..."+p.Branch))
+
+	lines := strings.Split(strings.TrimSuffix(p.Diff, "\n"), "\n")
+	if len(lines) > maxPullRequestDiffLines {
+		lines = append(lines[:maxPullRequestDiffLines], fmt.Sprintf("... (%d more lines)", len(lines)-maxPullRequestDiffLines))
+	}
+	fmt.Fprintf(&text, "\nDiff:\n%s\n", strings.Join(lines, "\n"))
+	return text.String()
+}
@@ -0,0 +1,67 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGetPullRequestInfo(t *testing.T) {
+	repo, dir := setupCommittedTestRepo(t)
+	mainBranch, _ := repo.GetCurrentBranch()
+
+	if err := repo.CreateBranch("feature", ""); err != nil {
+		t.Fatalf("Failed to create branch: %v", err)
+	}
+	if err := repo.CheckoutBranch("feature"); err != nil {
+		t.Fatalf("Failed to checkout feature: %v", err)
+	}
+	commitTestFile(t, repo, dir, "parser.go", "package parser\n", "feat: add parser")
+
+	// Commits on the base after the branch point are not described
+	if err := repo.CheckoutBranch(mainBranch); err != nil {
+		t.Fatalf("Failed to checkout main: %v", err)
+	}
+	commitTestFile(t, repo, dir, "main.txt", "main\n", "Main work")
+	if err := repo.CheckoutBranch("feature"); err != nil {
+		t.Fatalf("Failed to checkout feature: %v", err)
+	}
+
+	info, err := repo.GetPullRequestInfo(mainBranch)
+	if err != nil {
+		t.Fatalf("Failed to get pull request info: %v", err)
+	}
+	if info.Branch != "feature" || len(info.Summary.Commits) != 1 || info.Template != "" {
+		t.Errorf("Unexpected pull request info %+v", info)
+	}
+	if !strings.Contains(info.Diff, "+package parser") || strings.Contains(info.Diff, "main.txt") {
+		t.Errorf("Expected only the branch changes in the diff, got %q", info.Diff)
+	}
+
+	text := info.Describe()
+	for _, expected := range []string{"Branch feature into " + mainBranch, "Commits in " + mainBranch + "...feature", "feat: add parser", "+package parser"} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Expected %q in %q", expected, text)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, ".github"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	template := "## Summary\n\n## Testing\n"
+	if err := os.WriteFile(filepath.Join(dir, ".github", "pull_request_template.md"), []byte(template), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if info, err = repo.GetPullRequestInfo(mainBranch); err != nil || info.Template != template || info.TemplatePath != ".github/pull_request_template.md" {
+		t.Errorf("Expected the template to be found, got %+v (%v)", info, err)
+	}
+
+	// Nothing to describe on the base itself
+	if _, err := repo.GetPullRequestInfo("feature"); err == nil {
+		t.Error("Expected error without commits since the merge base")
+	}
+	if _, err := repo.GetPullRequestInfo("missing"); err == nil {
+		t.Error("Expected error for an unknown base")
+	}
+}
@@ -0,0 +1,65 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RangeSummary describes the commits of a revision range, used to generate
+// squash and merge commit messages
+type RangeSummary struct {
+	Range    string
+	Commits  []CommitInfo // Newest first
+	DiffStat string       // Aggregated diffstat of the whole range
+}
+
+// GetRangeSummary collects the commits of a range such as "main..feature"
+// and the diffstat of all their changes together. For "a..b" the diffstat
+// is taken from the merge base of a and b, like "git diff a...b".
+func (r *Repository) GetRangeSummary(revRange string) (*RangeSummary, error) {
+	from, to, found := strings.Cut(revRange, "..")
+	if !found || strings.HasPrefix(to, ".") {
+		if _, _, symmetric := strings.Cut(revRange, "..."); symmetric {
+			return nil, fmt.Errorf("symmetric ranges are not supported: %s", revRange)
+		}
+		return nil, fmt.Errorf("expected a range such as main..feature, got %q", revRange)
+	}
+	if from == "" {
+		from = "HEAD"
+	}
+	if to == "" {
+		to = "HEAD"
+	}
+
+	commits, err := r.QueryCommits(CommitQuery{Range: from + ".." + to})
+	if err != nil {
+		return nil, err
+	}
+	if len(commits) == 0 {
+		return nil, fmt.Errorf("no commits in %s", revRange)
+	}
+
+	diffStat, err := r.runGitCommand("diff", "--stat", "--summary", from+"..."+to)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RangeSummary{Range: revRange, Commits: commits, DiffStat: diffStat}, nil
+}
+
+// Describe renders the commits, oldest first, and the diffstat as plain text
+func (s *RangeSummary) Describe() string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "Commits in %s (%d, oldest first):\n", s.Range, len(s.Commits))
+	for i := len(s.Commits) - 1; i >= 0; i-- {
+		commit := s.Commits[i]
+		fmt.Fprintf(&text, "\n%s %s\n", commit.ShortHash, commit.Subject)
+		if body := strings.TrimSpace(commit.Body); body != "" {
+			for _, line := range strings.Split(body, "\n") {
+				text.WriteString("    " + line + "\n")
+			}
+		}
+	}
+	fmt.Fprintf(&text, "\nChanged files:\n%s\n", s.DiffStat)
+	return text.String()
+}
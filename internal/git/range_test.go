@@ -0,0 +1,55 @@
+package git
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGetRangeSummary(t *testing.T) {
+	repo, dir := setupCommittedTestRepo(t)
+	mainBranch, _ := repo.GetCurrentBranch()
+
+	if err := repo.CreateBranch("feature", ""); err != nil {
+		t.Fatalf("Failed to create branch: %v", err)
+	}
+	if err := repo.CheckoutBranch("feature"); err != nil {
+		t.Fatalf("Failed to checkout feature: %v", err)
+	}
+	commitTestFile(t, repo, dir, "parser.go", "package parser\n", "Add parser\n\nHandles the basic grammar.")
+	commitTestFile(t, repo, dir, "lexer.go", "package lexer\n", "Add lexer")
+
+	// Commits on main after the branch point are not part of the range
+	if err := repo.CheckoutBranch(mainBranch); err != nil {
+		t.Fatalf("Failed to checkout main: %v", err)
+	}
+	commitTestFile(t, repo, dir, "main.txt", "main\n", "Main work")
+
+	summary, err := repo.GetRangeSummary(mainBranch + "..feature")
+	if err != nil {
+		t.Fatalf("Failed to get range summary: %v", err)
+	}
+	if len(summary.Commits) != 2 || summary.Commits[0].Subject != "Add lexer" {
+		t.Fatalf("Expected the two feature commits newest first, got %+v", summary.Commits)
+	}
+	if !strings.Contains(summary.DiffStat, "parser.go") || !strings.Contains(summary.DiffStat, "lexer.go") || strings.Contains(summary.DiffStat, "main.txt") {
+		t.Errorf("Unexpected diffstat %q", summary.DiffStat)
+	}
+
+	text := summary.Describe()
+	parser := strings.Index(text, "Add parser\n    Handles the basic grammar.")
+	lexer := strings.Index(text, "Add lexer")
+	if parser < 0 || lexer < parser {
+		t.Errorf("Expected commits oldest first with bodies, got %q", text)
+	}
+
+	// An empty side means HEAD
+	if summary, err = repo.GetRangeSummary("feature.."); err != nil || len(summary.Commits) != 1 {
+		t.Errorf("Expected the main commit for feature.., got %+v (%v)", summary, err)
+	}
+
+	for _, revRange := range []string{"feature", mainBranch + "...feature", "feature.." + mainBranch + "~1"} {
+		if _, err := repo.GetRangeSummary(revRange); err == nil {
+			t.Errorf("Expected error for %q", revRange)
+		}
+	}
+}
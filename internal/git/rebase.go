@@ -0,0 +1,172 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/mopemope/git-rovo/internal/logger"
+)
+
+// RebaseAction is the command of an interactive rebase todo entry
+type RebaseAction string
+
+const (
+	RebasePick   RebaseAction = "pick"
+	RebaseReword RebaseAction = "reword"
+	RebaseSquash RebaseAction = "squash"
+	RebaseFixup  RebaseAction = "fixup"
+	RebaseDrop   RebaseAction = "drop"
+)
+
+// RebaseTodoEntry is a commit of an interactive rebase plan
+type RebaseTodoEntry struct {
+	Action  RebaseAction
+	Hash    string
+	Subject string
+	Message string // New message of a reword entry
+}
+
+// GetRebaseTodo returns the commits after base up to HEAD, oldest first, as
+// pick entries; with base "--root" the whole history is listed. A flat todo
+// list cannot replay merges, so ranges containing merge commits are refused.
+func (r *Repository) GetRebaseTodo(base string) ([]RebaseTodoEntry, error) {
+	revRange := "HEAD"
+	if base != "--root" {
+		if _, err := r.runGitCommand("merge-base", "--is-ancestor", base, "HEAD"); err != nil {
+			return nil, fmt.Errorf("%s is not an ancestor of HEAD", ShortHash(base))
+		}
+		revRange = base + "..HEAD"
+	}
+
+	commits, err := r.QueryCommits(CommitQuery{Range: revRange})
+	if err != nil {
+		return nil, err
+	}
+	if len(commits) == 0 {
+		return nil, fmt.Errorf("no commits after %s to rebase", ShortHash(base))
+	}
+
+	todo := make([]RebaseTodoEntry, 0, len(commits))
+	for _, commit := range slices.Backward(commits) {
+		if len(commit.Parents) > 1 {
+			return nil, fmt.Errorf("cannot rebase merge commit %s interactively", commit.ShortHash)
+		}
+		todo = append(todo, RebaseTodoEntry{Action: RebasePick, Hash: commit.Hash, Subject: commit.Subject})
+	}
+	return todo, nil
+}
+
+// ValidateRebaseTodo checks that a plan can be run: squash and fixup need an
+// earlier commit to fold into, and reworded commits need a message
+func ValidateRebaseTodo(todo []RebaseTodoEntry) error {
+	kept := 0
+	for _, entry := range todo {
+		switch entry.Action {
+		case RebaseDrop:
+			continue
+		case RebaseSquash, RebaseFixup:
+			if kept == 0 {
+				return fmt.Errorf("cannot %s %s without a previous commit", entry.Action, ShortHash(entry.Hash))
+			}
+		case RebaseReword:
+			if strings.TrimSpace(entry.Message) == "" {
+				return fmt.Errorf("no new message for reworded commit %s", ShortHash(entry.Hash))
+			}
+		case RebasePick:
+		default:
+			return fmt.Errorf("unknown rebase action %q", entry.Action)
+		}
+		kept++
+	}
+	if kept == 0 {
+		return fmt.Errorf("the plan drops every commit")
+	}
+	return nil
+}
+
+// RunInteractiveRebase runs git rebase -i onto base, or "--root", with the
+// plan. The todo list is written by a generated GIT_SEQUENCE_EDITOR script,
+// and reworded commits are picked and amended with their new message, kept
+// verbatim, by an exec line, as git would otherwise open an editor. The files
+// are removed once the rebase is done or aborted. Squashed messages are
+// combined without editing. The rewritten commits are signed as options asks,
+// and the amends run the commit hooks unless options.NoVerify is set. When
+// the rebase stops on a conflict, it is continued or aborted like any other
+// rebase in progress.
+func (r *Repository) RunInteractiveRebase(base string, todo []RebaseTodoEntry, options CommitOptions) error {
+	if err := ValidateRebaseTodo(todo); err != nil {
+		return err
+	}
+
+	journalDir, err := r.journalDir()
+	if err != nil {
+		return err
+	}
+	dir := filepath.Join(journalDir, "rebase")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create rebase directory: %w", err)
+	}
+
+	var lines strings.Builder
+	for i, entry := range todo {
+		subject, _, _ := strings.Cut(entry.Subject, "\n")
+		if entry.Action != RebaseReword {
+			fmt.Fprintf(&lines, "%s %s %s\n", entry.Action, entry.Hash, subject)
+			continue
+		}
+		messagePath := filepath.Join(dir, fmt.Sprintf("message-%d", i))
+		if err := os.WriteFile(messagePath, []byte(entry.Message+"\n"), 0644); err != nil {
+			return fmt.Errorf("failed to write message: %w", err)
+		}
+		fmt.Fprintf(&lines, "pick %s %s\n", entry.Hash, subject)
+		amend := append([]string{"git", "commit", "--amend", "--allow-empty", "--cleanup=verbatim"}, commitFlags(options)...)
+		fmt.Fprintf(&lines, "exec %s -F %s\n", strings.Join(amend, " "), shellQuote(messagePath))
+	}
+
+	todoPath := filepath.Join(dir, "todo")
+	if err := os.WriteFile(todoPath, []byte(lines.String()), 0644); err != nil {
+		return fmt.Errorf("failed to write rebase todo: %w", err)
+	}
+	scriptPath := filepath.Join(dir, "sequence-editor")
+	script := fmt.Sprintf("#!/bin/sh\ncat %s > \"$1\"\n", shellQuote(todoPath))
+	if err := os.WriteFile(scriptPath, []byte(script), 0755); err != nil {
+		return fmt.Errorf("failed to write sequence editor: %w", err)
+	}
+
+	env := []string{"GIT_SEQUENCE_EDITOR=" + shellQuote(scriptPath), "GIT_EDITOR=true"}
+	args := append([]string{"rebase", "-i"}, signFlags(options.Sign)...)
+	_, err = r.runGitCommandWithEnv(env, append(args, base)...)
+
+	// The todo list is read once; the messages are kept for the exec lines
+	// of a rebase that stopped until it is continued or aborted
+	_ = os.Remove(todoPath)
+	_ = os.Remove(scriptPath)
+	r.removeRebaseFiles()
+	if err != nil {
+		return fmt.Errorf("rebase onto %s stopped: %w", ShortHash(base), err)
+	}
+	return nil
+}
+
+// removeRebaseFiles removes the messages written by RunInteractiveRebase
+// once no rebase is in progress
+func (r *Repository) removeRebaseFiles() {
+	if state, err := r.GetRepositoryState(); err != nil || state.InProgress() {
+		return
+	}
+	journalDir, err := r.journalDir()
+	if err != nil {
+		return
+	}
+	if err := os.RemoveAll(filepath.Join(journalDir, "rebase")); err != nil {
+		logger.Warn("Failed to remove rebase files", "error", err.Error())
+	}
+}
+
+// shellQuote quotes a string for /bin/sh
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,157 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestInteractiveRebase(t *testing.T) {
+	repo, dir := setupCommittedTestRepo(t)
+	base, _ := repo.RunGitCommand("rev-parse", "HEAD")
+
+	commitTestFile(t, repo, dir, "b.txt", "b\n", "Add b")
+	commitTestFile(t, repo, dir, "c.txt", "c\n", "Add c")
+	commitTestFile(t, repo, dir, "d.txt", "d\n", "Add d")
+	commitTestFile(t, repo, dir, "e.txt", "e\n", "Add e")
+
+	todo, err := repo.GetRebaseTodo(base)
+	if err != nil {
+		t.Fatalf("Failed to get todo: %v", err)
+	}
+	var subjects []string
+	for _, entry := range todo {
+		if entry.Action != RebasePick {
+			t.Errorf("Expected pick entries, got %+v", entry)
+		}
+		subjects = append(subjects, entry.Subject)
+	}
+	if !slices.Equal(subjects, []string{"Add b", "Add c", "Add d", "Add e"}) {
+		t.Fatalf("Expected commits oldest first, got %v", subjects)
+	}
+
+	// Reword b, move e before c, fold d into it and drop c
+	b, c, d, e := todo[0], todo[1], todo[2], todo[3]
+	b.Action, b.Message = RebaseReword, "Add file b\n\nWith a body.\n#12 is fixed."
+	c.Action = RebaseDrop
+	d.Action = RebaseFixup
+	// The new message is kept verbatim, even with lines starting with #
+	if err := runCommand(dir, "git", "config", "commit.cleanup", "strip"); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.RunInteractiveRebase(base, []RebaseTodoEntry{b, e, d, c}, CommitOptions{}); err != nil {
+		t.Fatalf("Failed to rebase: %v", err)
+	}
+
+	log, _ := repo.RunGitCommand("log", "--format=%s", base+"..HEAD")
+	if log != "Add e\nAdd file b" {
+		t.Errorf("Unexpected history %q", log)
+	}
+	if body, _ := repo.RunGitCommand("log", "-1", "--format=%b", "HEAD~1"); strings.TrimSpace(body) != "With a body.\n#12 is fixed." {
+		t.Errorf("Expected the reworded body, got %q", body)
+	}
+	files, _ := repo.RunGitCommand("show", "--name-only", "--format=", "HEAD")
+	if files != "d.txt\ne.txt" {
+		t.Errorf("Expected d folded into e, got %q", files)
+	}
+	if out, _ := repo.RunGitCommand("ls-files"); strings.Contains(out, "c.txt") {
+		t.Errorf("Expected c to be dropped, got %q", out)
+	}
+	if state, _ := repo.GetRepositoryState(); state.InProgress() {
+		t.Errorf("Expected the rebase to finish, got %+v", state)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".git", "rovo", "rebase")); !os.IsNotExist(err) {
+		t.Errorf("Expected the rebase files to be removed, got %v", err)
+	}
+}
+
+func TestInteractiveRebaseStopped(t *testing.T) {
+	repo, dir := setupCommittedTestRepo(t)
+	base, _ := repo.RunGitCommand("rev-parse", "HEAD")
+	commitTestFile(t, repo, dir, "x.txt", "one\n", "Write one")
+	commitTestFile(t, repo, dir, "x.txt", "two\n", "Write two")
+
+	// Picking the second change first conflicts, before the reword runs
+	todo, err := repo.GetRebaseTodo(base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	todo[0].Action, todo[0].Message = RebaseReword, "Write one first"
+	if err := repo.RunInteractiveRebase(base, []RebaseTodoEntry{todo[1], todo[0]}, CommitOptions{}); err == nil {
+		t.Fatal("Expected the rebase to stop on a conflict")
+	}
+	rebaseDir := filepath.Join(dir, ".git", "rovo", "rebase")
+	if entries, _ := os.ReadDir(rebaseDir); len(entries) != 1 || entries[0].Name() != "message-1" {
+		t.Errorf("Expected only the pending message to be kept, got %v", entries)
+	}
+
+	if err := repo.AbortOperation(); err != nil {
+		t.Fatalf("Failed to abort: %v", err)
+	}
+	if _, err := os.Stat(rebaseDir); !os.IsNotExist(err) {
+		t.Errorf("Expected the rebase files to be removed, got %v", err)
+	}
+}
+
+func TestValidateRebaseTodo(t *testing.T) {
+	tests := []struct {
+		name string
+		todo []RebaseTodoEntry
+		want string
+	}{
+		{"empty", nil, "drops every commit"},
+		{"all dropped", []RebaseTodoEntry{{Action: RebaseDrop, Hash: "a"}}, "drops every commit"},
+		{"leading fixup", []RebaseTodoEntry{{Action: RebaseDrop, Hash: "a"}, {Action: RebaseFixup, Hash: "b"}}, "without a previous commit"},
+		{"reword without message", []RebaseTodoEntry{{Action: RebaseReword, Hash: "a", Message: " \n"}}, "no new message"},
+		{"unknown action", []RebaseTodoEntry{{Action: "edit", Hash: "a"}}, "unknown rebase action"},
+		{"valid", []RebaseTodoEntry{{Action: RebasePick, Hash: "a"}, {Action: RebaseSquash, Hash: "b"}}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRebaseTodo(tt.todo)
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("Expected a valid plan, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestGetRebaseTodoRefusesMerges(t *testing.T) {
+	repo, dir := setupCommittedTestRepo(t)
+	base, _ := repo.RunGitCommand("rev-parse", "HEAD")
+
+	if _, err := repo.GetRebaseTodo(base); err == nil {
+		t.Error("Expected an error without commits after the base")
+	}
+
+	mainBranch, _ := repo.GetCurrentBranch()
+	if _, err := repo.RunGitCommand("checkout", "-b", "side"); err != nil {
+		t.Fatal(err)
+	}
+	commitTestFile(t, repo, dir, "side.txt", "side\n", "Add side")
+	if _, err := repo.RunGitCommand("checkout", mainBranch); err != nil {
+		t.Fatal(err)
+	}
+	commitTestFile(t, repo, dir, "main.txt", "main\n", "Add main")
+	if _, err := repo.RunGitCommand("merge", "--no-ff", "-m", "Merge side", "side"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := repo.GetRebaseTodo(base); err == nil || !strings.Contains(err.Error(), "merge commit") {
+		t.Errorf("Expected merges to be refused, got %v", err)
+	}
+	if _, err := repo.GetRebaseTodo("side"); err == nil || !strings.Contains(err.Error(), "merge commit") {
+		t.Errorf("Expected merges to be refused, got %v", err)
+	}
+	if _, err := repo.GetRebaseTodo("0000000000000000000000000000000000000000"); err == nil {
+		t.Error("Expected an unknown base to be refused")
+	}
+}
@@ -0,0 +1,155 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/mopemope/git-rovo/internal/logger"
+)
+
+// ProgressFunc receives the progress lines git writes to stderr, such as
+// "Writing objects:  50% (1/2)"
+type ProgressFunc func(line string)
+
+// PushOptions controls what Push sends
+type PushOptions struct {
+	Remote      string // Remote to push to, the upstream remote when empty
+	Branch      string // Branch to push, the current branch when empty
+	SetUpstream bool   // Record Remote/Branch as the upstream of the branch
+}
+
+// UpstreamStatus describes how the current branch relates to its upstream
+type UpstreamStatus struct {
+	Upstream string // e.g. "origin/main", empty when no upstream is configured
+	Ahead    int    // Commits not pushed yet
+	Behind   int    // Upstream commits not pulled yet
+}
+
+// Push pushes the current branch, reporting progress as it goes
+func (r *Repository) Push(opts PushOptions, progress ProgressFunc) error {
+	args := []string{"push", "--progress"}
+	if opts.SetUpstream {
+		if opts.Remote == "" || opts.Branch == "" {
+			return fmt.Errorf("remote and branch are required to set the upstream")
+		}
+		args = append(args, "--set-upstream")
+	}
+	if opts.Remote != "" {
+		args = append(args, opts.Remote)
+		if opts.Branch != "" {
+			args = append(args, opts.Branch)
+		}
+	}
+
+	_, err := r.runGitCommandWithProgress(progress, args...)
+	return err
+}
+
+// Pull fetches and integrates the upstream of the current branch. The merge
+// commit message is not edited, so no editor is started.
+func (r *Repository) Pull(progress ProgressFunc) error {
+	_, err := r.runGitCommandWithProgress(progress, "pull", "--progress", "--no-edit")
+	return err
+}
+
+// Fetch fetches from the default remote
+func (r *Repository) Fetch(progress ProgressFunc) error {
+	_, err := r.runGitCommandWithProgress(progress, "fetch", "--progress")
+	return err
+}
+
+// GetRemotes returns the names of the configured remotes
+func (r *Repository) GetRemotes() ([]string, error) {
+	output, err := r.runGitCommand("remote")
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(output), nil
+}
+
+// GetUpstreamStatus returns the upstream of the current branch and how many
+// commits HEAD is ahead of and behind it. Upstream is empty when the branch
+// has no upstream or HEAD is detached.
+func (r *Repository) GetUpstreamStatus() (UpstreamStatus, error) {
+	upstream, err := r.runGitCommand("rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{u}")
+	if err != nil {
+		if strings.Contains(upstream, "no upstream") || strings.Contains(upstream, "does not point to a branch") {
+			return UpstreamStatus{}, nil
+		}
+		return UpstreamStatus{}, err
+	}
+
+	ahead, behind, err := r.GetAheadBehind("HEAD", "@{u}")
+	if err != nil {
+		return UpstreamStatus{}, err
+	}
+	return UpstreamStatus{Upstream: upstream, Ahead: ahead, Behind: behind}, nil
+}
+
+// runGitCommandWithProgress executes a Git command, passing each progress line
+// on stderr to progress. Credential prompts are disabled so the command cannot
+// block on the terminal.
+func (r *Repository) runGitCommandWithProgress(progress ProgressFunc, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = r.workDir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	pipe, err := cmd.StderrPipe()
+	if err != nil {
+		return "", err
+	}
+	if err := cmd.Start(); err != nil {
+		return "", err
+	}
+
+	scanner := bufio.NewScanner(io.TeeReader(pipe, &stderr))
+	scanner.Split(scanProgressLines)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" && progress != nil {
+			progress(line)
+		}
+	}
+	err = cmd.Wait()
+
+	outputStr := strings.TrimRight(stdout.String()+collapseProgress(stderr.String()), "\n")
+	logger.LogGitOperation("git", args, r.workDir, err == nil, outputStr, err)
+
+	if err != nil {
+		return outputStr, fmt.Errorf("git command failed: %w\nOutput: %s", err, outputStr)
+	}
+	return outputStr, nil
+}
+
+// scanProgressLines is a bufio.SplitFunc that splits on both '\n' and the
+// '\r' git uses to redraw progress lines
+func scanProgressLines(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+// collapseProgress keeps only the final state of lines redrawn with '\r'
+func collapseProgress(output string) string {
+	lines := strings.Split(output, "\n")
+	for i, line := range lines {
+		if j := strings.LastIndex(strings.TrimRight(line, "\r"), "\r"); j >= 0 {
+			line = line[j+1:]
+		}
+		lines[i] = strings.TrimRight(line, "\r")
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,120 @@
+package git
+
+import (
+	"strings"
+	"testing"
+)
+
+// setupRemoteTestRepos creates a repository, a bare remote named origin and a
+// second clone of the remote
+func setupRemoteTestRepos(t *testing.T) (repo *Repository, dir, remoteDir, cloneDir string) {
+	t.Helper()
+
+	repo, dir = setupCommittedTestRepo(t)
+	remoteDir = t.TempDir()
+	if err := runCommand(remoteDir, "git", "init", "-q", "--bare"); err != nil {
+		t.Fatalf("Failed to create bare remote: %v", err)
+	}
+	if err := runCommand(dir, "git", "remote", "add", "origin", remoteDir); err != nil {
+		t.Fatalf("Failed to add remote: %v", err)
+	}
+	return repo, dir, remoteDir, t.TempDir()
+}
+
+func TestPushPullFetch(t *testing.T) {
+	repo, dir, remoteDir, cloneDir := setupRemoteTestRepos(t)
+	branch, _ := repo.GetCurrentBranch()
+
+	remotes, err := repo.GetRemotes()
+	if err != nil || len(remotes) != 1 || remotes[0] != "origin" {
+		t.Fatalf("Unexpected remotes %v (%v)", remotes, err)
+	}
+
+	status, err := repo.GetUpstreamStatus()
+	if err != nil {
+		t.Fatalf("Failed to get upstream status: %v", err)
+	}
+	if status.Upstream != "" {
+		t.Errorf("Expected no upstream before the first push, got %+v", status)
+	}
+
+	if err := repo.Push(PushOptions{SetUpstream: true}, nil); err == nil {
+		t.Error("Expected error when setting the upstream without remote and branch")
+	}
+
+	var progress []string
+	err = repo.Push(PushOptions{Remote: "origin", Branch: branch, SetUpstream: true}, func(line string) {
+		progress = append(progress, line)
+	})
+	if err != nil {
+		t.Fatalf("Failed to push: %v", err)
+	}
+	if len(progress) == 0 {
+		t.Error("Expected progress lines while pushing")
+	}
+
+	status, _ = repo.GetUpstreamStatus()
+	if status.Upstream != "origin/"+branch || status.Ahead != 0 || status.Behind != 0 {
+		t.Errorf("Expected up to date upstream, got %+v", status)
+	}
+
+	commitTestFile(t, repo, dir, "local.txt", "local\n", "Local commit")
+	if status, _ = repo.GetUpstreamStatus(); status.Ahead != 1 || status.Behind != 0 {
+		t.Errorf("Expected 1 commit ahead, got %+v", status)
+	}
+	if err := repo.Push(PushOptions{}, nil); err != nil {
+		t.Fatalf("Failed to push to upstream: %v", err)
+	}
+
+	// Another clone pushes a commit that is fetched and pulled
+	if err := runCommand(cloneDir, "git", "clone", "-q", remoteDir, "."); err != nil {
+		t.Fatalf("Failed to clone: %v", err)
+	}
+	for _, args := range [][]string{
+		{"config", "user.name", "Other User"},
+		{"config", "user.email", "other@example.com"},
+		{"commit", "-q", "--allow-empty", "-m", "Remote commit"},
+		{"push", "-q"},
+	} {
+		if err := runCommand(cloneDir, "git", args...); err != nil {
+			t.Fatalf("git %v failed: %v", args, err)
+		}
+	}
+
+	if err := repo.Fetch(nil); err != nil {
+		t.Fatalf("Failed to fetch: %v", err)
+	}
+	if status, _ = repo.GetUpstreamStatus(); status.Ahead != 0 || status.Behind != 1 {
+		t.Errorf("Expected 1 commit behind after fetch, got %+v", status)
+	}
+
+	if err := repo.Pull(nil); err != nil {
+		t.Fatalf("Failed to pull: %v", err)
+	}
+	if status, _ = repo.GetUpstreamStatus(); status.Ahead != 0 || status.Behind != 0 {
+		t.Errorf("Expected up to date after pull, got %+v", status)
+	}
+	if subject, _ := repo.RunGitCommand("log", "-1", "--format=%s"); subject != "Remote commit" {
+		t.Errorf("Expected pulled commit at HEAD, got %q", subject)
+	}
+}
+
+func TestPushFailureOutput(t *testing.T) {
+	repo, _, _, _ := setupRemoteTestRepos(t)
+
+	err := repo.Push(PushOptions{Remote: "missing", Branch: "main"}, nil)
+	if err == nil {
+		t.Fatal("Expected push to an unknown remote to fail")
+	}
+	if !strings.Contains(err.Error(), "missing") {
+		t.Errorf("Expected git's error output in %q", err.Error())
+	}
+}
+
+func TestCollapseProgress(t *testing.T) {
+	output := "Counting objects:  50% (1/2)\rCounting objects: 100% (2/2)\rCounting objects: 100% (2/2), done.\nTo origin\n"
+	expected := "Counting objects: 100% (2/2), done.\nTo origin\n"
+	if got := collapseProgress(output); got != expected {
+		t.Errorf("collapseProgress() = %q, want %q", got, expected)
+	}
+}
@@ -0,0 +1,109 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/mopemope/git-rovo/internal/logger"
+)
+
+// GetUpstreamContaining returns the upstream of the current branch when the
+// commit is reachable from it, i.e. already pushed, or an empty string
+func (r *Repository) GetUpstreamContaining(hash string) (string, error) {
+	status, err := r.GetUpstreamStatus()
+	if err != nil || status.Upstream == "" {
+		return "", err
+	}
+
+	_, err = r.runGitCommandRaw("merge-base", "--is-ancestor", hash, "@{u}")
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return status.Upstream, nil
+}
+
+// RewordCommit replaces the message of a commit of the current branch. HEAD
+// is amended without the staged changes; older commits are reworded by an
+// interactive rebase onto their parent, which rewrites their descendants with
+// the same trees. Both sign and run the commit hooks as options asks. The
+// previous HEAD is journaled so the reword can be undone.
+func (r *Repository) RewordCommit(hash, message string, options CommitOptions) error {
+	if strings.TrimSpace(message) == "" {
+		return fmt.Errorf("commit message cannot be empty")
+	}
+
+	headBefore, err := r.GetLastCommitHash()
+	if err != nil {
+		return fmt.Errorf("no commits found to reword: %v", err)
+	}
+	commit, err := r.GetCommitDetails(hash)
+	if err != nil {
+		return err
+	}
+
+	if commit.Hash == headBefore {
+		err = r.commit([]string{"commit", "--amend", "--only", "--allow-empty", "--cleanup=verbatim", "-m", message}, options)
+	} else {
+		err = r.rewordWithRebase(commit.CommitInfo, message, options)
+	}
+	if err != nil {
+		return err
+	}
+
+	entry := newJournalEntry(OperationReword)
+	entry.HeadBefore = headBefore
+	entry.HeadAfter, _ = r.GetLastCommitHash()
+	entry.Commit = commit.Hash
+	if err := r.appendJournal(entry); err != nil {
+		logger.Warn("Failed to record reword in journal", "error", err.Error())
+	}
+	return nil
+}
+
+// rewordWithRebase rewords a commit older than HEAD with a todo list that
+// picks every commit after its parent. Only messages change, so a rebase that
+// stops, e.g. on a rejected message or a signing failure, is aborted.
+func (r *Repository) rewordWithRebase(commit CommitInfo, message string, options CommitOptions) error {
+	if _, err := r.runGitCommand("merge-base", "--is-ancestor", commit.Hash, "HEAD"); err != nil {
+		return fmt.Errorf("%s is not on the current branch", commit.ShortHash)
+	}
+	if len(commit.Parents) > 1 {
+		return fmt.Errorf("cannot reword merge commit %s below HEAD", commit.ShortHash)
+	}
+
+	base := "--root"
+	if len(commit.Parents) == 1 {
+		base = commit.Parents[0]
+	}
+	todo, err := r.GetRebaseTodo(base)
+	if err != nil {
+		return err
+	}
+	for i := range todo {
+		if todo[i].Hash == commit.Hash {
+			todo[i].Action = RebaseReword
+			todo[i].Message = message
+		}
+	}
+	err = r.RunInteractiveRebase(base, todo, options)
+	if err == nil {
+		return nil
+	}
+	if state, stateErr := r.GetRepositoryState(); stateErr == nil && state.InProgress() {
+		if abortErr := r.AbortOperation(); abortErr != nil {
+			return fmt.Errorf("%w; aborting the rebase failed: %v", err, abortErr)
+		}
+	}
+	if signingFailed(err.Error()) {
+		if config, configErr := r.GetSigningConfig(); configErr == nil && config.Signs(options.Sign) {
+			return &SigningError{Format: config.Format, Output: err.Error()}
+		}
+	}
+	return err
+}
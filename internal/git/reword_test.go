@@ -0,0 +1,140 @@
+package git
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRewordCommit(t *testing.T) {
+	repo, dir, _, _ := setupRemoteTestRepos(t)
+	branch, _ := repo.GetCurrentBranch()
+	initial, _ := repo.GetLastCommitHash()
+
+	commitTestFile(t, repo, dir, "b.txt", "b\n", "Add b")
+	commitTestFile(t, repo, dir, "c.txt", "c\n", "Add c")
+	addB, _ := repo.RunGitCommand("rev-parse", "HEAD~1")
+
+	if upstream, err := repo.GetUpstreamContaining(initial); err != nil || upstream != "" {
+		t.Errorf("Expected no upstream before pushing, got %q (%v)", upstream, err)
+	}
+	if _, err := repo.RunGitCommand("push", "-q", "-u", "origin", "HEAD~1:refs/heads/"+branch); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.RunGitCommand("branch", "--set-upstream-to=origin/"+branch); err != nil {
+		t.Fatal(err)
+	}
+	if upstream, err := repo.GetUpstreamContaining(addB); err != nil || upstream != "origin/"+branch {
+		t.Errorf("Expected the pushed commit on the upstream, got %q (%v)", upstream, err)
+	}
+	head, _ := repo.GetLastCommitHash()
+	if upstream, err := repo.GetUpstreamContaining(head); err != nil || upstream != "" {
+		t.Errorf("Expected HEAD not to be pushed, got %q (%v)", upstream, err)
+	}
+
+	// Rewording HEAD leaves staged changes alone
+	writeTestFile(t, dir, "tracked.txt", "staged\n")
+	if err := repo.StageFiles("tracked.txt"); err != nil {
+		t.Fatal(err)
+	}
+	// Messages are kept verbatim, even with lines starting with #
+	if _, err := repo.RunGitCommand("config", "commit.cleanup", "strip"); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.RewordCommit(head, "feat: add c\n\n#7 is fixed.", CommitOptions{}); err != nil {
+		t.Fatalf("Failed to reword HEAD: %v", err)
+	}
+	if body, _ := repo.RunGitCommand("log", "-1", "--format=%b"); strings.TrimSpace(body) != "#7 is fixed." {
+		t.Errorf("Expected the body to be kept, got %q", body)
+	}
+	if files, _ := repo.RunGitCommand("show", "--name-only", "--format=%s", "HEAD"); files != "feat: add c\n\nc.txt" {
+		t.Errorf("Unexpected reworded HEAD %q", files)
+	}
+	if staged, _ := repo.HasStagedChanges(); !staged {
+		t.Error("Expected the staged change to stay staged")
+	}
+	if _, err := repo.RunGitCommand("reset", "-q", "--hard"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Older commits are reworded by a rebase, down to the root
+	if err := repo.RewordCommit(addB, "feat: add b\n\nWith a body.", CommitOptions{}); err != nil {
+		t.Fatalf("Failed to reword: %v", err)
+	}
+	if err := repo.RewordCommit(initial, "chore: initial commit", CommitOptions{}); err != nil {
+		t.Fatalf("Failed to reword the root: %v", err)
+	}
+	log, _ := repo.RunGitCommand("log", "--format=%s")
+	if log != "feat: add c\nfeat: add b\nchore: initial commit" {
+		t.Errorf("Unexpected history %q", log)
+	}
+	if message, _ := repo.GetCommitMessage("HEAD~1"); message != "feat: add b\n\nWith a body." {
+		t.Errorf("Unexpected message %q", message)
+	}
+
+	journal, _ := repo.GetJournal()
+	if entry := journal[len(journal)-1]; entry.Operation != OperationReword || entry.Description() != "reword of "+ShortHash(initial) {
+		t.Errorf("Unexpected journal entry %+v", entry)
+	}
+	if _, err := repo.Undo(1); err != nil {
+		t.Fatalf("Failed to undo: %v", err)
+	}
+	if subject, _ := repo.RunGitCommand("log", "-1", "--format=%s", "HEAD~2"); subject != "Initial commit" {
+		t.Errorf("Expected the root message to be restored, got %q", subject)
+	}
+
+	if err := repo.RewordCommit(addB, " \n", CommitOptions{}); err == nil || !strings.Contains(err.Error(), "cannot be empty") {
+		t.Errorf("Expected an empty message to be refused, got %v", err)
+	}
+}
+
+func TestRewordCommitHooksAndSigning(t *testing.T) {
+	repo, dir := setupCommittedTestRepo(t)
+	commitTestFile(t, repo, dir, "b.txt", "b\n", "Add b")
+	commitTestFile(t, repo, dir, "c.txt", "c\n", "Add c")
+	addB, _ := repo.RunGitCommand("rev-parse", "HEAD~1")
+	head, _ := repo.GetLastCommitHash()
+
+	// The amend of an older commit runs the commit-msg hook like HEAD's, and
+	// a rejected message leaves no rebase behind
+	writeHook(t, dir, "commit-msg", "if grep -q WIP \"$1\"; then echo 'no WIP commits' >&2; exit 1; fi\n")
+	err := repo.RewordCommit(addB, "WIP: add b", CommitOptions{})
+	if err == nil || !strings.Contains(err.Error(), "no WIP commits") {
+		t.Fatalf("Expected the hook to reject the message, got %v", err)
+	}
+	if state, _ := repo.GetRepositoryState(); state.InProgress() {
+		t.Errorf("Expected the rebase to be aborted, got %+v", state)
+	}
+	if after, _ := repo.GetLastCommitHash(); after != head {
+		t.Errorf("Expected HEAD to be unchanged, got %s", after)
+	}
+	if err := repo.RewordCommit(addB, "WIP: add b", CommitOptions{NoVerify: true}); err != nil {
+		t.Fatalf("Expected --no-verify to skip the hook: %v", err)
+	}
+	addB, _ = repo.RunGitCommand("rev-parse", "HEAD~1")
+
+	// -S is passed to the rebase and the amend; a failing signing program
+	// stands in for a locked key
+	for _, args := range [][]string{
+		{"config", "gpg.format", "ssh"},
+		{"config", "gpg.ssh.program", "false"},
+		{"config", "user.signingkey", "~/.ssh/id_ed25519.pub"},
+	} {
+		if err := runCommand(dir, "git", args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var signingErr *SigningError
+	if err := repo.RewordCommit(addB, "feat: add b", CommitOptions{Sign: SignAlways}); !errors.As(err, &signingErr) {
+		t.Fatalf("Expected a signing error, got %v", err)
+	}
+	if state, _ := repo.GetRepositoryState(); state.InProgress() {
+		t.Errorf("Expected the rebase to be aborted, got %+v", state)
+	}
+	if err := repo.RewordCommit(addB, "feat: add b", CommitOptions{Sign: SignNever}); err != nil {
+		t.Fatalf("Expected the reword without signing to succeed: %v", err)
+	}
+	if subject, _ := repo.RunGitCommand("log", "-1", "--format=%s", "HEAD~1"); subject != "feat: add b" {
+		t.Errorf("Unexpected subject %q", subject)
+	}
+}
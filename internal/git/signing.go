@@ -0,0 +1,90 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// SignMode selects whether a commit is signed
+type SignMode int
+
+const (
+	SignDefault SignMode = iota // Follow commit.gpgsign
+	SignAlways                  // git commit -S
+	SignNever                   // git commit --no-gpg-sign
+)
+
+// SigningConfig is the commit signing configuration of the repository
+type SigningConfig struct {
+	Enabled bool   // commit.gpgsign
+	Format  string // gpg.format: openpgp, ssh or x509
+	Key     string // user.signingkey, empty for the default key
+}
+
+// Signs reports whether a commit made with mode is signed
+func (c SigningConfig) Signs(mode SignMode) bool {
+	switch mode {
+	case SignAlways:
+		return true
+	case SignNever:
+		return false
+	}
+	return c.Enabled
+}
+
+// SigningError reports a commit that could not be signed, e.g. because the
+// key is missing or gpg-agent could not ask for the passphrase
+type SigningError struct {
+	Format string
+	Output string
+}
+
+func (e *SigningError) Error() string {
+	return fmt.Sprintf("failed to sign the commit with %s: %s", e.Format, e.Output)
+}
+
+// GetSigningConfig reads the commit signing configuration. Unset keys keep
+// Git's defaults.
+func (r *Repository) GetSigningConfig() (SigningConfig, error) {
+	config := SigningConfig{Format: "openpgp"}
+
+	enabled, err := r.configValue("--type=bool", "commit.gpgsign")
+	if err != nil {
+		return config, err
+	}
+	config.Enabled = enabled == "true"
+
+	if format, err := r.configValue("gpg.format"); err != nil {
+		return config, err
+	} else if format != "" {
+		config.Format = format
+	}
+
+	config.Key, err = r.configValue("user.signingkey")
+	return config, err
+}
+
+// configValue returns the value of a configuration key, or an empty string
+// when it is unset
+func (r *Repository) configValue(args ...string) (string, error) {
+	output, err := r.runGitCommandRaw(append([]string{"config", "--get"}, args...)...)
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// signingFailed reports whether git commit output shows that the commit
+// could not be signed: the signing program failed, so the commit object
+// could not be written, or no signing key is configured
+func signingFailed(output string) bool {
+	return strings.Contains(output, "failed to sign") ||
+		strings.Contains(output, "failed to write commit object") ||
+		strings.Contains(output, "user.signingkey")
+}
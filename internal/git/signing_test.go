@@ -0,0 +1,63 @@
+package git
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCommitWithSigning(t *testing.T) {
+	repo, dir := setupCommittedTestRepo(t)
+
+	config, err := repo.GetSigningConfig()
+	if err != nil {
+		t.Fatalf("Failed to get signing config: %v", err)
+	}
+	if config.Enabled || config.Format != "openpgp" || config.Key != "" {
+		t.Errorf("Expected Git's defaults, got %+v", config)
+	}
+
+	// A signing program that always fails stands in for a locked key
+	for _, args := range [][]string{
+		{"config", "commit.gpgsign", "yes"},
+		{"config", "gpg.format", "ssh"},
+		{"config", "gpg.ssh.program", "false"},
+		{"config", "user.signingkey", "~/.ssh/id_ed25519.pub"},
+	} {
+		if err := runCommand(dir, "git", args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	config, err = repo.GetSigningConfig()
+	if err != nil {
+		t.Fatalf("Failed to get signing config: %v", err)
+	}
+	if !config.Enabled || config.Format != "ssh" || config.Key != "~/.ssh/id_ed25519.pub" {
+		t.Errorf("Unexpected signing config %+v", config)
+	}
+
+	writeTestFile(t, dir, "signed.txt", "signed\n")
+	if err := repo.StageFiles("signed.txt"); err != nil {
+		t.Fatal(err)
+	}
+	var signingErr *SigningError
+	if err := repo.Commit("Signed commit"); !errors.As(err, &signingErr) || signingErr.Format != "ssh" {
+		t.Fatalf("Expected a signing error, got %v", err)
+	}
+	if err := repo.CommitWithOptions("Unsigned commit", CommitOptions{Sign: SignNever}); err != nil {
+		t.Fatalf("Expected --no-gpg-sign to skip signing: %v", err)
+	}
+
+	if err := runCommand(dir, "git", "config", "commit.gpgsign", "false"); err != nil {
+		t.Fatal(err)
+	}
+	writeTestFile(t, dir, "signed.txt", "changed\n")
+	if err := repo.StageFiles("signed.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.CommitWithOptions("Signed commit", CommitOptions{Sign: SignAlways}); !errors.As(err, &signingErr) {
+		t.Fatalf("Expected -S to sign and fail, got %v", err)
+	}
+	if err := repo.CommitWithOptions("", CommitOptions{Sign: SignNever}); err == nil {
+		t.Error("Expected error for an empty message")
+	}
+}
@@ -0,0 +1,152 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// StashEntry represents an entry of the stash list
+type StashEntry struct {
+	Index   int
+	Ref     string // e.g. "stash@{0}"
+	Hash    string
+	Branch  string // Branch the stash was created on
+	Message string // Stash message without the "On <branch>:" prefix
+	Date    time.Time
+}
+
+// StashOptions controls what StashPush saves
+type StashOptions struct {
+	Message          string
+	StagedOnly       bool     // Only stash changes in the index
+	IncludeUntracked bool     // Also stash untracked files
+	Paths            []string // Limit the stash to these paths
+}
+
+// stashListFields lists the log placeholders used by GetStashes
+var stashListFields = []string{"%gd", "%H", "%gs", "%cI"}
+
+// GetStashes returns the stash entries, most recent first
+func (r *Repository) GetStashes() ([]StashEntry, error) {
+	output, err := r.runGitCommandRaw("stash", "list", "-z", "--format="+strings.Join(stashListFields, "%x1f"))
+	if err != nil {
+		return nil, err
+	}
+
+	var stashes []StashEntry
+	for _, record := range strings.Split(string(output), "\x00") {
+		parts := strings.Split(strings.TrimSpace(record), "\x1f")
+		if len(parts) != len(stashListFields) {
+			continue
+		}
+
+		entry := StashEntry{Ref: parts[0], Hash: parts[1]}
+		if _, err := fmt.Sscanf(entry.Ref, "stash@{%d}", &entry.Index); err != nil {
+			continue
+		}
+		entry.Branch, entry.Message = parseStashSubject(parts[2])
+		if date, err := time.Parse(time.RFC3339, parts[3]); err == nil {
+			entry.Date = date
+		}
+		stashes = append(stashes, entry)
+	}
+
+	return stashes, nil
+}
+
+// parseStashSubject splits a stash reflog subject such as "On main: message"
+// or "WIP on main: 1234567 subject" into the branch and the message
+func parseStashSubject(subject string) (branch, message string) {
+	rest, ok := strings.CutPrefix(subject, "WIP on ")
+	if !ok {
+		rest, ok = strings.CutPrefix(subject, "On ")
+	}
+	if !ok {
+		return "", subject
+	}
+
+	branch, message, found := strings.Cut(rest, ": ")
+	if !found {
+		return "", subject
+	}
+	return branch, message
+}
+
+// StashPush saves local changes to a new stash entry and reverts them in the
+// working tree
+func (r *Repository) StashPush(opts StashOptions) error {
+	if opts.StagedOnly && opts.IncludeUntracked {
+		return fmt.Errorf("untracked files cannot be stashed together with staged-only changes")
+	}
+
+	args := []string{"stash", "push"}
+	if opts.StagedOnly {
+		args = append(args, "--staged")
+	}
+	if opts.IncludeUntracked {
+		args = append(args, "--include-untracked")
+	}
+	if opts.Message != "" {
+		args = append(args, "-m", opts.Message)
+	}
+	if len(opts.Paths) > 0 {
+		args = append(args, "--")
+		args = append(args, opts.Paths...)
+	}
+
+	output, err := r.runGitCommand(args...)
+	if err != nil {
+		return err
+	}
+
+	// Git exits successfully without creating an entry when there is nothing to stash
+	if strings.Contains(output, "No local changes to save") {
+		return fmt.Errorf("no local changes to stash")
+	}
+	return nil
+}
+
+// StashApply applies a stash entry to the working tree and keeps it in the stash
+func (r *Repository) StashApply(index int) error {
+	return r.runStashCommand("apply", index)
+}
+
+// StashPop applies a stash entry and removes it from the stash. The entry is
+// kept when applying it results in conflicts.
+func (r *Repository) StashPop(index int) error {
+	return r.runStashCommand("pop", index)
+}
+
+// StashDrop removes a stash entry
+func (r *Repository) StashDrop(index int) error {
+	return r.runStashCommand("drop", index)
+}
+
+// runStashCommand runs a stash subcommand on the entry with the given index
+func (r *Repository) runStashCommand(subcommand string, index int) error {
+	if index < 0 {
+		return fmt.Errorf("invalid stash index: %d", index)
+	}
+	_, err := r.runGitCommand("stash", subcommand, stashRef(index))
+	return err
+}
+
+// GetStashDiff returns the changes recorded in a stash entry, including
+// stashed untracked files
+func (r *Repository) GetStashDiff(index int) ([]DiffInfo, error) {
+	if index < 0 {
+		return nil, fmt.Errorf("invalid stash index: %d", index)
+	}
+
+	output, err := r.runGitCommand("stash", "show", "-p", "--no-color", "--include-untracked", stashRef(index))
+	if err != nil {
+		return nil, err
+	}
+	return r.parseDiff(output), nil
+}
+
+// stashRef returns the reference of the stash entry with the given index
+func stashRef(index int) string {
+	return fmt.Sprintf("stash@{%d}", index)
+}
@@ -0,0 +1,160 @@
+package git
+
+import (
+	"testing"
+)
+
+func TestStashLifecycle(t *testing.T) {
+	repo, tempDir := setupCommittedTestRepo(t)
+	branch, _ := repo.GetCurrentBranch()
+
+	if err := repo.StashPush(StashOptions{Message: "nothing"}); err == nil {
+		t.Error("Expected error when there is nothing to stash")
+	}
+
+	writeTestFile(t, tempDir, "tracked.txt", "first change\n")
+	if err := repo.StashPush(StashOptions{Message: "first"}); err != nil {
+		t.Fatalf("Failed to stash: %v", err)
+	}
+	if content := readTestFile(t, tempDir, "tracked.txt"); content != "original\n" {
+		t.Errorf("Expected working tree to be reverted, got %q", content)
+	}
+
+	writeTestFile(t, tempDir, "tracked.txt", "second change\n")
+	writeTestFile(t, tempDir, "new.txt", "untracked\n")
+	if err := repo.StashPush(StashOptions{Message: "second", IncludeUntracked: true}); err != nil {
+		t.Fatalf("Failed to stash with untracked files: %v", err)
+	}
+
+	stashes, err := repo.GetStashes()
+	if err != nil {
+		t.Fatalf("Failed to list stashes: %v", err)
+	}
+	if len(stashes) != 2 {
+		t.Fatalf("Expected 2 stashes, got %+v", stashes)
+	}
+	latest := stashes[0]
+	if latest.Index != 0 || latest.Ref != "stash@{0}" || latest.Message != "second" || latest.Branch != branch {
+		t.Errorf("Unexpected latest stash %+v", latest)
+	}
+	if latest.Hash == "" || latest.Date.IsZero() {
+		t.Errorf("Expected hash and date, got %+v", latest)
+	}
+	if stashes[1].Index != 1 || stashes[1].Message != "first" {
+		t.Errorf("Unexpected older stash %+v", stashes[1])
+	}
+
+	// The diff includes stashed untracked files
+	diffs, err := repo.GetStashDiff(0)
+	if err != nil {
+		t.Fatalf("Failed to get stash diff: %v", err)
+	}
+	paths := map[string]bool{}
+	for _, diff := range diffs {
+		paths[diff.FilePath] = true
+	}
+	if !paths["tracked.txt"] || !paths["new.txt"] {
+		t.Errorf("Expected tracked and untracked files in stash diff, got %+v", diffs)
+	}
+
+	// Apply keeps the entry, pop removes it
+	if err := repo.StashApply(1); err != nil {
+		t.Fatalf("Failed to apply stash: %v", err)
+	}
+	if content := readTestFile(t, tempDir, "tracked.txt"); content != "first change\n" {
+		t.Errorf("Expected applied change, got %q", content)
+	}
+	if stashes, _ = repo.GetStashes(); len(stashes) != 2 {
+		t.Errorf("Expected apply to keep the stash, got %d entries", len(stashes))
+	}
+
+	if err := repo.DiscardChanges("tracked.txt"); err != nil {
+		t.Fatalf("Failed to discard changes: %v", err)
+	}
+	if err := repo.StashPop(0); err != nil {
+		t.Fatalf("Failed to pop stash: %v", err)
+	}
+	if content := readTestFile(t, tempDir, "new.txt"); content != "untracked\n" {
+		t.Errorf("Expected untracked file to be restored, got %q", content)
+	}
+
+	stashes, _ = repo.GetStashes()
+	if len(stashes) != 1 || stashes[0].Message != "first" {
+		t.Fatalf("Expected only the first stash to remain, got %+v", stashes)
+	}
+
+	if err := repo.StashDrop(0); err != nil {
+		t.Fatalf("Failed to drop stash: %v", err)
+	}
+	if stashes, _ = repo.GetStashes(); len(stashes) != 0 {
+		t.Errorf("Expected empty stash, got %+v", stashes)
+	}
+
+	if err := repo.StashDrop(-1); err == nil {
+		t.Error("Expected error for invalid stash index")
+	}
+}
+
+func TestStashStagedOnlyAndPaths(t *testing.T) {
+	repo, tempDir := setupCommittedTestRepo(t)
+	commitTestFile(t, repo, tempDir, "other.txt", "other\n", "Add other")
+
+	// Only the staged file is stashed
+	writeTestFile(t, tempDir, "tracked.txt", "staged\n")
+	if err := repo.StageFiles("tracked.txt"); err != nil {
+		t.Fatalf("Failed to stage file: %v", err)
+	}
+	writeTestFile(t, tempDir, "other.txt", "unstaged\n")
+
+	if err := repo.StashPush(StashOptions{Message: "staged", StagedOnly: true}); err != nil {
+		t.Fatalf("Failed to stash staged changes: %v", err)
+	}
+	if content := readTestFile(t, tempDir, "tracked.txt"); content != "original\n" {
+		t.Errorf("Expected staged change to be stashed, got %q", content)
+	}
+	if content := readTestFile(t, tempDir, "other.txt"); content != "unstaged\n" {
+		t.Errorf("Expected unstaged change to be kept, got %q", content)
+	}
+
+	// Only the selected path is stashed
+	writeTestFile(t, tempDir, "tracked.txt", "selected\n")
+	if err := repo.StashPush(StashOptions{Message: "paths", Paths: []string{"other.txt"}}); err != nil {
+		t.Fatalf("Failed to stash selected path: %v", err)
+	}
+	if content := readTestFile(t, tempDir, "other.txt"); content != "other\n" {
+		t.Errorf("Expected selected path to be stashed, got %q", content)
+	}
+	if content := readTestFile(t, tempDir, "tracked.txt"); content != "selected\n" {
+		t.Errorf("Expected other path to be kept, got %q", content)
+	}
+
+	diffs, err := repo.GetStashDiff(0)
+	if err != nil {
+		t.Fatalf("Failed to get stash diff: %v", err)
+	}
+	if len(diffs) != 1 || diffs[0].FilePath != "other.txt" {
+		t.Errorf("Expected only other.txt in the stash, got %+v", diffs)
+	}
+
+	if err := repo.StashPush(StashOptions{StagedOnly: true, IncludeUntracked: true}); err == nil {
+		t.Error("Expected error for staged-only stash with untracked files")
+	}
+}
+
+func TestParseStashSubject(t *testing.T) {
+	tests := []struct {
+		subject, branch, message string
+	}{
+		{"On main: my message", "main", "my message"},
+		{"WIP on feature/x: 1234567 fix: thing", "feature/x", "1234567 fix: thing"},
+		{"On main: a: b", "main", "a: b"},
+		{"something else", "", "something else"},
+	}
+
+	for _, tt := range tests {
+		branch, message := parseStashSubject(tt.subject)
+		if branch != tt.branch || message != tt.message {
+			t.Errorf("parseStashSubject(%q) = %q, %q", tt.subject, branch, message)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GetMergedTags returns the names of the tags reachable from HEAD
+func (r *Repository) GetMergedTags() ([]string, error) {
+	output, err := r.runGitCommandRaw("tag", "--list", "--merged", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if tag := strings.TrimSpace(line); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags, nil
+}
+
+// CreateAnnotatedTag creates an annotated tag at HEAD. The message is kept
+// verbatim, so Markdown headings starting with "#" are not stripped.
+func (r *Repository) CreateAnnotatedTag(name, message string) error {
+	if name == "" {
+		return fmt.Errorf("tag name cannot be empty")
+	}
+	if strings.TrimSpace(message) == "" {
+		return fmt.Errorf("tag message cannot be empty")
+	}
+
+	if _, err := r.runGitCommand("tag", "-a", "--cleanup=verbatim", "-m", message, name, "HEAD"); err != nil {
+		return fmt.Errorf("failed to create tag %s: %w", name, err)
+	}
+	return nil
+}
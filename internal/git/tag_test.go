@@ -0,0 +1,50 @@
+package git
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTags(t *testing.T) {
+	repo, dir := setupCommittedTestRepo(t)
+
+	if tags, err := repo.GetMergedTags(); err != nil || len(tags) != 0 {
+		t.Fatalf("Expected no tags, got %v (%v)", tags, err)
+	}
+
+	message := "## [1.0.0]\n\n### Added\n\n- first release\n"
+	if err := repo.CreateAnnotatedTag("v1.0.0", message); err != nil {
+		t.Fatalf("Failed to create tag: %v", err)
+	}
+	if err := repo.CreateAnnotatedTag("v1.0.0", message); err == nil {
+		t.Error("Expected error for an existing tag")
+	}
+	if err := repo.CreateAnnotatedTag("v1.0.1", " "); err == nil {
+		t.Error("Expected error for an empty message")
+	}
+
+	// The message is kept verbatim, including Markdown headings
+	if output, err := repo.runGitCommandRaw("tag", "-l", "--format=%(contents)", "v1.0.0"); err != nil || string(output) != message+"\n" {
+		t.Errorf("Unexpected tag message %q (%v)", output, err)
+	}
+
+	// Tags on other branches are not merged into HEAD
+	if err := repo.CreateBranch("other", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.CheckoutBranch("other"); err != nil {
+		t.Fatal(err)
+	}
+	commitTestFile(t, repo, dir, "other.txt", "other\n", "Other work")
+	if err := repo.CreateAnnotatedTag("v2.0.0", "other"); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.CheckoutBranch("-"); err != nil {
+		t.Fatal(err)
+	}
+
+	tags, err := repo.GetMergedTags()
+	if err != nil || !slices.Equal(tags, []string{"v1.0.0"}) {
+		t.Errorf("Expected only v1.0.0, got %v (%v)", tags, err)
+	}
+}
@@ -0,0 +1,91 @@
+package git
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// String formats the trailer as a "Key: value" line
+func (t Trailer) String() string {
+	return t.Key + ": " + t.Value
+}
+
+// AddTrailers appends trailers to a commit message with git interpret-trailers,
+// so they join an existing trailer block and a trailer already present with
+// the same value is not added again
+func (r *Repository) AddTrailers(message string, trailers []Trailer) (string, error) {
+	if len(trailers) == 0 {
+		return message, nil
+	}
+
+	args := []string{"interpret-trailers", "--if-exists", "addIfDifferent"}
+	for _, trailer := range trailers {
+		args = append(args, "--trailer", trailer.String())
+	}
+	// Without a final newline the last line is not recognized as a paragraph
+	input := strings.TrimRight(message, "\n") + "\n"
+	output, err := r.runGitCommandWithInput(nil, strings.NewReader(input), args...)
+	if err != nil {
+		return message, err
+	}
+	return strings.TrimRight(output, "\n"), nil
+}
+
+// Contributor is an author of commits reachable from HEAD
+type Contributor struct {
+	Name    string
+	Email   string
+	Commits int
+}
+
+// String formats the contributor as an identity, e.g. "Jane Doe <jane@example.com>"
+func (c Contributor) String() string {
+	return fmt.Sprintf("%s <%s>", c.Name, c.Email)
+}
+
+// GetContributors returns the authors of the commits reachable from HEAD,
+// most active first, as listed by git shortlog -sne
+func (r *Repository) GetContributors() ([]Contributor, error) {
+	output, err := r.runGitCommandRaw("shortlog", "-sne", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	var contributors []Contributor
+	for _, line := range strings.Split(string(output), "\n") {
+		count, identity, found := strings.Cut(strings.TrimSpace(line), "\t")
+		if !found {
+			continue
+		}
+		commits, err := strconv.Atoi(strings.TrimSpace(count))
+		if err != nil {
+			continue
+		}
+		name, email, found := strings.Cut(identity, " <")
+		if !found {
+			continue
+		}
+		contributors = append(contributors, Contributor{
+			Name:    strings.TrimSpace(name),
+			Email:   strings.TrimSuffix(email, ">"),
+			Commits: commits,
+		})
+	}
+	return contributors, nil
+}
+
+// GetCommitterIdentity returns the identity Git commits with, e.g.
+// "Jane Doe <jane@example.com>", as used by git commit --signoff
+func (r *Repository) GetCommitterIdentity() (string, error) {
+	output, err := r.runGitCommand("var", "GIT_COMMITTER_IDENT")
+	if err != nil {
+		return "", fmt.Errorf("failed to get committer identity: %w", err)
+	}
+	// The identity is followed by the timestamp and time zone
+	end := strings.LastIndex(output, ">")
+	if end < 0 {
+		return "", fmt.Errorf("unexpected committer identity %q", output)
+	}
+	return output[:end+1], nil
+}
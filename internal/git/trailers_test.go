@@ -0,0 +1,59 @@
+package git
+
+import (
+	"testing"
+)
+
+func TestAddTrailers(t *testing.T) {
+	repo, _ := setupCommittedTestRepo(t)
+	refs := []Trailer{{Key: "Refs", Value: "PROJ-1234"}}
+
+	tests := []struct {
+		name     string
+		message  string
+		trailers []Trailer
+		want     string
+	}{
+		{"subject only", "feat: add login", refs, "feat: add login\n\nRefs: PROJ-1234"},
+		{"body", "feat: add login\n\nExplain the change.", refs, "feat: add login\n\nExplain the change.\n\nRefs: PROJ-1234"},
+		{"existing block", "fix: crash\n\nBody.\n\nReviewed-by: A <a@example.com>", refs, "fix: crash\n\nBody.\n\nReviewed-by: A <a@example.com>\nRefs: PROJ-1234"},
+		{"already present", "fix: crash\n\nRefs: PROJ-1234", refs, "fix: crash\n\nRefs: PROJ-1234"},
+		{"different value", "fix: crash\n\nRefs: PROJ-1", refs, "fix: crash\n\nRefs: PROJ-1\nRefs: PROJ-1234"},
+		{"no trailers", "fix: crash", nil, "fix: crash"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := repo.AddTrailers(test.message, test.trailers)
+			if err != nil {
+				t.Fatalf("Failed to add trailers: %v", err)
+			}
+			if got != test.want {
+				t.Errorf("AddTrailers() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestContributors(t *testing.T) {
+	repo, dir := setupCommittedTestRepo(t)
+	commitTestFile(t, repo, dir, "a.txt", "a\n", "Add a")
+	if err := runCommand(dir, "git", "-c", "user.name=Jane Doe", "-c", "user.email=jane@example.com", "commit", "-q", "--allow-empty", "-m", "Jane's work"); err != nil {
+		t.Fatal(err)
+	}
+
+	contributors, err := repo.GetContributors()
+	if err != nil {
+		t.Fatalf("Failed to get contributors: %v", err)
+	}
+	if len(contributors) != 2 || contributors[0].Commits != 2 || contributors[1].String() != "Jane Doe <jane@example.com>" || contributors[1].Commits != 1 {
+		t.Errorf("Unexpected contributors %+v", contributors)
+	}
+
+	identity, err := repo.GetCommitterIdentity()
+	if err != nil {
+		t.Fatalf("Failed to get committer identity: %v", err)
+	}
+	if identity != contributors[0].String() {
+		t.Errorf("Expected the committer %q to be the main contributor %q", identity, contributors[0].String())
+	}
+}
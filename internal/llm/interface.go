@@ -18,8 +18,44 @@ type Provider interface {
 	Close() error
 }
 
+// RequestMode selects the kind of text generated from a diff
+type RequestMode string
+
+const (
+	// ModeCommit generates a Conventional Commits message (the default)
+	ModeCommit RequestMode = ""
+
+	// ModeStash generates a short one-line description of a stash entry
+	ModeStash RequestMode = "stash"
+
+	// ModeConflict proposes a resolution for a merge conflict. Diff holds the
+	// conflict; the response is parsed with ParseConflictResolution.
+	ModeConflict RequestMode = "conflict"
+
+	// ModeSquash generates the message of a commit that squashes a range of
+	// commits. Diff holds the commit messages and the diffstat of the range.
+	ModeSquash RequestMode = "squash"
+
+	// ModeMerge generates the message of a merge commit for a range of
+	// commits, with Diff as for ModeSquash
+	ModeMerge RequestMode = "merge"
+
+	// ModePullRequest generates a pull request title and Markdown description.
+	// Diff holds the commits and changes of the branch, AdditionalContext an
+	// optional template; the response is parsed with ParsePullRequest.
+	ModePullRequest RequestMode = "pull request"
+
+	// ModeChangelog rewrites one changelog section as user-facing release
+	// notes. Diff holds the section title and its entries; the response is a
+	// Markdown bullet list.
+	ModeChangelog RequestMode = "changelog"
+)
+
 // CommitMessageRequest represents a request to generate a commit message
 type CommitMessageRequest struct {
+	// Mode selects what is generated, a commit message by default
+	Mode RequestMode
+
 	// Diff contains the git diff content
 	Diff string
 
@@ -29,6 +65,10 @@ type CommitMessageRequest struct {
 	// AdditionalContext provides extra context for the commit message generation
 	AdditionalContext string
 
+	// BreakingChanges lists likely breaking changes found by analyzing the
+	// diff, which the commit message must mark
+	BreakingChanges []string
+
 	// MaxTokens specifies the maximum number of tokens in the response
 	MaxTokens int
 
@@ -192,8 +232,21 @@ func ValidateRequest(request *CommitMessageRequest) error {
 	return nil
 }
 
-// BuildPrompt builds a prompt for commit message generation
+// BuildPrompt builds a prompt for the mode of the request
 func BuildPrompt(request *CommitMessageRequest) string {
+	switch request.Mode {
+	case ModeStash:
+		return buildStashPrompt(request)
+	case ModeConflict:
+		return buildConflictPrompt(request)
+	case ModeSquash, ModeMerge:
+		return buildRangePrompt(request)
+	case ModePullRequest:
+		return buildPullRequestPrompt(request)
+	case ModeChangelog:
+		return buildChangelogPrompt(request)
+	}
+
 	prompt := fmt.Sprintf(`You are an expert software developer.
 Generate a concise and descriptive commit message following the Conventional Commits specification.
 And then one empty line. Then detailed description of all changes.
@@ -234,11 +287,252 @@ Git diff:
 		prompt += fmt.Sprintf("\n\nAdditional context:\n%s", request.AdditionalContext)
 	}
 
+	if len(request.BreakingChanges) > 0 {
+		prompt += fmt.Sprintf(`
+
+Likely breaking changes found in the exported API:
+- %s
+
+If these changes break users of the code, add "!" after the type or scope (e.g. "feat(api)!: ...")
+and end the message with a footer, after an empty line, that starts with "BREAKING CHANGE: " and
+explains what breaks and how to migrate.`, strings.Join(request.BreakingChanges, "\n- "))
+	}
+
+	prompt += "\n\nGenerate only the commit message in plain text format, no explanations, no markdown formatting:"
+
+	return prompt
+}
+
+// buildStashPrompt builds a prompt for a one-line stash description
+func buildStashPrompt(request *CommitMessageRequest) string {
+	prompt := fmt.Sprintf(`You are an expert software developer.
+Describe the following work-in-progress changes in a single short line so they can be found again in the git stash list.
+
+Language: %s
+
+Rules:
+1. One line only, under 60 characters
+2. Describe what the changes are about, not how they were made
+3. Do NOT use a Conventional Commits prefix
+4. Do NOT use markdown formatting or quotes
+
+Git diff:
+%s`, request.Language, request.Diff)
+
+	if request.AdditionalContext != "" {
+		prompt += fmt.Sprintf("\n\nAdditional context:\n%s", request.AdditionalContext)
+	}
+
+	prompt += "\n\nGenerate only the stash description in plain text:"
+
+	return prompt
+}
+
+// buildRangePrompt builds a prompt for a squash or merge commit message
+// summarizing a range of commits
+func buildRangePrompt(request *CommitMessageRequest) string {
+	task := "Write the commit message for a single commit that squashes all of the following commits."
+	if request.Mode == ModeMerge {
+		task = "Write the message for a merge commit that brings in the following commits."
+	}
+
+	prompt := fmt.Sprintf(`You are an expert software developer.
+%s
+Follow the Conventional Commits specification, then one empty line, then a description of the changes.
+
+Language: %s
+Format: <type>(<scope>): <description>
+
+<detailed description of all changes>
+
+Rules:
+1. Use lowercase for type and description
+2. Keep the first line under 50 characters
+3. Summarize the combined result, not the history of how it was developed
+4. Leave out fixups of changes made within the same range
+5. Use imperative mood (e.g., "add" not "added")
+6. Do NOT use markdown formatting (no asterisks, underscores, backticks, etc.)
+7. Must insert a blank line after the first line before detailing the changes
+
+%s`, task, request.Language, request.Diff)
+
+	if request.AdditionalContext != "" {
+		prompt += fmt.Sprintf("\n\nAdditional context:\n%s", request.AdditionalContext)
+	}
+
 	prompt += "\n\nGenerate only the commit message in plain text format, no explanations, no markdown formatting:"
 
 	return prompt
 }
 
+// buildConflictPrompt builds a prompt asking for the resolution of one conflict
+func buildConflictPrompt(request *CommitMessageRequest) string {
+	prompt := fmt.Sprintf(`You are an expert software developer resolving a git merge conflict.
+Propose the content that replaces the conflict region, from the "<<<<<<<" line to the ">>>>>>>" line.
+
+Language of the explanation: %s
+
+Rules:
+1. Keep the intent of both sides; only drop a change when the other side supersedes it
+2. The resolution must not contain conflict markers
+3. Keep the indentation and style of the surrounding code
+4. Output the resolution verbatim, without markdown code fences
+5. Keep the explanation to a few sentences
+
+Respond in exactly this format:
+<resolution>
+the lines replacing the conflict region
+</resolution>
+<explanation>
+why the conflict is resolved this way
+</explanation>
+
+%s`, request.Language, request.Diff)
+
+	if request.AdditionalContext != "" {
+		prompt += fmt.Sprintf("\n\nAdditional context:\n%s", request.AdditionalContext)
+	}
+
+	return prompt
+}
+
+// buildPullRequestPrompt builds a prompt for a pull request title and description.
+// A template in AdditionalContext replaces the default sections.
+func buildPullRequestPrompt(request *CommitMessageRequest) string {
+	structure := `Use these Markdown sections:
+## Summary
+what the change does and why, in one or two paragraphs
+## Changes
+a bullet list of the notable changes
+## Testing
+how the change was or should be tested`
+	if request.AdditionalContext != "" {
+		structure = fmt.Sprintf(`Follow the structure of the repository's pull request template below.
+Keep its headings and checklists, fill in every section from the changes and drop HTML comments.
+
+<template>
+%s</template>`, request.AdditionalContext)
+	}
+
+	return fmt.Sprintf(`You are an expert software developer opening a pull request.
+Write a title and a description for the pull request of the following branch.
+
+Language: %s
+
+Rules:
+1. Keep the title under 72 characters, in imperative mood, without a trailing period
+2. Describe the result of the branch as a whole, not the history of its commits
+3. Do not invent test results; when unsure how it was tested, list what reviewers should check
+
+%s
+
+Respond in exactly this format:
+<title>
+the pull request title
+</title>
+<body>
+the Markdown description
+</body>
+
+%s`, request.Language, structure, request.Diff)
+}
+
+// ParsePullRequest extracts the title and Markdown body from the response to
+// a ModePullRequest request
+func ParsePullRequest(message string) (title, body string, err error) {
+	title, found := extractTag(message, "title")
+	title = strings.TrimSpace(title)
+	if !found || title == "" {
+		return "", "", fmt.Errorf("no title in the response")
+	}
+	if strings.Contains(title, "\n") {
+		return "", "", fmt.Errorf("the title spans multiple lines")
+	}
+	body, found = extractTag(message, "body")
+	if !found || strings.TrimSpace(body) == "" {
+		return "", "", fmt.Errorf("no description in the response")
+	}
+	return title, body, nil
+}
+
+// buildChangelogPrompt builds a prompt rewriting a changelog section as release notes
+func buildChangelogPrompt(request *CommitMessageRequest) string {
+	prompt := fmt.Sprintf(`You are an expert software developer writing release notes.
+Rewrite the following changelog section for the users of the project.
+
+Language: %s
+
+Rules:
+1. Output a Markdown bullet list only, without the section heading
+2. Describe what changed for users, not how it was implemented
+3. Merge entries describing the same change and leave out purely internal ones
+4. Keep scopes in bold at the start of an entry, e.g. "- **api:** ..."
+5. Keep issue and pull request references and commit hashes in parentheses
+6. Do not invent changes that are not listed
+
+%s`, request.Language, request.Diff)
+
+	if request.AdditionalContext != "" {
+		prompt += fmt.Sprintf("\n\nAdditional context:\n%s", request.AdditionalContext)
+	}
+
+	return prompt
+}
+
+// MarksBreakingChange reports whether a Conventional Commits message marks a
+// breaking change with "!" before the colon of its subject or a BREAKING
+// CHANGE footer
+func MarksBreakingChange(message string) bool {
+	lines := strings.Split(strings.TrimSpace(message), "\n")
+	if prefix, _, found := strings.Cut(lines[0], ":"); found && strings.HasSuffix(prefix, "!") {
+		return true
+	}
+	for _, line := range lines[1:] {
+		if strings.HasPrefix(line, "BREAKING CHANGE:") || strings.HasPrefix(line, "BREAKING-CHANGE:") {
+			return true
+		}
+	}
+	return false
+}
+
+// FormatPullRequest renders a pull request as its title, an empty line and
+// the body, like a commit message
+func FormatPullRequest(title, body string) string {
+	return title + "\n\n" + strings.TrimRight(body, "\n") + "\n"
+}
+
+// ParseConflictResolution extracts the resolution and explanation from the
+// response to a ModeConflict request
+func ParseConflictResolution(message string) (resolution, explanation string, err error) {
+	resolution, found := extractTag(message, "resolution")
+	if !found {
+		return "", "", fmt.Errorf("no resolution in the response")
+	}
+	if strings.Contains(resolution, "<<<<<<<") || strings.Contains(resolution, ">>>>>>>") {
+		return "", "", fmt.Errorf("the proposed resolution still contains conflict markers")
+	}
+	explanation, _ = extractTag(message, "explanation")
+	return resolution, strings.TrimSpace(explanation), nil
+}
+
+// extractTag returns the text between <tag> and </tag>, without the line
+// breaks directly inside the tags
+func extractTag(message, tag string) (string, bool) {
+	_, rest, found := strings.Cut(message, "<"+tag+">")
+	if !found {
+		return "", false
+	}
+	content, _, found := strings.Cut(rest, "</"+tag+">")
+	if !found {
+		return "", false
+	}
+	content = strings.TrimPrefix(strings.TrimPrefix(content, "\r"), "\n")
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	return content, true
+}
+
 // CleanMarkdownFromCommitMessage removes markdown formatting from commit message
 func CleanMarkdownFromCommitMessage(message string) string {
 	// Remove common markdown formatting
@@ -376,3 +376,219 @@ func TestBuildPrompt(t *testing.T) {
 		}
 	}
 }
+
+func TestBuildStashPrompt(t *testing.T) {
+	request := &CommitMessageRequest{
+		Mode:              ModeStash,
+		Diff:              "diff --git a/file.txt b/file.txt\n+new line",
+		Language:          "japanese",
+		AdditionalContext: "Stashed on branch main",
+	}
+
+	prompt := BuildPrompt(request)
+
+	for _, element := range []string{"single short line", "git stash list", "japanese", "+new line", "Stashed on branch main"} {
+		if !strings.Contains(prompt, element) {
+			t.Errorf("Expected stash prompt to contain '%s'", element)
+		}
+	}
+	if strings.Contains(prompt, "feat, fix, docs") {
+		t.Error("Expected stash prompt not to ask for a commit type")
+	}
+}
+
+func TestBuildConflictPrompt(t *testing.T) {
+	request := &CommitMessageRequest{
+		Mode:     ModeConflict,
+		Diff:     "Conflict in main.go:\n<<<<<<< HEAD\na\n=======\nb\n>>>>>>> feature",
+		Language: "japanese",
+	}
+
+	prompt := BuildPrompt(request)
+
+	for _, element := range []string{"merge conflict", "<resolution>", "<explanation>", "japanese", "Conflict in main.go"} {
+		if !strings.Contains(prompt, element) {
+			t.Errorf("Expected conflict prompt to contain '%s'", element)
+		}
+	}
+}
+
+func TestParseConflictResolution(t *testing.T) {
+	tests := []struct {
+		name        string
+		message     string
+		resolution  string
+		explanation string
+		wantErr     bool
+	}{
+		{
+			name:        "resolution and explanation",
+			message:     "<resolution>\n\tx := *p\n\n// keep both\n</resolution>\n<explanation>\nBoth sides are kept.\n</explanation>",
+			resolution:  "\tx := *p\n\n// keep both\n",
+			explanation: "Both sides are kept.",
+		},
+		{
+			name:       "empty resolution removes the region",
+			message:    "<resolution>\n</resolution>",
+			resolution: "",
+		},
+		{
+			name:    "missing resolution",
+			message: "Keep both sides.",
+			wantErr: true,
+		},
+		{
+			name:    "markers left",
+			message: "<resolution>\n<<<<<<< HEAD\na\n</resolution>",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolution, explanation, err := ParseConflictResolution(tt.message)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseConflictResolution() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if resolution != tt.resolution || explanation != tt.explanation {
+				t.Errorf("ParseConflictResolution() = %q, %q, want %q, %q", resolution, explanation, tt.resolution, tt.explanation)
+			}
+		})
+	}
+}
+
+func TestBuildRangePrompt(t *testing.T) {
+	diff := "Commits in main..feature (2, oldest first):\n\nabc1234 Add parser\n\nChanged files:\n parser.go | 1 +"
+
+	squash := BuildPrompt(&CommitMessageRequest{Mode: ModeSquash, Diff: diff, Language: "english"})
+	for _, element := range []string{"squashes all of the following commits", "Conventional Commits", "abc1234 Add parser", "parser.go | 1 +"} {
+		if !strings.Contains(squash, element) {
+			t.Errorf("Expected squash prompt to contain '%s'", element)
+		}
+	}
+
+	merge := BuildPrompt(&CommitMessageRequest{Mode: ModeMerge, Diff: diff, Language: "english", AdditionalContext: "Merging feature into main"})
+	for _, element := range []string{"merge commit", "Merging feature into main"} {
+		if !strings.Contains(merge, element) {
+			t.Errorf("Expected merge prompt to contain '%s'", element)
+		}
+	}
+}
+
+func TestBuildPullRequestPrompt(t *testing.T) {
+	diff := "Branch feature into main (merge base abc12345)\n\nCommits in main...feature (1, oldest first):\n\ndef67890 feat: add parser\n"
+
+	prompt := BuildPrompt(&CommitMessageRequest{Mode: ModePullRequest, Diff: diff, Language: "english"})
+	for _, element := range []string{"pull request", "## Summary", "## Changes", "## Testing", "<title>", "<body>", "def67890 feat: add parser"} {
+		if !strings.Contains(prompt, element) {
+			t.Errorf("Expected pull request prompt to contain '%s'", element)
+		}
+	}
+
+	template := "## What\n\n## Checklist\n- [ ] Tests added\n"
+	prompt = BuildPrompt(&CommitMessageRequest{Mode: ModePullRequest, Diff: diff, Language: "english", AdditionalContext: template})
+	if !strings.Contains(prompt, "<template>\n"+template+"</template>") || strings.Contains(prompt, "## Changes") {
+		t.Errorf("Expected the template to replace the default sections, got %q", prompt)
+	}
+}
+
+func TestParsePullRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		title   string
+		body    string
+		wantErr bool
+	}{
+		{
+			name:    "title and body",
+			message: "<title>\nAdd parser\n</title>\n<body>\n## Summary\n\nAdds a **parser**.\n</body>",
+			title:   "Add parser",
+			body:    "## Summary\n\nAdds a **parser**.\n",
+		},
+		{
+			name:    "missing title",
+			message: "<body>\n## Summary\n</body>",
+			wantErr: true,
+		},
+		{
+			name:    "multi-line title",
+			message: "<title>\nAdd parser\nand lexer\n</title>\n<body>\ntext\n</body>",
+			wantErr: true,
+		},
+		{
+			name:    "missing body",
+			message: "<title>Add parser</title>",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			title, body, err := ParsePullRequest(tt.message)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePullRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if title != tt.title || body != tt.body {
+				t.Errorf("ParsePullRequest() = %q, %q, want %q, %q", title, body, tt.title, tt.body)
+			}
+		})
+	}
+}
+
+func TestFormatPullRequest(t *testing.T) {
+	if got := FormatPullRequest("Add parser", "## Summary\n\nAdds a parser.\n\n"); got != "Add parser\n\n## Summary\n\nAdds a parser.\n" {
+		t.Errorf("FormatPullRequest() = %q", got)
+	}
+}
+
+func TestBuildChangelogPrompt(t *testing.T) {
+	section := "### Added\n\n- **api:** add export endpoint (abc1234)\n"
+
+	prompt := BuildPrompt(&CommitMessageRequest{Mode: ModeChangelog, Diff: section, Language: "english", AdditionalContext: "Release 1.2.0"})
+	for _, element := range []string{"release notes", "Markdown bullet list", "**api:** add export endpoint (abc1234)", "Release 1.2.0"} {
+		if !strings.Contains(prompt, element) {
+			t.Errorf("Expected changelog prompt to contain '%s'", element)
+		}
+	}
+}
+
+func TestBuildPromptBreakingChanges(t *testing.T) {
+	request := &CommitMessageRequest{Diff: "diff --git a/api.go b/api.go", Language: "english"}
+	if prompt := BuildPrompt(request); strings.Contains(prompt, "BREAKING CHANGE") {
+		t.Error("Expected no breaking change instructions without findings")
+	}
+
+	request.BreakingChanges = []string{"api.go: removed exported Load (func() error)", "api.go: renamed exported Get to Fetch"}
+	prompt := BuildPrompt(request)
+	for _, element := range []string{"- api.go: removed exported Load (func() error)\n- api.go: renamed exported Get to Fetch", `"feat(api)!: ...")`, "BREAKING CHANGE: "} {
+		if !strings.Contains(prompt, element) {
+			t.Errorf("Expected prompt to contain '%s'", element)
+		}
+	}
+
+	request.Mode = ModeStash
+	if prompt := BuildPrompt(request); strings.Contains(prompt, "BREAKING CHANGE") {
+		t.Error("Expected no breaking change instructions in the one-line stash prompt")
+	}
+}
+
+func TestMarksBreakingChange(t *testing.T) {
+	tests := []struct {
+		message string
+		want    bool
+	}{
+		{message: "feat(api)!: drop Load", want: true},
+		{message: "refactor!: rename options\n\nDetails", want: true},
+		{message: "fix: handle errors\n\n- details\nBREAKING CHANGE: Load now returns an error", want: true},
+		{message: "feat: add option\n\nBREAKING-CHANGE: defaults changed", want: true},
+		{message: "feat: add option! really\n\nMentions a BREAKING CHANGE: inline", want: false},
+		{message: "feat: add option", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := MarksBreakingChange(tt.message); got != tt.want {
+			t.Errorf("MarksBreakingChange(%q) = %v, want %v", tt.message, got, tt.want)
+		}
+	}
+}
@@ -82,7 +82,7 @@ func (p *OpenAIProvider) GenerateCommitMessage(ctx context.Context, request *Com
 		Messages: []openai.ChatCompletionMessage{
 			{
 				Role:    openai.ChatMessageRoleSystem,
-				Content: "You are an expert software developer who writes excellent commit messages following Conventional Commits specification. Always respond with plain text only, never use markdown formatting.",
+				Content: systemMessage(request.Mode),
 			},
 			{
 				Role:    openai.ChatMessageRoleUser,
@@ -112,8 +112,12 @@ func (p *OpenAIProvider) GenerateCommitMessage(ctx context.Context, request *Com
 		return nil, err
 	}
 
-	// Clean any markdown formatting from the commit message
-	commitMessage = CleanMarkdownFromCommitMessage(commitMessage)
+	// Clean any markdown formatting from the commit message. Conflict
+	// resolutions are code, pull request descriptions and changelogs are
+	// Markdown, so they are kept verbatim.
+	if request.Mode != ModeConflict && request.Mode != ModePullRequest && request.Mode != ModeChangelog {
+		commitMessage = CleanMarkdownFromCommitMessage(commitMessage)
+	}
 
 	// Calculate confidence based on finish reason and response quality
 	confidence := p.calculateConfidence(response.Choices[0])
@@ -132,6 +136,23 @@ func (p *OpenAIProvider) GenerateCommitMessage(ctx context.Context, request *Com
 	return result, nil
 }
 
+// systemMessage returns the system message for a request mode. Only commit
+// messages follow Conventional Commits in plain text; pull request
+// descriptions and changelogs are Markdown and conflict resolutions are code.
+func systemMessage(mode RequestMode) string {
+	switch mode {
+	case ModePullRequest:
+		return "You are an expert software developer who writes clear pull request descriptions. Respond with the title and a Markdown body exactly as requested."
+	case ModeChangelog:
+		return "You are an expert software developer who writes release notes for users. Respond with Markdown exactly as requested."
+	case ModeConflict:
+		return "You are an expert software developer who resolves merge conflicts. Respond exactly in the requested format, keeping code verbatim."
+	case ModeStash:
+		return "You are an expert software developer who describes work in progress in one short line. Always respond with plain text only, never use markdown formatting."
+	}
+	return "You are an expert software developer who writes excellent commit messages following Conventional Commits specification. Always respond with plain text only, never use markdown formatting."
+}
+
 // calculateConfidence calculates confidence based on the OpenAI response
 func (p *OpenAIProvider) calculateConfidence(choice openai.ChatCompletionChoice) float32 {
 	var confidence float32
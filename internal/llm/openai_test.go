@@ -235,6 +235,22 @@ func TestCalculateConfidence(t *testing.T) {
 	}
 }
 
+func TestSystemMessage(t *testing.T) {
+	for _, mode := range []RequestMode{ModeCommit, ModeSquash, ModeMerge} {
+		if message := systemMessage(mode); !strings.Contains(message, "Conventional Commits") || !strings.Contains(message, "never use markdown") {
+			t.Errorf("Expected a plain text commit system message for %q, got %q", mode, message)
+		}
+	}
+	for _, mode := range []RequestMode{ModePullRequest, ModeChangelog, ModeConflict} {
+		if message := systemMessage(mode); strings.Contains(message, "markdown") || strings.Contains(message, "Conventional Commits") {
+			t.Errorf("Expected the %q system message to allow its format, got %q", mode, message)
+		}
+	}
+	if message := systemMessage(ModeStash); strings.Contains(message, "Conventional Commits") {
+		t.Errorf("Expected no Conventional Commits in the stash system message, got %q", message)
+	}
+}
+
 // Integration test - only runs if OPENAI_API_KEY is set
 func TestGenerateCommitMessageIntegration(t *testing.T) {
 	apiKey := os.Getenv("OPENAI_API_KEY")
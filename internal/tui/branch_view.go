@@ -0,0 +1,364 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/mopemope/git-rovo/internal/git"
+	"github.com/mopemope/git-rovo/internal/logger"
+)
+
+// refreshBranches reloads the local and remote branches
+func (m *Model) refreshBranches() tea.Cmd {
+	return func() tea.Msg {
+		branches, err := m.repo.GetBranches()
+		if err != nil {
+			return errorMsg{error: fmt.Sprintf("Failed to load branches: %v", err)}
+		}
+		return branchesRefreshedMsg{branches: branches}
+	}
+}
+
+// getSelectedBranch returns the branch under the cursor in the branches view
+func (m *Model) getSelectedBranch() *git.BranchInfo {
+	if m.currentView != ViewModeBranches || m.cursor >= len(m.branches) {
+		return nil
+	}
+	return &m.branches[m.cursor]
+}
+
+// checkoutSelectedBranch switches to the selected branch. Remote branches are
+// checked out as a new local branch tracking them.
+func (m *Model) checkoutSelectedBranch() tea.Cmd {
+	branch := m.getSelectedBranch()
+	if branch == nil {
+		return nil
+	}
+	if branch.Current {
+		m.statusMessage = fmt.Sprintf("Already on %s", branch.Name)
+		return nil
+	}
+
+	name, remote := branch.Name, branch.Remote
+	return func() tea.Msg {
+		var err error
+		if remote {
+			err = m.repo.TrackRemoteBranch(name)
+		} else {
+			err = m.repo.CheckoutBranch(name)
+		}
+		if err != nil {
+			return errorMsg{error: fmt.Sprintf("Failed to checkout %s: %v", name, err)}
+		}
+
+		logger.LogUIAction("branch_checked_out", map[string]interface{}{
+			"branch": name,
+			"remote": remote,
+		})
+
+		return operationCompletedMsg{message: fmt.Sprintf("Switched to %s", name)}
+	}
+}
+
+// openCreateBranchPrompt asks for the name of a new branch starting at the
+// selected branch or, in the log view, at the selected commit
+func (m *Model) openCreateBranchPrompt() tea.Cmd {
+	var startPoint string
+	switch m.currentView {
+	case ViewModeBranches:
+		if branch := m.getSelectedBranch(); branch != nil {
+			startPoint = branch.Name
+		}
+	case ViewModeLog, ViewModeCommitDetail:
+		if commit := m.getSelectedCommit(); commit != nil {
+			startPoint = commit.ShortHash
+		}
+	}
+
+	fields := []promptField{
+		{label: "Name", placeholder: "new branch name"},
+		{label: "Start point", value: startPoint, placeholder: "HEAD"},
+	}
+	m.prompt = NewInputPrompt("create_branch", "Create branch", fields, func(values []string) tea.Cmd {
+		name, start := values[0], values[1]
+		if name == "" {
+			return func() tea.Msg { return errorMsg{error: "Branch name cannot be empty"} }
+		}
+
+		return func() tea.Msg {
+			if err := m.repo.CreateBranch(name, start); err != nil {
+				return errorMsg{error: fmt.Sprintf("Failed to create branch: %v", err)}
+			}
+
+			logger.LogUIAction("branch_created", map[string]interface{}{
+				"branch": name,
+				"start":  start,
+			})
+
+			if start == "" {
+				start = "HEAD"
+			}
+			return operationCompletedMsg{message: fmt.Sprintf("Created branch %s at %s", name, start)}
+		}
+	})
+
+	return nil
+}
+
+// openRenameBranchPrompt asks for a new name for the selected local branch
+func (m *Model) openRenameBranchPrompt() tea.Cmd {
+	branch := m.getSelectedBranch()
+	if branch == nil {
+		return nil
+	}
+	if branch.Remote {
+		m.errorMessage = "Remote branches cannot be renamed"
+		return nil
+	}
+
+	oldName := branch.Name
+	fields := []promptField{{label: "New name", value: oldName}}
+	m.prompt = NewInputPrompt("rename_branch", "Rename "+oldName, fields, func(values []string) tea.Cmd {
+		newName := values[0]
+		if newName == "" || newName == oldName {
+			return nil
+		}
+
+		return func() tea.Msg {
+			if err := m.repo.RenameBranch(oldName, newName); err != nil {
+				return errorMsg{error: fmt.Sprintf("Failed to rename branch: %v", err)}
+			}
+
+			logger.LogUIAction("branch_renamed", map[string]interface{}{
+				"from": oldName,
+				"to":   newName,
+			})
+
+			return operationCompletedMsg{message: fmt.Sprintf("Renamed %s to %s", oldName, newName)}
+		}
+	})
+
+	return nil
+}
+
+// openSetUpstreamPrompt asks for the upstream of the selected local branch
+func (m *Model) openSetUpstreamPrompt() tea.Cmd {
+	branch := m.getSelectedBranch()
+	if branch == nil {
+		return nil
+	}
+	if branch.Remote {
+		m.errorMessage = "Upstreams can only be set for local branches"
+		return nil
+	}
+
+	name := branch.Name
+	upstream := branch.Upstream
+	if upstream == "" {
+		upstream = "origin/" + name
+	}
+
+	fields := []promptField{{label: "Upstream", value: upstream, placeholder: "remote/branch"}}
+	m.prompt = NewInputPrompt("set_upstream", "Set upstream of "+name, fields, func(values []string) tea.Cmd {
+		upstream := values[0]
+		if upstream == "" {
+			return nil
+		}
+
+		return func() tea.Msg {
+			if err := m.repo.SetUpstream(name, upstream); err != nil {
+				return errorMsg{error: fmt.Sprintf("Failed to set upstream: %v", err)}
+			}
+
+			logger.LogUIAction("branch_upstream_set", map[string]interface{}{
+				"branch":   name,
+				"upstream": upstream,
+			})
+
+			return operationCompletedMsg{message: fmt.Sprintf("%s now tracks %s", name, upstream)}
+		}
+	})
+
+	return nil
+}
+
+// deleteSelectedBranch deletes the selected local branch. Branches that are not
+// merged into HEAD always ask for confirmation before being force deleted.
+func (m *Model) deleteSelectedBranch() (tea.Model, tea.Cmd) {
+	branch := m.getSelectedBranch()
+	if branch == nil {
+		return m, nil
+	}
+	if branch.Remote {
+		m.errorMessage = "Remote branches cannot be deleted from here"
+		return m, nil
+	}
+	if branch.Current {
+		m.errorMessage = "Cannot delete the current branch"
+		return m, nil
+	}
+
+	merged, err := m.repo.IsBranchMerged(branch.Name)
+	if err != nil {
+		m.errorMessage = fmt.Sprintf("Failed to check merge status: %v", err)
+		return m, nil
+	}
+
+	name, hash := branch.Name, branch.Hash
+	cmd := func() tea.Msg {
+		if err := m.repo.DeleteBranch(name, !merged); err != nil {
+			return errorMsg{error: fmt.Sprintf("Failed to delete branch: %v", err)}
+		}
+
+		logger.LogUIAction("branch_deleted", map[string]interface{}{
+			"branch": name,
+			"hash":   hash,
+			"forced": !merged,
+		})
+
+		return operationCompletedMsg{message: fmt.Sprintf("Deleted branch %s (was %s)", name, hash[:min(8, len(hash))])}
+	}
+
+	if !merged {
+		m.modal = m.deleteBranchConfirmation(*branch, false)
+		m.modal.onConfirm = cmd
+		return m, nil
+	}
+	return m.withConfirmation("delete_branch", cmd, func() *ConfirmModal {
+		return m.deleteBranchConfirmation(*branch, true)
+	})
+}
+
+// deleteBranchConfirmation builds the confirmation modal for deleting a branch
+func (m *Model) deleteBranchConfirmation(branch git.BranchInfo, merged bool) *ConfirmModal {
+	details := []string{fmt.Sprintf("%s %s %s", branch.Name, branch.Hash[:min(8, len(branch.Hash))], branch.Subject)}
+
+	message := "The branch is fully merged into HEAD."
+	if !merged {
+		message = "The branch is NOT merged into HEAD and will be force deleted."
+		if ahead, _, err := m.repo.GetAheadBehind(branch.Name, "HEAD"); err == nil {
+			details = append(details, fmt.Sprintf("%d commits are only reachable from this branch", ahead))
+		}
+		details = append(details, "The tip can be recovered from the reflog: git branch "+branch.Name+" "+branch.Hash)
+	}
+	if branch.Upstream != "" {
+		details = append(details, "Upstream "+branch.Upstream+" is not deleted")
+	}
+
+	return NewConfirmModal("delete_branch", "Delete branch "+branch.Name+"?", message, details, nil)
+}
+
+// renderBranchesView renders the local and remote branches
+func (m *Model) renderBranchesView() string {
+	var content strings.Builder
+
+	local := 0
+	for _, branch := range m.branches {
+		if !branch.Remote {
+			local++
+		}
+	}
+
+	header := fmt.Sprintf("Branches - %d local, %d remote", local, len(m.branches)-local)
+	content.WriteString(m.styles.Header.Width(m.width).Render(header))
+	content.WriteString("\n")
+
+	if len(m.branches) == 0 {
+		content.WriteString(m.styles.Info.Render("No branches found"))
+		return content.String()
+	}
+
+	nameWidth := 0
+	for _, branch := range m.branches {
+		nameWidth = max(nameWidth, len(branch.Name))
+	}
+
+	var lines []string
+	cursorLine := 0
+	for i, branch := range m.branches {
+		switch {
+		case i == 0 && !branch.Remote:
+			lines = append(lines, m.styles.Success.Render("Local branches:"))
+		case branch.Remote && (i == 0 || !m.branches[i-1].Remote):
+			if i > 0 {
+				lines = append(lines, "")
+			}
+			lines = append(lines, m.styles.Success.Render("Remote branches:"))
+		}
+		if i == m.cursor {
+			cursorLine = len(lines)
+		}
+		lines = append(lines, m.renderBranchLine(branch, nameWidth, i == m.cursor))
+	}
+
+	// Keep the cursor visible
+	maxVisible := max(m.getMaxVisibleLines(), 1)
+	start := 0
+	if cursorLine >= maxVisible {
+		start = cursorLine - maxVisible + 1
+	}
+	end := min(start+maxVisible, len(lines))
+
+	content.WriteString(strings.Join(lines[start:end], "\n"))
+	return content.String()
+}
+
+// renderBranchLine renders a single branch with its tracking state and last commit
+func (m *Model) renderBranchLine(branch git.BranchInfo, nameWidth int, selected bool) string {
+	prefix := "  "
+	if selected {
+		prefix = "> "
+	}
+
+	marker := "  "
+	if branch.Current {
+		marker = "* "
+	}
+
+	name := fmt.Sprintf("%-*s", nameWidth, branch.Name)
+	switch {
+	case selected:
+		name = m.styles.Selected.Render(name)
+	case branch.Current:
+		name = m.styles.Success.Render(name)
+	case branch.Remote:
+		name = m.styles.Untracked.Render(name)
+	}
+
+	line := prefix + marker + name + "  " + m.styles.Warning.Render(branch.Hash[:min(8, len(branch.Hash))])
+
+	if tracking := describeTracking(branch); tracking != "" {
+		line += "  " + m.styles.Info.Render("["+tracking+"]")
+	}
+
+	line += "  " + branch.Subject
+	if !branch.Date.IsZero() {
+		line += m.styles.Help.Render(fmt.Sprintf(" (%s, %s)", branch.Author, branch.Date.Format("2006-01-02")))
+	}
+
+	return line
+}
+
+// describeTracking describes the upstream and ahead/behind counts of a branch
+func describeTracking(branch git.BranchInfo) string {
+	if branch.Upstream == "" {
+		return ""
+	}
+
+	parts := []string{branch.Upstream}
+	switch {
+	case branch.UpstreamGone:
+		parts = append(parts, "gone")
+	case branch.Ahead == 0 && branch.Behind == 0:
+		parts = append(parts, "up to date")
+	default:
+		if branch.Ahead > 0 {
+			parts = append(parts, fmt.Sprintf("↑%d", branch.Ahead))
+		}
+		if branch.Behind > 0 {
+			parts = append(parts, fmt.Sprintf("↓%d", branch.Behind))
+		}
+	}
+	return strings.Join(parts, " ")
+}
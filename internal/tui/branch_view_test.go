@@ -0,0 +1,187 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/mopemope/git-rovo/internal/git"
+)
+
+// setupBranchRepoTest creates a model backed by a temporary repository with
+// a merged branch and an unmerged branch
+func setupBranchRepoTest(t *testing.T) *Model {
+	t.Helper()
+
+	model := setupMainViewTest(t)
+	dir, run := setupGitRepo(t)
+	run("commit", "-q", "--allow-empty", "-m", "Initial commit")
+	run("branch", "merged")
+	run("switch", "-q", "-c", "unmerged")
+	run("commit", "-q", "--allow-empty", "-m", "Unmerged work")
+	run("switch", "-q", "main")
+	repo := openGitRepo(t, model, dir)
+
+	branches, err := repo.GetBranches()
+	if err != nil {
+		t.Fatalf("Failed to get branches: %v", err)
+	}
+	model.branches = branches
+	model.currentView = ViewModeBranches
+	return model
+}
+
+func selectBranch(t *testing.T, model *Model, name string) {
+	t.Helper()
+	for i, branch := range model.branches {
+		if branch.Name == name {
+			model.cursor = i
+			return
+		}
+	}
+	t.Fatalf("Branch %s not found", name)
+}
+
+func TestRenderBranchesView(t *testing.T) {
+	model := setupMainViewTest(t)
+	model.width = 120
+	model.height = 40
+	model.currentView = ViewModeBranches
+	model.branches = []git.BranchInfo{
+		{Name: "main", Current: true, Upstream: "origin/main", Ahead: 2, Behind: 1, Hash: "0123456789abcdef", Subject: "feat: latest", Author: "Dev", Date: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)},
+		{Name: "old", Upstream: "origin/old", UpstreamGone: true, Hash: "89abcdef01234567", Subject: "chore: old"},
+		{Name: "origin/main", Remote: true, Hash: "fedcba9876543210", Subject: "feat: remote"},
+	}
+
+	output := model.renderBranchesView()
+	expected := []string{
+		"2 local, 1 remote", "Local branches:", "Remote branches:",
+		"* ", "main", "01234567", "origin/main ↑2 ↓1", "feat: latest", "Dev, 2024-05-01",
+		"origin/old gone", "fedcba98",
+	}
+	for _, want := range expected {
+		if !contains(output, want) {
+			t.Errorf("Expected branches view to contain %q", want)
+		}
+	}
+}
+
+func TestBranchActionsGuards(t *testing.T) {
+	model := setupMainViewTest(t)
+	model.currentView = ViewModeBranches
+	model.branches = []git.BranchInfo{
+		{Name: "main", Current: true, Hash: "0123456789abcdef"},
+		{Name: "origin/main", Remote: true, Hash: "0123456789abcdef"},
+	}
+
+	// Checking out the current branch is a no-op
+	if _, cmd := model.executeAction("checkout_branch"); cmd != nil {
+		t.Error("Expected no checkout for the current branch")
+	}
+
+	model.executeAction("delete_branch")
+	if !contains(model.errorMessage, "current branch") {
+		t.Errorf("Expected current branch error, got %q", model.errorMessage)
+	}
+
+	model.cursor = 1
+	for _, action := range []string{"rename_branch", "delete_branch", "set_upstream"} {
+		model.errorMessage = ""
+		model.executeAction(action)
+		if model.errorMessage == "" || model.prompt != nil || model.modal != nil {
+			t.Errorf("Expected %s to be refused for a remote branch", action)
+		}
+	}
+
+	// Remote branches are checked out as tracking branches
+	if _, cmd := model.executeAction("checkout_branch"); cmd == nil {
+		t.Error("Expected checkout command for remote branch")
+	}
+}
+
+func TestCreateBranchPromptStartPoint(t *testing.T) {
+	model := setupMainViewTest(t)
+	model.currentView = ViewModeLog
+	model.commitHistory = makeTestCommits(3)
+	model.cursor = 2
+
+	model.executeAction("create_branch")
+	if model.prompt == nil {
+		t.Fatal("Expected create branch prompt")
+	}
+	if model.prompt.fields[1].value != model.commitHistory[2].ShortHash {
+		t.Errorf("Expected start point of selected commit, got %q", model.prompt.fields[1].value)
+	}
+
+	// An empty name is rejected
+	_, cmd := model.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	if msg, ok := cmd().(errorMsg); !ok || !contains(msg.error, "empty") {
+		t.Errorf("Expected empty name error, got %#v", msg)
+	}
+}
+
+func TestDeleteMergedBranch(t *testing.T) {
+	model := setupBranchRepoTest(t)
+	model.config.UI.ConfirmActions = []string{"delete_branch"}
+	selectBranch(t, model, "merged")
+
+	model.executeAction("delete_branch")
+	if model.modal == nil {
+		t.Fatal("Expected confirmation modal")
+	}
+	if !contains(model.modal.message, "fully merged") {
+		t.Errorf("Unexpected modal message %q", model.modal.message)
+	}
+
+	_, cmd := model.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if _, ok := cmd().(operationCompletedMsg); !ok {
+		t.Fatal("Expected branch to be deleted")
+	}
+
+	branches, _ := model.repo.GetBranches()
+	for _, branch := range branches {
+		if branch.Name == "merged" {
+			t.Error("Expected merged branch to be deleted")
+		}
+	}
+}
+
+func TestDeleteUnmergedBranchAlwaysConfirms(t *testing.T) {
+	model := setupBranchRepoTest(t)
+	model.config.UI.ConfirmActions = nil
+	selectBranch(t, model, "unmerged")
+
+	model.executeAction("delete_branch")
+	if model.modal == nil {
+		t.Fatal("Expected confirmation modal for unmerged branch even without confirm_actions")
+	}
+	if !contains(model.modal.message, "NOT merged") {
+		t.Errorf("Unexpected modal message %q", model.modal.message)
+	}
+	found := false
+	for _, detail := range model.modal.details {
+		if contains(detail, "1 commits are only reachable") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected unmerged commit count in %v", model.modal.details)
+	}
+
+	// Cancelling keeps the branch
+	model.handleKeyPress(tea.KeyMsg{Type: tea.KeyEsc})
+	branches, _ := model.repo.GetBranches()
+	if len(branches) != 3 {
+		t.Errorf("Expected branch to be kept, got %d branches", len(branches))
+	}
+
+	model.executeAction("delete_branch")
+	_, cmd := model.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if _, ok := cmd().(operationCompletedMsg); !ok {
+		t.Fatal("Expected unmerged branch to be force deleted")
+	}
+	branches, _ = model.repo.GetBranches()
+	if len(branches) != 2 {
+		t.Errorf("Expected branch to be deleted, got %d branches", len(branches))
+	}
+}
@@ -0,0 +1,58 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mopemope/git-rovo/internal/git"
+	"github.com/mopemope/git-rovo/internal/llm"
+	"github.com/mopemope/git-rovo/internal/logger"
+)
+
+// analyzeBreakingChanges describes the likely breaking changes of the staged
+// diffs. The analysis is a hint for the LLM, so failures are only logged.
+func (m *Model) analyzeBreakingChanges(diffs []git.DiffInfo) []string {
+	changes, err := m.repo.AnalyzeBreakingChanges(diffs)
+	if err != nil {
+		logger.Warn("Breaking change analysis failed", "error", err)
+		return nil
+	}
+
+	findings := make([]string, 0, len(changes))
+	for _, change := range changes {
+		findings = append(findings, change.String())
+	}
+	return findings
+}
+
+// breakingChangeWarning returns a warning when likely breaking changes were
+// found but the generated message does not mark them
+func breakingChangeWarning(message string, findings []string) string {
+	if len(findings) == 0 || llm.MarksBreakingChange(message) {
+		return ""
+	}
+	return fmt.Sprintf("%d likely breaking changes are not marked with '!' or a BREAKING CHANGE footer", len(findings))
+}
+
+// renderBreakingChanges renders the likely breaking changes below the
+// generated commit message
+func (m *Model) renderBreakingChanges() string {
+	if len(m.breakingChanges) == 0 {
+		return ""
+	}
+
+	var content strings.Builder
+	style := m.styles.Help
+	title := " Likely breaking changes (marked in the message):"
+	if warning := breakingChangeWarning(m.generatedMessage, m.breakingChanges); warning != "" {
+		style = m.styles.Warning
+		title = " ⚠ " + warning + ":"
+	}
+	content.WriteString(style.Render(title))
+	content.WriteString("\n")
+	for _, finding := range m.breakingChanges {
+		content.WriteString(m.styles.Help.Render("   • " + finding))
+		content.WriteString("\n")
+	}
+	return content.String()
+}
@@ -0,0 +1,41 @@
+package tui
+
+import (
+	"testing"
+)
+
+func TestGenerateCommitMessageWithBreakingChanges(t *testing.T) {
+	model := setupMainViewTest(t)
+	model.width = 120
+	model.height = 40
+	dir, run := setupGitRepo(t)
+	writeRepoFile(t, dir, "api.go", "package api\n\nfunc Load(path string) error { return nil }\n")
+	run("add", ".")
+	run("commit", "-q", "-m", "feat: add api")
+	writeRepoFile(t, dir, "api.go", "package api\n\nfunc Load(path string, strict bool) error { return nil }\n")
+	run("add", ".")
+	openGitRepo(t, model, dir)
+	provider := useScriptedLLM(t, model, "feat: add strict loading")
+
+	model.Update(model.generateCommitMessage()())
+
+	expected := "api.go: changed exported Load from func(path string) error to func(path string, strict bool) error"
+	if len(provider.requests) != 1 || len(provider.requests[0].BreakingChanges) != 1 || provider.requests[0].BreakingChanges[0] != expected {
+		t.Fatalf("Expected the finding in the request, got %+v", provider.requests)
+	}
+	if !contains(model.statusMessage, "1 likely breaking changes are not marked") {
+		t.Errorf("Expected a warning for the unmarked message, got %q", model.statusMessage)
+	}
+	if output := model.renderCommitMessageSection(); !contains(output, "not marked") || !contains(output, "• "+expected) {
+		t.Errorf("Expected the findings below the message, got %q", output)
+	}
+
+	provider.message = "feat(api)!: add strict loading\n\nBREAKING CHANGE: Load takes a strict flag"
+	model.Update(model.generateCommitMessage()())
+	if contains(model.statusMessage, "not marked") {
+		t.Errorf("Expected no warning for a marked message, got %q", model.statusMessage)
+	}
+	if output := model.renderCommitMessageSection(); !contains(output, "marked in the message") {
+		t.Errorf("Expected the findings to be listed, got %q", output)
+	}
+}
@@ -0,0 +1,234 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/mopemope/git-rovo/internal/git"
+	"github.com/mopemope/git-rovo/internal/logger"
+)
+
+// loadCommitDetails loads the metadata and file statistics of a commit
+func (m *Model) loadCommitDetails(hash string) tea.Cmd {
+	return func() tea.Msg {
+		details, err := m.repo.GetCommitDetails(hash)
+		if err != nil {
+			return errorMsg{error: fmt.Sprintf("Failed to load commit details: %v", err)}
+		}
+
+		logger.LogUIAction("commit_details_viewed", map[string]interface{}{
+			"commit": hash,
+			"files":  len(details.Files),
+		})
+
+		return commitDetailsLoadedMsg{details: details}
+	}
+}
+
+// returnToLog switches back to the log view, restoring the selected commit
+func (m *Model) returnToLog() *Model {
+	cursor := m.logViewState.selectedCommit
+	m.switchView(ViewModeLog)
+	if cursor < len(m.commitHistory) {
+		m.cursor = cursor
+	}
+	return m
+}
+
+// getCommitDetailFiles returns the changed files of the displayed commit
+func (m *Model) getCommitDetailFiles() []git.FileStat {
+	if m.commitDetails == nil {
+		return nil
+	}
+	return m.commitDetails.Files
+}
+
+// handleShowCommitFileDiff opens the diff of the selected file in the commit
+func (m *Model) handleShowCommitFileDiff() (tea.Model, tea.Cmd) {
+	files := m.getCommitDetailFiles()
+	if m.cursor >= len(files) {
+		return m, nil
+	}
+
+	hash := m.commitDetails.Hash
+	path := files[m.cursor].Path
+	m = m.switchView(ViewModeDiff)
+	return m, m.showCommitDiff(hash, path)
+}
+
+// handleShowFullCommitDiff opens the diff of the whole commit
+func (m *Model) handleShowFullCommitDiff() (tea.Model, tea.Cmd) {
+	if m.commitDetails == nil {
+		return m, nil
+	}
+
+	hash := m.commitDetails.Hash
+	m = m.switchView(ViewModeDiff)
+	return m, m.showCommitDiff(hash, "")
+}
+
+// renderCommitDetailView renders the full metadata and file table of a commit
+func (m *Model) renderCommitDetailView() string {
+	details := m.commitDetails
+	if details == nil {
+		return m.styles.Loading.Render("Loading commit details...")
+	}
+
+	var content strings.Builder
+	content.WriteString(m.styles.Header.Width(m.width).Render(fmt.Sprintf("Commit %s", details.ShortHash)))
+	content.WriteString("\n")
+
+	lines, fileStart := m.commitDetailLines(details)
+
+	// Keep the selected file visible
+	maxVisible := max(m.getMaxVisibleLines(), 1)
+	start := 0
+	if selected := fileStart + m.cursor; len(details.Files) > 0 && selected >= maxVisible {
+		start = selected - maxVisible + 1
+	}
+	end := min(start+maxVisible, len(lines))
+
+	content.WriteString(strings.Join(lines[start:end], "\n"))
+	return content.String()
+}
+
+// commitDetailLines returns the rendered lines of the commit detail view and
+// the index of the first file table row
+func (m *Model) commitDetailLines(details *git.CommitDetails) ([]string, int) {
+	var lines []string
+	add := func(line string) {
+		lines = append(lines, line)
+	}
+
+	hashLine := "commit " + details.Hash
+	if len(details.Refs) > 0 {
+		hashLine += fmt.Sprintf(" (%s)", strings.Join(details.Refs, ", "))
+	}
+	add(m.styles.Warning.Render(hashLine))
+
+	add(m.styles.Info.Render(fmt.Sprintf("Author:    %s <%s>  %s",
+		details.Author, details.AuthorEmail, details.Date.Format("2006-01-02 15:04:05 -0700"))))
+	add(m.styles.Info.Render(fmt.Sprintf("Committer: %s <%s>  %s",
+		details.Committer, details.CommitterEmail, details.CommitDate.Format("2006-01-02 15:04:05 -0700"))))
+
+	if len(details.Parents) > 0 {
+		var parents []string
+		for _, parent := range details.Parents {
+			parents = append(parents, parent[:min(8, len(parent))])
+		}
+		label := "Parent:    "
+		if len(parents) > 1 {
+			label = "Merge:     "
+		}
+		add(m.styles.Base.Render(label + strings.Join(parents, " ")))
+	}
+
+	if signature := describeSignature(details.Signature); signature != "" {
+		add(m.styles.Base.Render("Signature: " + signature))
+	}
+
+	// Message
+	add("")
+	add(m.styles.Selected.Render("    " + details.Subject))
+	if body := bodyWithoutTrailers(details.Body, details.Trailers); body != "" {
+		add("")
+		for _, line := range strings.Split(body, "\n") {
+			add(m.styles.Base.Render("    " + line))
+		}
+	}
+
+	if len(details.Trailers) > 0 {
+		add("")
+		for _, trailer := range details.Trailers {
+			add(m.styles.Help.Render(fmt.Sprintf("    %s: %s", trailer.Key, trailer.Value)))
+		}
+	}
+
+	// File table
+	additions, deletions := 0, 0
+	for _, file := range details.Files {
+		additions += file.Additions
+		deletions += file.Deletions
+	}
+	add("")
+	add(m.styles.Success.Render(fmt.Sprintf("Files (%d changed, +%d -%d):", len(details.Files), additions, deletions)))
+
+	fileStart := len(lines)
+	for i, file := range details.Files {
+		add(m.renderCommitFileStat(file, i == m.cursor))
+	}
+	if len(details.Files) == 0 {
+		add(m.styles.Help.Render("  No file changes"))
+	}
+
+	return lines, fileStart
+}
+
+// renderCommitFileStat renders one row of the commit file table
+func (m *Model) renderCommitFileStat(file git.FileStat, selected bool) string {
+	prefix := "  "
+	if selected {
+		prefix = "> "
+	}
+
+	path := file.Path
+	if file.OldPath != "" {
+		path = fmt.Sprintf("%s → %s", file.OldPath, file.Path)
+	}
+
+	var counts string
+	if file.Binary {
+		counts = m.styles.Help.Render(fmt.Sprintf("%13s", "binary"))
+	} else {
+		counts = m.styles.DiffAdd.Render(fmt.Sprintf("%6s", fmt.Sprintf("+%d", file.Additions))) + " " +
+			m.styles.DiffRemove.Render(fmt.Sprintf("%6s", fmt.Sprintf("-%d", file.Deletions)))
+	}
+
+	if selected {
+		return m.styles.Selected.Render(prefix) + counts + "  " + m.styles.Selected.Render(path)
+	}
+	return prefix + counts + "  " + m.styles.Base.Render(path)
+}
+
+// bodyWithoutTrailers strips the trailer block from a message body, since
+// trailers are listed separately
+func bodyWithoutTrailers(body string, trailers []git.Trailer) string {
+	body = strings.TrimSpace(body)
+	if len(trailers) == 0 {
+		return body
+	}
+
+	paragraphs := strings.Split(body, "\n\n")
+	last := paragraphs[len(paragraphs)-1]
+	for _, line := range strings.Split(last, "\n") {
+		// Continuation lines of folded trailers start with whitespace
+		if !strings.Contains(line, ":") && !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+			return body
+		}
+	}
+
+	return strings.TrimSpace(strings.Join(paragraphs[:len(paragraphs)-1], "\n\n"))
+}
+
+// describeSignature returns a human readable description of a %G? status
+func describeSignature(status string) string {
+	switch status {
+	case "G":
+		return "good"
+	case "B":
+		return "bad"
+	case "U":
+		return "good, unknown validity"
+	case "X":
+		return "good, expired"
+	case "Y":
+		return "good, made by an expired key"
+	case "R":
+		return "good, made by a revoked key"
+	case "E":
+		return "cannot be checked (missing key)"
+	default:
+		return ""
+	}
+}
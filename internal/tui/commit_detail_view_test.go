@@ -0,0 +1,139 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mopemope/git-rovo/internal/git"
+)
+
+func setupCommitDetailTest(t *testing.T) *Model {
+	t.Helper()
+
+	model := setupMainViewTest(t)
+	model.width = 100
+	model.height = 40
+	model.commitHistory = makeTestCommits(5)
+	model.commitDetails = &git.CommitDetails{
+		CommitInfo: git.CommitInfo{
+			Hash:           "0123456789abcdef0123456789abcdef01234567",
+			ShortHash:      "01234567",
+			Author:         "Jane",
+			AuthorEmail:    "jane@example.com",
+			Date:           time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC),
+			Committer:      "John",
+			CommitterEmail: "john@example.com",
+			CommitDate:     time.Date(2024, 3, 2, 11, 0, 0, 0, time.UTC),
+			Subject:        "feat: add parser",
+			Body:           "Explain the parser.\n\nRefs: #42",
+			Parents:        []string{"89abcdef0123456789abcdef0123456789abcdef"},
+			Refs:           []string{"HEAD -> main"},
+			Signature:      "G",
+		},
+		Trailers: []git.Trailer{{Key: "Refs", Value: "#42"}},
+		Files: []git.FileStat{
+			{Path: "parser.go", Additions: 120, Deletions: 4},
+			{Path: "new.go", OldPath: "old.go", Additions: 1, Deletions: 1},
+			{Path: "logo.png", Binary: true},
+		},
+	}
+	return model
+}
+
+func TestShowCommitDetailsOpensDetailView(t *testing.T) {
+	model := setupMainViewTest(t)
+	model.currentView = ViewModeLog
+	model.commitHistory = makeTestCommits(5)
+	model.cursor = 3
+
+	_, cmd := model.executeAction("show_commit_details")
+	if model.currentView != ViewModeCommitDetail {
+		t.Errorf("Expected commit detail view, got %v", model.currentView)
+	}
+	if cmd == nil {
+		t.Error("Expected command to load commit details")
+	}
+	if model.logViewState.selectedCommit != 3 {
+		t.Errorf("Expected log position to be remembered, got %d", model.logViewState.selectedCommit)
+	}
+
+	model.executeAction("back_to_log")
+	if model.currentView != ViewModeLog || model.cursor != 3 {
+		t.Errorf("Expected to return to log at commit 3, got view %v cursor %d", model.currentView, model.cursor)
+	}
+}
+
+func TestRenderCommitDetailView(t *testing.T) {
+	model := setupCommitDetailTest(t)
+	model.currentView = ViewModeCommitDetail
+
+	output := model.renderCommitDetailView()
+	expected := []string{
+		"commit 0123456789abcdef", "HEAD -> main",
+		"Jane <jane@example.com>", "2024-03-01",
+		"John <john@example.com>", "2024-03-02",
+		"Parent:    89abcdef", "Signature: good",
+		"feat: add parser", "Explain the parser.", "Refs: #42",
+		"Files (3 changed, +121 -5)", "parser.go", "old.go → new.go", "binary",
+	}
+	for _, want := range expected {
+		if !contains(output, want) {
+			t.Errorf("Expected commit detail view to contain %q", want)
+		}
+	}
+}
+
+func TestCommitDetailFileNavigation(t *testing.T) {
+	model := setupCommitDetailTest(t)
+	model.currentView = ViewModeCommitDetail
+
+	model.executeAction("nav_down")
+	model.executeAction("nav_down")
+	model.executeAction("nav_down")
+	if model.cursor != 2 {
+		t.Errorf("Expected cursor on last file, got %d", model.cursor)
+	}
+
+	model.executeAction("nav_up")
+	_, cmd := model.executeAction("show_file_diff")
+	if model.currentView != ViewModeDiff {
+		t.Errorf("Expected diff view, got %v", model.currentView)
+	}
+	if cmd == nil {
+		t.Error("Expected command to load the file diff")
+	}
+
+	// The loaded commit diff selects the chosen file
+	model.Update(commitDiffRefreshedMsg{
+		diffs:      []git.DiffInfo{{FilePath: "parser.go"}, {FilePath: "new.go"}, {FilePath: "logo.png"}},
+		commitHash: model.commitDetails.Hash,
+		selectPath: "new.go",
+	})
+	if model.diffViewState.selectedFile != 1 {
+		t.Errorf("Expected new.go to be selected, got %d", model.diffViewState.selectedFile)
+	}
+}
+
+func TestBodyWithoutTrailers(t *testing.T) {
+	trailers := []git.Trailer{{Key: "Signed-off-by", Value: "Dev"}}
+
+	tests := []struct {
+		name     string
+		body     string
+		trailers []git.Trailer
+		expected string
+	}{
+		{"no trailers", "Body text.\n\nMore: text", nil, "Body text.\n\nMore: text"},
+		{"trailer block", "Body text.\n\nSigned-off-by: Dev", trailers, "Body text."},
+		{"only trailers", "Signed-off-by: Dev\n", trailers, ""},
+		{"prose last paragraph", "Body text.\n\nNot a trailer line", trailers, "Body text.\n\nNot a trailer line"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := bodyWithoutTrailers(tt.body, tt.trailers); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/mopemope/git-rovo/internal/git"
+)
+
+// commitGraphRow holds the graph columns drawn next to a single commit entry
+type commitGraphRow struct {
+	node       string // Row containing the commit node
+	transition string // Row showing merges and branch points below the node
+	padding    string // Row for any further lines of the entry
+}
+
+// line returns the graph prefix for the n-th line of a commit entry
+func (r commitGraphRow) line(n int) string {
+	switch n {
+	case 0:
+		return r.node
+	case 1:
+		return r.transition
+	default:
+		return r.padding
+	}
+}
+
+// graphConnection is a horizontal link between the commit lane and another lane
+type graphConnection struct {
+	lane  int
+	glyph rune
+}
+
+// buildCommitGraph assigns each commit to a lane and renders the graph rows,
+// similar to `git log --graph`. Commits must be ordered children first.
+func buildCommitGraph(commits []git.CommitInfo) []commitGraphRow {
+	rows := make([]commitGraphRow, 0, len(commits))
+	var lanes []string // Hash each lane is waiting for, "" when free
+	width := 0
+
+	for _, commit := range commits {
+		// Find the lane waiting for this commit, or open a new one
+		col := indexOfLane(lanes, commit.Hash)
+		if col < 0 {
+			col = firstFreeLane(lanes, nil)
+			if col == len(lanes) {
+				lanes = append(lanes, "")
+			}
+			lanes[col] = commit.Hash
+		}
+
+		nodeCells := make([]rune, len(lanes))
+		for i, lane := range lanes {
+			switch {
+			case i == col:
+				nodeCells[i] = '●'
+			case lane != "":
+				nodeCells[i] = '│'
+			default:
+				nodeCells[i] = ' '
+			}
+		}
+
+		// Other lanes waiting for the same commit end here (branch point)
+		var connections []graphConnection
+		ended := make(map[int]bool)
+		for i, lane := range lanes {
+			if i != col && lane == commit.Hash {
+				lanes[i] = ""
+				ended[i] = true
+				connections = append(connections, graphConnection{i, cornerGlyph(i, col, '╯', '╰')})
+			}
+		}
+
+		// The first parent continues the commit lane, further parents branch out
+		hasParent := len(commit.Parents) > 0
+		if hasParent {
+			lanes[col] = commit.Parents[0]
+		} else {
+			lanes[col] = ""
+		}
+		for _, parent := range commit.Parents[min(1, len(commit.Parents)):] {
+			if k := indexOfLane(lanes, parent); k >= 0 && k != col {
+				connections = append(connections, graphConnection{k, cornerGlyph(k, col, '┤', '├')})
+				continue
+			}
+			k := firstFreeLane(lanes, func(i int) bool { return i == col || ended[i] })
+			if k == len(lanes) {
+				lanes = append(lanes, "")
+			}
+			lanes[k] = parent
+			connections = append(connections, graphConnection{k, cornerGlyph(k, col, '╮', '╭')})
+		}
+
+		transition := renderTransition(lanes, len(nodeCells), col, hasParent, connections)
+
+		// Drop trailing free lanes so the graph does not grow forever
+		for len(lanes) > 0 && lanes[len(lanes)-1] == "" {
+			lanes = lanes[:len(lanes)-1]
+		}
+
+		padding := make([]rune, len(lanes))
+		for i, lane := range lanes {
+			if lane != "" {
+				padding[i] = '│'
+			} else {
+				padding[i] = ' '
+			}
+		}
+
+		row := commitGraphRow{
+			node:       joinGraphCells(nodeCells, nil),
+			transition: transition,
+			padding:    joinGraphCells(padding, nil),
+		}
+		for _, s := range []string{row.node, row.transition, row.padding} {
+			width = max(width, len([]rune(s)))
+		}
+		rows = append(rows, row)
+	}
+
+	// Pad all rows to the same width so entries line up
+	for i := range rows {
+		rows[i].node = padGraph(rows[i].node, width)
+		rows[i].transition = padGraph(rows[i].transition, width)
+		rows[i].padding = padGraph(rows[i].padding, width)
+	}
+
+	return rows
+}
+
+// renderTransition draws the row below a commit node, joining the commit lane
+// horizontally to lanes that end, start or receive a merge at this commit
+func renderTransition(lanes []string, nodeWidth, col int, hasParent bool, connections []graphConnection) string {
+	cells := make([]rune, max(len(lanes), nodeWidth))
+	for i := range cells {
+		if i < len(lanes) && lanes[i] != "" {
+			cells[i] = '│'
+		} else {
+			cells[i] = ' '
+		}
+	}
+
+	if len(connections) == 0 {
+		return joinGraphCells(cells, nil)
+	}
+
+	left, right := col, col
+	for _, conn := range connections {
+		left = min(left, conn.lane)
+		right = max(right, conn.lane)
+	}
+
+	// Horizontal line between the outermost connections
+	spacers := make([]rune, len(cells))
+	for i := range spacers {
+		spacers[i] = ' '
+	}
+	for i := left; i < right; i++ {
+		spacers[i] = '─'
+	}
+	for i := left + 1; i < right; i++ {
+		if cells[i] == '│' {
+			cells[i] = '┼'
+		} else {
+			cells[i] = '─'
+		}
+	}
+
+	for _, conn := range connections {
+		cells[conn.lane] = conn.glyph
+	}
+
+	// Junction on the commit lane
+	toLeft, toRight := left < col, right > col
+	switch {
+	case toLeft && toRight:
+		if hasParent {
+			cells[col] = '┼'
+		} else {
+			cells[col] = '┴'
+		}
+	case toRight:
+		if hasParent {
+			cells[col] = '├'
+		} else {
+			cells[col] = '╰'
+		}
+	case toLeft:
+		if hasParent {
+			cells[col] = '┤'
+		} else {
+			cells[col] = '╯'
+		}
+	}
+
+	return joinGraphCells(cells, spacers)
+}
+
+// cornerGlyph picks the glyph for a lane depending on which side of the commit lane it is
+func cornerGlyph(lane, col int, right, left rune) rune {
+	if lane > col {
+		return right
+	}
+	return left
+}
+
+// joinGraphCells interleaves lane glyphs with the spacer column that follows each lane
+func joinGraphCells(cells, spacers []rune) string {
+	var b strings.Builder
+	for i, cell := range cells {
+		b.WriteRune(cell)
+		if spacers != nil {
+			b.WriteRune(spacers[i])
+		} else {
+			b.WriteRune(' ')
+		}
+	}
+	return strings.TrimRight(b.String(), " ")
+}
+
+// padGraph right-pads a graph row to width runes plus a separating space
+func padGraph(row string, width int) string {
+	return row + strings.Repeat(" ", width-len([]rune(row))+1)
+}
+
+// indexOfLane returns the lane waiting for hash, or -1
+func indexOfLane(lanes []string, hash string) int {
+	for i, lane := range lanes {
+		if lane == hash {
+			return i
+		}
+	}
+	return -1
+}
+
+// firstFreeLane returns the first free lane not excluded by skip, or len(lanes)
+func firstFreeLane(lanes []string, skip func(int) bool) int {
+	for i, lane := range lanes {
+		if lane == "" && (skip == nil || !skip(i)) {
+			return i
+		}
+	}
+	return len(lanes)
+}
@@ -0,0 +1,115 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mopemope/git-rovo/internal/git"
+)
+
+func TestBuildCommitGraphLinear(t *testing.T) {
+	commits := []git.CommitInfo{
+		{Hash: "c", Parents: []string{"b"}},
+		{Hash: "b", Parents: []string{"a"}},
+		{Hash: "a"},
+	}
+
+	rows := buildCommitGraph(commits)
+	if len(rows) != 3 {
+		t.Fatalf("Expected 3 rows, got %d", len(rows))
+	}
+
+	expected := []struct{ node, padding string }{
+		{"● ", "│ "},
+		{"● ", "│ "},
+		{"● ", "  "},
+	}
+	for i, want := range expected {
+		if rows[i].node != want.node {
+			t.Errorf("Row %d: expected node %q, got %q", i, want.node, rows[i].node)
+		}
+		if rows[i].padding != want.padding {
+			t.Errorf("Row %d: expected padding %q, got %q", i, want.padding, rows[i].padding)
+		}
+	}
+}
+
+func TestBuildCommitGraphMerge(t *testing.T) {
+	// m merges feature (f) into main (b); both branch off a
+	commits := []git.CommitInfo{
+		{Hash: "m", Parents: []string{"b", "f"}},
+		{Hash: "f", Parents: []string{"a"}},
+		{Hash: "b", Parents: []string{"a"}},
+		{Hash: "a"},
+	}
+
+	rows := buildCommitGraph(commits)
+	if len(rows) != 4 {
+		t.Fatalf("Expected 4 rows, got %d", len(rows))
+	}
+
+	// The merge opens a second lane for the feature parent
+	if !strings.HasPrefix(rows[0].node, "●") {
+		t.Errorf("Expected merge node in first lane, got %q", rows[0].node)
+	}
+	if !strings.HasPrefix(rows[0].transition, "├─╮") {
+		t.Errorf("Expected merge transition, got %q", rows[0].transition)
+	}
+
+	// The feature commit sits in the second lane
+	if !strings.HasPrefix(rows[1].node, "│ ●") {
+		t.Errorf("Expected feature node in second lane, got %q", rows[1].node)
+	}
+
+	// At the branch point the feature lane joins back into the first lane
+	if !strings.HasPrefix(rows[3].node, "● │") {
+		t.Errorf("Expected branch point node, got %q", rows[3].node)
+	}
+	if !strings.HasPrefix(rows[3].transition, "╰─╯") {
+		t.Errorf("Expected branch point transition, got %q", rows[3].transition)
+	}
+}
+
+func TestBuildCommitGraphWidth(t *testing.T) {
+	commits := []git.CommitInfo{
+		{Hash: "m", Parents: []string{"b", "f"}},
+		{Hash: "f", Parents: []string{"a"}},
+		{Hash: "b", Parents: []string{"a"}},
+		{Hash: "a"},
+	}
+
+	rows := buildCommitGraph(commits)
+	width := len([]rune(rows[0].node))
+	for i, row := range rows {
+		for _, line := range []string{row.node, row.transition, row.padding} {
+			if got := len([]rune(line)); got != width {
+				t.Errorf("Row %d: expected width %d, got %d (%q)", i, width, got, line)
+			}
+		}
+	}
+}
+
+func TestRenderCommitEntryWithGraph(t *testing.T) {
+	model := setupMainViewTest(t)
+	model.width = 80
+	commits := []git.CommitInfo{
+		{Hash: "bbbb", ShortHash: "bbbb", Subject: "second", Author: "Dev", Parents: []string{"aaaa"}, Refs: []string{"HEAD -> main"}},
+		{Hash: "aaaa", ShortHash: "aaaa", Subject: "first", Author: "Dev"},
+	}
+	model.commitHistory = commits
+	model.logViewState.graphRows = buildCommitGraph(commits)
+
+	output := model.renderCommitEntry(commits[0], false, 0)
+	if contains(output, "●") {
+		t.Error("Expected no graph when showGraph is disabled")
+	}
+	if !contains(output, "HEAD -> main") {
+		t.Error("Expected refs to be rendered")
+	}
+
+	model.logViewState.showGraph = true
+	output = model.renderCommitEntry(commits[0], false, 0)
+	if !contains(output, "●") {
+		t.Error("Expected graph node when showGraph is enabled")
+	}
+}
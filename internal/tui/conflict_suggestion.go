@@ -0,0 +1,198 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/mopemope/git-rovo/internal/git"
+	"github.com/mopemope/git-rovo/internal/llm"
+	"github.com/mopemope/git-rovo/internal/logger"
+)
+
+const (
+	// conflictContextLines is the number of lines sent around a conflict region
+	conflictContextLines = 10
+
+	// maxConflictVersionLines limits each version of the file sent to the LLM
+	maxConflictVersionLines = 300
+)
+
+// conflictProposal is a resolution suggested by the LLM that is shown in the
+// diff view until it is accepted or rejected
+type conflictProposal struct {
+	hunk        *git.ConflictHunk
+	resolution  string
+	explanation string
+}
+
+// currentConflictIndex returns the conflict region of the current diff at or
+// after the scroll position, or -1 when the diff is not an unmerged file
+func (m *Model) currentConflictIndex() int {
+	if m.conflictProposal != nil || m.diffViewState.selectedFile >= len(m.currentDiff) {
+		return -1
+	}
+	diff := m.currentDiff[m.diffViewState.selectedFile]
+	if diff.Status != "U" {
+		return -1
+	}
+
+	regions := git.FindConflictMarkers(diff.Content)
+	if len(regions) == 0 {
+		return -1
+	}
+	for i, region := range regions {
+		if region.End >= m.diffViewState.scrollOffset {
+			return i
+		}
+	}
+	return len(regions) - 1
+}
+
+// suggestConflictResolution asks the LLM to resolve the current conflict region
+func (m *Model) suggestConflictResolution() (tea.Model, tea.Cmd) {
+	if m.conflictProposal != nil {
+		m.errorMessage = "Accept (a) or reject (esc) the suggested resolution first"
+		return m, nil
+	}
+	index := m.currentConflictIndex()
+	if index < 0 {
+		m.errorMessage = "No conflict to resolve in this diff"
+		return m, nil
+	}
+	path := m.currentDiff[m.diffViewState.selectedFile].FilePath
+
+	m.loading = true
+	m.loadingMessage = fmt.Sprintf("Suggesting a resolution for conflict %d of %s...", index+1, path)
+	return m, func() tea.Msg {
+		hunk, err := m.repo.GetConflictHunk(path, index, conflictContextLines)
+		if err != nil {
+			return errorMsg{error: fmt.Sprintf("Failed to read conflict: %v", err)}
+		}
+
+		request := &llm.CommitMessageRequest{
+			Mode:        llm.ModeConflict,
+			Diff:        describeConflictHunk(hunk),
+			Language:    m.config.LLM.Language,
+			MaxTokens:   m.config.LLM.OpenAI.MaxTokens,
+			Temperature: m.config.LLM.OpenAI.Temperature,
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		response, err := m.llmClient.GenerateCommitMessage(ctx, request, "")
+		if err != nil {
+			return errorMsg{error: fmt.Sprintf("Failed to suggest a resolution: %v", err)}
+		}
+		resolution, explanation, err := llm.ParseConflictResolution(response.Message)
+		if err != nil {
+			return errorMsg{error: fmt.Sprintf("Unusable suggestion: %v", err)}
+		}
+
+		logger.LogUIAction("conflict_resolution_suggested", map[string]interface{}{
+			"file":     path,
+			"conflict": index + 1,
+		})
+
+		return conflictSuggestedMsg{proposal: &conflictProposal{hunk: hunk, resolution: resolution, explanation: explanation}}
+	}
+}
+
+// describeConflictHunk describes a conflict region, its context and the
+// versions of the file for the LLM
+func describeConflictHunk(hunk *git.ConflictHunk) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "File: %s\n\n", hunk.Path)
+	text.WriteString("Conflict region with surrounding lines:\n")
+	for _, lines := range [][]string{hunk.Before, hunk.Lines, hunk.After} {
+		for _, line := range lines {
+			text.WriteString(line + "\n")
+		}
+	}
+
+	for _, version := range []struct{ title, content string }{
+		{"Common ancestor (git show :1:" + hunk.Path + ")", hunk.Base},
+		{"Our version (git show :2:" + hunk.Path + ")", hunk.Ours},
+		{"Their version (git show :3:" + hunk.Path + ")", hunk.Theirs},
+	} {
+		fmt.Fprintf(&text, "\n%s:\n", version.title)
+		if version.content == "" {
+			text.WriteString("(the file does not exist in this version)\n")
+			continue
+		}
+		lines := strings.Split(strings.TrimSuffix(version.content, "\n"), "\n")
+		if len(lines) > maxConflictVersionLines {
+			lines = append(lines[:maxConflictVersionLines], fmt.Sprintf("... (%d more lines)", len(lines)-maxConflictVersionLines))
+		}
+		text.WriteString(strings.Join(lines, "\n") + "\n")
+	}
+	return text.String()
+}
+
+// showConflictProposal shows a suggested resolution as a diff against the file
+func (m *Model) showConflictProposal(proposal *conflictProposal) {
+	m.conflictProposal = proposal
+	m.currentDiff = []git.DiffInfo{*proposal.hunk.ResolutionDiff(proposal.resolution)}
+	m.diffViewState.selectedFile = 0
+	m.diffViewState.scrollOffset = 0
+	m.diffViewState.markedFiles = 0
+	m.diffViewState.currentCommit = ""
+	m.statusMessage = fmt.Sprintf("Suggested resolution for conflict %d of %s (a:accept esc:reject)", proposal.hunk.Index+1, proposal.hunk.Path)
+}
+
+// acceptConflictResolution writes the suggested resolution into the file. The
+// file is shown again once the resolution is applied.
+func (m *Model) acceptConflictResolution() (tea.Model, tea.Cmd) {
+	proposal := m.conflictProposal
+	if proposal == nil {
+		m.errorMessage = "No suggested resolution to accept"
+		return m, nil
+	}
+	m.conflictProposal = nil
+
+	path := proposal.hunk.Path
+	return m, func() tea.Msg {
+		if err := m.repo.ApplyConflictResolution(proposal.hunk, proposal.resolution); err != nil {
+			return errorMsg{error: fmt.Sprintf("Failed to apply resolution: %v", err)}
+		}
+
+		logger.LogUIAction("conflict_resolution_accepted", map[string]interface{}{
+			"file":     path,
+			"conflict": proposal.hunk.Index + 1,
+		})
+
+		return conflictResolutionAppliedMsg{path: path}
+	}
+}
+
+// rejectConflictResolution discards the suggested resolution
+func (m *Model) rejectConflictResolution() (tea.Model, tea.Cmd) {
+	proposal := m.conflictProposal
+	if proposal == nil {
+		return m, nil
+	}
+	m.conflictProposal = nil
+	m.statusMessage = "Rejected suggested resolution"
+
+	logger.LogUIAction("conflict_resolution_rejected", map[string]interface{}{
+		"file":     proposal.hunk.Path,
+		"conflict": proposal.hunk.Index + 1,
+	})
+
+	return m, m.showConflictDiff(proposal.hunk.Path)
+}
+
+// renderConflictProposal renders the explanation of a suggested resolution
+func (m *Model) renderConflictProposal() string {
+	var content strings.Builder
+	content.WriteString(m.styles.Success.Render(" Suggested resolution • a:accept esc:reject"))
+	content.WriteString("\n")
+	if m.conflictProposal.explanation != "" {
+		content.WriteString(m.styles.Help.Render(" " + m.conflictProposal.explanation))
+		content.WriteString("\n")
+	}
+	return content.String()
+}
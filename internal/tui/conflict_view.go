@@ -0,0 +1,215 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/mopemope/git-rovo/internal/git"
+	"github.com/mopemope/git-rovo/internal/logger"
+)
+
+// unmergedFiles returns the files with unresolved conflicts
+func (m *Model) unmergedFiles() []git.FileStatus {
+	var files []git.FileStatus
+	for _, file := range m.fileStatus {
+		if file.Unmerged() {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// describeRepositoryState describes the operation in progress for the header
+func describeRepositoryState(state git.RepositoryState) string {
+	head := git.ShortHash(state.Head)
+
+	switch state.Operation {
+	case git.OperationMerge:
+		return "MERGING " + head
+	case git.OperationRebase:
+		text := "REBASING"
+		if state.Branch != "" {
+			text += " " + state.Branch
+		}
+		if head != "" {
+			text += " onto " + head
+		}
+		if state.Total > 0 {
+			text += fmt.Sprintf(" (%d/%d)", state.Step, state.Total)
+		}
+		return text
+	case git.OperationCherryPick:
+		return "CHERRY-PICKING " + head
+	case git.OperationRevert:
+		return "REVERTING " + head
+	}
+	return ""
+}
+
+// renderOperationBanner renders the operation in progress with the number of
+// conflicts left and how to continue or abort
+func (m *Model) renderOperationBanner() string {
+	text := describeRepositoryState(m.repoState)
+	if text == "" {
+		return ""
+	}
+
+	if unmerged := len(m.unmergedFiles()); unmerged > 0 {
+		text += fmt.Sprintf(" • %d unresolved conflicts (o:ours t:theirs B:both)", unmerged)
+	} else {
+		text += " • conflicts resolved"
+	}
+	text += " • M:continue X:abort"
+
+	return m.styles.Warning.Render(text)
+}
+
+// resolveConflicts resolves the target unmerged files with the given side
+func (m *Model) resolveConflicts(side git.ConflictSide) tea.Cmd {
+	var paths []string
+	for _, file := range m.getTargetFiles() {
+		if file.Unmerged() {
+			paths = append(paths, file.Path)
+		}
+	}
+	if len(paths) == 0 {
+		return func() tea.Msg {
+			return errorMsg{error: "No conflicted file selected"}
+		}
+	}
+
+	batch := len(m.getMarkedFiles()) > 0
+	return func() tea.Msg {
+		for _, path := range paths {
+			if err := m.repo.ResolveConflict(path, side); err != nil {
+				return errorMsg{error: fmt.Sprintf("Failed to resolve %s: %v", path, err)}
+			}
+		}
+
+		logger.LogUIAction("conflicts_resolved", map[string]interface{}{
+			"files": paths,
+			"side":  string(side),
+		})
+
+		return operationCompletedMsg{message: fmt.Sprintf("Resolved %s using %s", describeTargets(paths), side), clearSelection: batch}
+	}
+}
+
+// continueOperation concludes a merge or continues a rebase, cherry-pick or
+// revert once all conflicts are resolved
+func (m *Model) continueOperation() tea.Cmd {
+	operation := m.repoState.Operation
+	if operation == git.OperationNone {
+		return func() tea.Msg {
+			return errorMsg{error: "No merge, rebase, cherry-pick or revert in progress"}
+		}
+	}
+
+	return func() tea.Msg {
+		if err := m.repo.ContinueOperation(); err != nil {
+			return errorMsg{error: fmt.Sprintf("Failed to continue %s: %v", operation, err)}
+		}
+
+		logger.LogUIAction("operation_continued", map[string]interface{}{
+			"operation": string(operation),
+		})
+
+		return operationCompletedMsg{message: fmt.Sprintf("Continued %s", operation)}
+	}
+}
+
+// abortOperation aborts the operation in progress
+func (m *Model) abortOperation() tea.Cmd {
+	operation := m.repoState.Operation
+	if operation == git.OperationNone {
+		return nil
+	}
+
+	return func() tea.Msg {
+		if err := m.repo.AbortOperation(); err != nil {
+			return errorMsg{error: fmt.Sprintf("Failed to abort %s: %v", operation, err)}
+		}
+
+		logger.LogUIAction("operation_aborted", map[string]interface{}{
+			"operation": string(operation),
+		})
+
+		return operationCompletedMsg{message: fmt.Sprintf("Aborted %s", operation), clearSelection: true}
+	}
+}
+
+// abortConfirmation builds the confirmation modal for aborting the operation in progress
+func (m *Model) abortConfirmation() *ConfirmModal {
+	details := []string{describeRepositoryState(m.repoState)}
+	for _, file := range m.unmergedFiles() {
+		details = append(details, fmt.Sprintf("%s (%s)", file.Path, file.ConflictDescription()))
+	}
+	return NewConfirmModal("abort_operation", fmt.Sprintf("Abort %s?", m.repoState.Operation),
+		"The repository returns to the state before the operation started. Conflict resolutions are lost.",
+		details, nil)
+}
+
+// handleAbortOperation asks for confirmation before aborting the operation in progress
+func (m *Model) handleAbortOperation() (tea.Model, tea.Cmd) {
+	if !m.repoState.InProgress() {
+		m.errorMessage = "No merge, rebase, cherry-pick or revert in progress"
+		return m, nil
+	}
+	return m.withConfirmation("abort_operation", m.abortOperation(), m.abortConfirmation)
+}
+
+// showConflictDiff shows the working tree copy of an unmerged file with its
+// conflict markers in the diff view
+func (m *Model) showConflictDiff(path string) tea.Cmd {
+	return func() tea.Msg {
+		diff, err := m.repo.GetConflictDiff(path)
+		if err != nil {
+			return errorMsg{error: err.Error()}
+		}
+		return diffRefreshedMsg{diffs: []git.DiffInfo{*diff}}
+	}
+}
+
+// jumpToConflict scrolls the diff view to the next (or previous) conflict
+// marker block of the current file
+func (m *Model) jumpToConflict(forward bool) (tea.Model, tea.Cmd) {
+	if m.diffViewState.selectedFile >= len(m.currentDiff) {
+		return m, nil
+	}
+
+	regions := git.FindConflictMarkers(m.currentDiff[m.diffViewState.selectedFile].Content)
+	if len(regions) == 0 {
+		m.statusMessage = "No conflict markers in this file"
+		return m, nil
+	}
+
+	offset := m.diffViewState.scrollOffset
+	target := -1
+	if forward {
+		for _, region := range regions {
+			if region.Start > offset {
+				target = region.Start
+				break
+			}
+		}
+	} else {
+		for i := len(regions) - 1; i >= 0; i-- {
+			if regions[i].Start < offset {
+				target = regions[i].Start
+				break
+			}
+		}
+	}
+	if target < 0 {
+		m.statusMessage = fmt.Sprintf("No more conflicts (%d in this file)", len(regions))
+		return m, nil
+	}
+
+	m.diffViewState.scrollOffset = target
+	for i, region := range regions {
+		if region.Start == target {
+			m.statusMessage = fmt.Sprintf("Conflict %d/%d", i+1, len(regions))
+		}
+	}
+	return m, nil
+}
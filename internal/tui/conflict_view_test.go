@@ -0,0 +1,317 @@
+package tui
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/mopemope/git-rovo/internal/git"
+	"github.com/mopemope/git-rovo/internal/llm"
+)
+
+// setupConflictRepoTest creates a repository in the middle of a merge that
+// conflicts in a.txt and b.txt, with a clean change to c.txt staged
+func setupConflictRepoTest(t *testing.T) *Model {
+	t.Helper()
+
+	model := setupMainViewTest(t)
+	dir, run := setupGitRepo(t)
+	writeFiles := func(content string, names ...string) {
+		t.Helper()
+		for _, name := range names {
+			writeRepoFile(t, dir, name, content)
+		}
+	}
+
+	writeFiles("base\n", "a.txt", "b.txt", "c.txt")
+	run("add", ".")
+	run("commit", "-q", "-m", "Initial commit")
+	run("switch", "-q", "-c", "feature")
+	writeFiles("theirs\n", "a.txt", "b.txt", "c.txt")
+	run("commit", "-q", "-am", "Feature change")
+	run("switch", "-q", "main")
+	writeFiles("ours\n", "a.txt", "b.txt")
+	run("commit", "-q", "-am", "Main change")
+
+	repo := openGitRepo(t, model, dir)
+	if _, err := repo.RunGitCommand("merge", "feature"); err == nil {
+		t.Fatal("Expected merge to conflict")
+	}
+	model.width = 120
+	model.height = 40
+	model.currentView = ViewModeStatus
+	model.Update(model.refreshStatus()())
+	return model
+}
+
+func TestConflictStatusView(t *testing.T) {
+	model := setupConflictRepoTest(t)
+
+	if model.repoState.Operation != git.OperationMerge {
+		t.Fatalf("Expected merge in progress, got %+v", model.repoState)
+	}
+
+	files := model.getDisplayedFiles()
+	if len(files) != 3 || files[0].Path != "a.txt" || files[1].Path != "b.txt" || files[2].Path != "c.txt" {
+		t.Fatalf("Expected unmerged files first, got %+v", files)
+	}
+	staged, unstaged, _ := model.groupFiles()
+	if len(staged) != 1 || len(unstaged) != 0 {
+		t.Errorf("Expected unmerged files outside the other sections, got staged=%v unstaged=%v", staged, unstaged)
+	}
+
+	view := model.renderEnhancedStatusView()
+	for _, want := range []string{"Unmerged Paths (2)", "a.txt (both modified)", "2 unmerged"} {
+		if !contains(view, want) {
+			t.Errorf("Expected status view to contain %q", want)
+		}
+	}
+	header := model.renderHeader()
+	for _, want := range []string{"MERGING", "2 unresolved conflicts", "M:continue X:abort"} {
+		if !contains(header, want) {
+			t.Errorf("Expected header to contain %q", want)
+		}
+	}
+
+	if msg, ok := model.commitStagedChanges()().(errorMsg); !ok || !contains(msg.error, "Resolve 2 conflicted files") {
+		t.Errorf("Expected commit to be refused, got %#v", msg)
+	}
+	if msg, ok := model.continueOperation()().(errorMsg); !ok || !contains(msg.error, "resolve the conflicts") {
+		t.Errorf("Expected continue to be refused, got %#v", msg)
+	}
+}
+
+func TestResolveConflictsAndContinue(t *testing.T) {
+	model := setupConflictRepoTest(t)
+	dir := model.repo.GetWorkDir()
+
+	// Resolving a file without a conflict is refused
+	model.cursor = 2
+	if msg, ok := model.resolveConflicts(git.ConflictOurs)().(errorMsg); !ok || !contains(msg.error, "No conflicted file") {
+		t.Errorf("Expected error for a file without conflict, got %#v", msg)
+	}
+
+	model.cursor = 0
+	_, cmd := model.executeAction("resolve_ours")
+	msg, ok := cmd().(operationCompletedMsg)
+	if !ok || msg.message != "Resolved a.txt using ours" {
+		t.Fatalf("Expected a.txt to be resolved, got %#v", msg)
+	}
+	model.Update(model.refreshStatus()())
+
+	model.cursor = 0
+	_, cmd = model.executeAction("resolve_theirs")
+	if _, ok := cmd().(operationCompletedMsg); !ok {
+		t.Fatal("Expected b.txt to be resolved")
+	}
+	model.Update(model.refreshStatus()())
+
+	for name, want := range map[string]string{"a.txt": "ours\n", "b.txt": "theirs\n"} {
+		content, _ := os.ReadFile(filepath.Join(dir, name))
+		if string(content) != want {
+			t.Errorf("Expected %s to contain %q, got %q", name, want, content)
+		}
+	}
+	if len(model.unmergedFiles()) != 0 || !contains(model.renderHeader(), "conflicts resolved") {
+		t.Error("Expected all conflicts to be resolved")
+	}
+
+	_, cmd = model.executeAction("continue_operation")
+	if msg, ok := cmd().(operationCompletedMsg); !ok || msg.message != "Continued merge" {
+		t.Fatalf("Expected merge to be concluded, got %#v", msg)
+	}
+	model.Update(model.refreshStatus()())
+	if model.repoState.InProgress() || contains(model.renderHeader(), "MERGING") {
+		t.Errorf("Expected no operation after continuing, got %+v", model.repoState)
+	}
+}
+
+func TestAbortOperationConfirmation(t *testing.T) {
+	model := setupConflictRepoTest(t)
+	model.config.UI.ConfirmActions = []string{"abort_operation"}
+
+	model.executeAction("abort_operation")
+	if model.modal == nil || !contains(model.modal.title, "Abort merge?") {
+		t.Fatalf("Expected abort confirmation, got %+v", model.modal)
+	}
+	if len(model.modal.details) != 3 || !contains(model.modal.details[1], "a.txt (both modified)") {
+		t.Errorf("Unexpected modal details %v", model.modal.details)
+	}
+
+	_, cmd := model.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if msg, ok := cmd().(operationCompletedMsg); !ok || msg.message != "Aborted merge" {
+		t.Fatalf("Expected merge to be aborted, got %#v", msg)
+	}
+	model.Update(model.refreshStatus()())
+	if model.repoState.InProgress() || len(model.fileStatus) != 0 {
+		t.Errorf("Expected a clean repository after abort, got %+v %+v", model.repoState, model.fileStatus)
+	}
+
+	model.executeAction("abort_operation")
+	if model.modal != nil || !contains(model.errorMessage, "No merge") {
+		t.Errorf("Expected error without an operation, got %q", model.errorMessage)
+	}
+}
+
+func TestConflictDiffNavigation(t *testing.T) {
+	model := setupConflictRepoTest(t)
+	dir := model.repo.GetWorkDir()
+
+	// Two conflict regions in one file
+	content := "<<<<<<< HEAD\nours\n=======\ntheirs\n>>>>>>> feature\nshared\n<<<<<<< HEAD\nx\n=======\ny\n>>>>>>> feature\n"
+	writeRepoFile(t, dir, "a.txt", content)
+
+	model.cursor = 0
+	_, cmd := model.executeAction("diff")
+	if model.currentView != ViewModeDiff {
+		t.Fatal("Expected diff view")
+	}
+	model.Update(cmd())
+	if len(model.currentDiff) != 1 || model.currentDiff[0].Status != "U" {
+		t.Fatalf("Expected conflict diff, got %+v", model.currentDiff)
+	}
+	if view := model.renderEnhancedDiffView(); !contains(view, "-ours") || !contains(view, "+theirs") {
+		t.Error("Expected our and their lines in the diff view")
+	}
+
+	model.executeAction("next_conflict")
+	if model.diffViewState.scrollOffset != 4 || model.statusMessage != "Conflict 1/2" {
+		t.Errorf("Expected first conflict, got offset %d (%q)", model.diffViewState.scrollOffset, model.statusMessage)
+	}
+	model.executeAction("next_conflict")
+	if model.diffViewState.scrollOffset != 10 || model.statusMessage != "Conflict 2/2" {
+		t.Errorf("Expected second conflict, got offset %d (%q)", model.diffViewState.scrollOffset, model.statusMessage)
+	}
+	model.executeAction("next_conflict")
+	if model.diffViewState.scrollOffset != 10 || !contains(model.statusMessage, "No more conflicts") {
+		t.Errorf("Expected to stay at the last conflict, got offset %d (%q)", model.diffViewState.scrollOffset, model.statusMessage)
+	}
+	model.executeAction("prev_conflict")
+	if model.diffViewState.scrollOffset != 4 {
+		t.Errorf("Expected first conflict again, got offset %d", model.diffViewState.scrollOffset)
+	}
+}
+
+// scriptedLLMProvider returns a fixed response and records the requests
+type scriptedLLMProvider struct {
+	message  string
+	requests []*llm.CommitMessageRequest
+}
+
+func (p *scriptedLLMProvider) GenerateCommitMessage(ctx context.Context, request *llm.CommitMessageRequest) (*llm.CommitMessageResponse, error) {
+	p.requests = append(p.requests, request)
+	return &llm.CommitMessageResponse{Message: p.message, Provider: "scripted"}, nil
+}
+
+func (p *scriptedLLMProvider) GetProviderName() string { return "scripted" }
+
+func (p *scriptedLLMProvider) Close() error { return nil }
+
+// useScriptedLLM makes the model's LLM client answer with message
+func useScriptedLLM(t *testing.T, model *Model, message string) *scriptedLLMProvider {
+	t.Helper()
+	provider := &scriptedLLMProvider{message: message}
+	if err := model.llmClient.RegisterProvider("scripted", provider); err != nil {
+		t.Fatalf("Failed to register provider: %v", err)
+	}
+	if err := model.llmClient.SetDefaultProvider("scripted"); err != nil {
+		t.Fatalf("Failed to select provider: %v", err)
+	}
+	return provider
+}
+
+func TestSuggestConflictResolution(t *testing.T) {
+	model := setupConflictRepoTest(t)
+	dir := model.repo.GetWorkDir()
+	provider := useScriptedLLM(t, model, "<resolution>\nours and theirs\n</resolution>\n<explanation>\nBoth changes are kept.\n</explanation>")
+
+	model.cursor = 0
+	_, cmd := model.executeAction("diff")
+	model.Update(cmd())
+
+	_, cmd = model.executeAction("suggest_resolution")
+	if !model.loading {
+		t.Error("Expected loading state while the suggestion is generated")
+	}
+	model.Update(cmd())
+
+	if len(provider.requests) != 1 || provider.requests[0].Mode != llm.ModeConflict {
+		t.Fatalf("Expected one conflict request, got %+v", provider.requests)
+	}
+	for _, want := range []string{"File: a.txt", "<<<<<<< HEAD\nours\n=======\ntheirs\n>>>>>>> feature", "git show :1:a.txt):\nbase", "git show :3:a.txt):\ntheirs"} {
+		if !contains(provider.requests[0].Diff, want) {
+			t.Errorf("Expected request to contain %q, got %q", want, provider.requests[0].Diff)
+		}
+	}
+
+	if model.conflictProposal == nil || model.loading {
+		t.Fatal("Expected a pending proposal")
+	}
+	view := model.renderEnhancedDiffView()
+	for _, want := range []string{"Suggested resolution", "Both changes are kept.", "-ours", "+ours and theirs"} {
+		if !contains(view, want) {
+			t.Errorf("Expected diff view to contain %q", want)
+		}
+	}
+
+	// Nothing is written before the proposal is accepted
+	if content, _ := os.ReadFile(filepath.Join(dir, "a.txt")); !contains(string(content), "<<<<<<<") {
+		t.Fatal("Expected the file to be unchanged before accepting")
+	}
+	model.executeAction("suggest_resolution")
+	if !contains(model.errorMessage, "first") {
+		t.Errorf("Expected a pending proposal to block a new one, got %q", model.errorMessage)
+	}
+
+	_, cmd = model.executeAction("accept_resolution")
+	if model.conflictProposal != nil {
+		t.Error("Expected the proposal to be cleared")
+	}
+	if msg, ok := cmd().(conflictResolutionAppliedMsg); !ok || msg.path != "a.txt" {
+		t.Fatalf("Expected resolution to be applied, got %#v", msg)
+	}
+	if content, _ := os.ReadFile(filepath.Join(dir, "a.txt")); string(content) != "ours and theirs\n" {
+		t.Errorf("Expected resolved content, got %q", content)
+	}
+	model.Update(model.showConflictDiff("a.txt")())
+	if len(model.currentDiff) != 1 || model.currentConflictIndex() != -1 {
+		t.Errorf("Expected no conflicts left in the diff, got %+v", model.currentDiff)
+	}
+}
+
+func TestRejectConflictResolution(t *testing.T) {
+	model := setupConflictRepoTest(t)
+	useScriptedLLM(t, model, "Just keep both.")
+
+	model.cursor = 0
+	_, cmd := model.executeAction("diff")
+	model.Update(cmd())
+
+	_, cmd = model.executeAction("suggest_resolution")
+	model.Update(cmd())
+	if model.conflictProposal != nil || !contains(model.errorMessage, "Unusable suggestion") {
+		t.Fatalf("Expected unparsable response to be rejected, got %q", model.errorMessage)
+	}
+
+	provider, _ := model.llmClient.GetProvider("scripted")
+	provider.(*scriptedLLMProvider).message = "<resolution>\ntheirs\n</resolution>"
+	_, cmd = model.executeAction("suggest_resolution")
+	model.Update(cmd())
+	if model.conflictProposal == nil {
+		t.Fatal("Expected a pending proposal")
+	}
+
+	_, cmd = model.executeAction("reject_resolution")
+	if model.conflictProposal != nil {
+		t.Error("Expected the proposal to be discarded")
+	}
+	model.Update(cmd())
+	if model.currentConflictIndex() != 0 {
+		t.Error("Expected the conflict to be shown again")
+	}
+	if content, _ := os.ReadFile(filepath.Join(model.repo.GetWorkDir(), "a.txt")); !contains(string(content), "<<<<<<<") {
+		t.Error("Expected the file to be unchanged after rejecting")
+	}
+}
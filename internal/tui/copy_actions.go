@@ -0,0 +1,94 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/mopemope/git-rovo/internal/git"
+	"github.com/mopemope/git-rovo/internal/logger"
+)
+
+// copyToClipboard writes text to the system clipboard in the background
+func (m *Model) copyToClipboard(description, text string) tea.Cmd {
+	if text == "" {
+		m.errorMessage = fmt.Sprintf("Nothing to copy: no %s", description)
+		return nil
+	}
+
+	copyText := m.copyText
+	return func() tea.Msg {
+		method, err := copyText(text)
+		if err != nil {
+			return errorMsg{error: fmt.Sprintf("Failed to copy %s: %v", description, err)}
+		}
+
+		logger.LogUIAction("clipboard_copied", map[string]interface{}{
+			"what":   description,
+			"method": method,
+			"length": len(text),
+		})
+
+		return clipboardCopiedMsg{description: description, method: method}
+	}
+}
+
+// getSelectedCommit returns the commit shown in the commit detail view or
+// selected in the log view
+func (m *Model) getSelectedCommit() *git.CommitInfo {
+	switch m.currentView {
+	case ViewModeCommitDetail:
+		if m.commitDetails != nil {
+			return &m.commitDetails.CommitInfo
+		}
+	case ViewModeLog:
+		if m.cursor < len(m.commitHistory) {
+			return &m.commitHistory[m.cursor]
+		}
+	}
+	return nil
+}
+
+// handleCopyCommitHash copies the full hash of the selected commit
+func (m *Model) handleCopyCommitHash() (tea.Model, tea.Cmd) {
+	commit := m.getSelectedCommit()
+	if commit == nil {
+		return m, nil
+	}
+	return m, m.copyToClipboard("commit hash "+commit.ShortHash, commit.Hash)
+}
+
+// handleCopyCommitSubject copies the subject line of the selected commit
+func (m *Model) handleCopyCommitSubject() (tea.Model, tea.Cmd) {
+	commit := m.getSelectedCommit()
+	if commit == nil {
+		return m, nil
+	}
+	return m, m.copyToClipboard("commit subject", commit.Subject)
+}
+
+// handleCopyCommitMessage copies the generated commit message
+func (m *Model) handleCopyCommitMessage() (tea.Model, tea.Cmd) {
+	return m, m.copyToClipboard("generated commit message", m.generatedMessage)
+}
+
+// handleCopyFilePath copies the path of the current file in the status, diff
+// or commit detail view
+func (m *Model) handleCopyFilePath() (tea.Model, tea.Cmd) {
+	var path string
+	switch m.currentView {
+	case ViewModeStatus:
+		if file := m.getCurrentFile(); file != nil {
+			path = file.Path
+		}
+	case ViewModeDiff:
+		if m.diffViewState.selectedFile < len(m.currentDiff) {
+			path = m.currentDiff[m.diffViewState.selectedFile].FilePath
+		}
+	case ViewModeCommitDetail:
+		if files := m.getCommitDetailFiles(); m.cursor < len(files) {
+			path = files[m.cursor].Path
+		}
+	}
+
+	return m, m.copyToClipboard("file path", path)
+}
@@ -0,0 +1,113 @@
+package tui
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mopemope/git-rovo/internal/git"
+)
+
+func setupCopyTest(t *testing.T) (*Model, *string) {
+	t.Helper()
+
+	model := setupMainViewTest(t)
+	copied := new(string)
+	model.copyText = func(text string) (string, error) {
+		*copied = text
+		return "test", nil
+	}
+	return model, copied
+}
+
+func TestCopyActions(t *testing.T) {
+	model, copied := setupCopyTest(t)
+	model.commitHistory = makeTestCommits(3)
+	model.generatedMessage = "feat: add clipboard support"
+	model.fileStatus = []git.FileStatus{{Path: "internal/tui/model.go", Status: "M", Modified: true}}
+	model.currentDiff = []git.DiffInfo{{FilePath: "a.go"}, {FilePath: "b.go"}}
+	model.diffViewState.selectedFile = 1
+
+	tests := []struct {
+		name     string
+		view     ViewMode
+		cursor   int
+		action   string
+		expected string
+	}{
+		{"log hash", ViewModeLog, 1, "copy_commit_hash", model.commitHistory[1].Hash},
+		{"log subject", ViewModeLog, 2, "copy_commit_subject", "commit 2"},
+		{"generated message", ViewModeStatus, 0, "copy_commit_message", "feat: add clipboard support"},
+		{"status file path", ViewModeStatus, 0, "copy_file_path", "internal/tui/model.go"},
+		{"diff file path", ViewModeDiff, 0, "copy_file_path", "b.go"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			*copied = ""
+			model.currentView = tt.view
+			model.cursor = tt.cursor
+
+			_, cmd := model.executeAction(tt.action)
+			if cmd == nil {
+				t.Fatal("Expected copy command")
+			}
+			msg, ok := cmd().(clipboardCopiedMsg)
+			if !ok {
+				t.Fatalf("Expected clipboardCopiedMsg, got %T", msg)
+			}
+			if *copied != tt.expected {
+				t.Errorf("Expected %q to be copied, got %q", tt.expected, *copied)
+			}
+
+			model.Update(msg)
+			if !contains(model.statusMessage, "Copied") {
+				t.Errorf("Expected status message, got %q", model.statusMessage)
+			}
+		})
+	}
+}
+
+func TestCopyFromCommitDetailView(t *testing.T) {
+	model := setupCommitDetailTest(t)
+	copied := ""
+	model.copyText = func(text string) (string, error) {
+		copied = text
+		return "test", nil
+	}
+	model.currentView = ViewModeCommitDetail
+	model.cursor = 2
+
+	_, cmd := model.executeAction("copy_file_path")
+	cmd()
+	if copied != "logo.png" {
+		t.Errorf("Expected selected file path, got %q", copied)
+	}
+
+	_, cmd = model.executeAction("copy_commit_hash")
+	cmd()
+	if copied != model.commitDetails.Hash {
+		t.Errorf("Expected commit hash, got %q", copied)
+	}
+}
+
+func TestCopyNothingOrFailure(t *testing.T) {
+	model, _ := setupCopyTest(t)
+	model.currentView = ViewModeStatus
+
+	_, cmd := model.executeAction("copy_commit_message")
+	if cmd != nil {
+		t.Error("Expected no command without a generated message")
+	}
+	if !contains(model.errorMessage, "Nothing to copy") {
+		t.Errorf("Expected error message, got %q", model.errorMessage)
+	}
+
+	model.generatedMessage = "fix: something"
+	model.copyText = func(string) (string, error) {
+		return "", errors.New("no clipboard available")
+	}
+	_, cmd = model.executeAction("copy_commit_message")
+	if msg, ok := cmd().(errorMsg); !ok || !contains(msg.error, "no clipboard available") {
+		t.Errorf("Expected clipboard error, got %#v", msg)
+	}
+}
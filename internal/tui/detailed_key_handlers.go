@@ -41,7 +41,7 @@ func (m *Model) cycleDiffViewMode() {
 	})
 }
 
-func (m *Model) showCommitDiff(commitHash string) tea.Cmd {
+func (m *Model) showCommitDiff(commitHash, selectPath string) tea.Cmd {
 	return func() tea.Msg {
 		// Get diff for specific commit
 		output, err := m.repo.RunGitCommand("show", "--no-color", commitHash)
@@ -56,6 +56,6 @@ func (m *Model) showCommitDiff(commitHash string) tea.Cmd {
 			"commit": commitHash,
 		})
 
-		return commitDiffRefreshedMsg{diffs: diffs, commitHash: commitHash}
+		return commitDiffRefreshedMsg{diffs: diffs, commitHash: commitHash, selectPath: selectPath}
 	}
 }
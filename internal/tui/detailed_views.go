@@ -20,6 +20,7 @@ type DiffViewState struct {
 	viewMode        DiffViewMode
 	isStaged        bool   // Track whether showing staged or unstaged diff
 	currentCommit   string // Track current commit hash being viewed
+	markedFiles     int    // Number of marked files when showing a combined diff
 }
 
 // DiffViewMode represents different diff view modes
@@ -38,8 +39,12 @@ type LogViewState struct {
 	showDetails    bool
 	showGraph      bool
 	showStats      bool
-	filterAuthor   string
-	maxCommits     int
+	filter         git.CommitQuery // Active log filter, Skip and Limit are unused
+	maxCommits     int             // Commits loaded per page
+	loadingMore    bool
+	exhausted      bool             // All commits matching the filter are loaded
+	graphRows      []commitGraphRow // Graph prefix per commit, rebuilt when history changes
+	rangeAnchor    int              // Index where a commit range selection started, -1 if none
 }
 
 // NewDiffViewState creates a new diff view state
@@ -66,6 +71,7 @@ func NewLogViewState() *LogViewState {
 		showGraph:      false,
 		showStats:      true,
 		maxCommits:     50,
+		rangeAnchor:    -1,
 	}
 }
 
@@ -81,6 +87,10 @@ func (m *Model) renderEnhancedDiffView() string {
 	content.WriteString(m.renderDiffHeader())
 	content.WriteString("\n")
 
+	if m.conflictProposal != nil {
+		content.WriteString(m.renderConflictProposal())
+	}
+
 	// File tabs if multiple files
 	if len(m.currentDiff) > 1 {
 		content.WriteString(m.renderFileTabs())
@@ -135,7 +145,9 @@ func (m *Model) renderDiffHeader() string {
 
 	// Staged/Unstaged indicator (only for regular diffs, not commit diffs)
 	if m.diffViewState.currentCommit == "" {
-		if m.diffViewState.isStaged {
+		if m.diffViewState.markedFiles > 0 {
+			headerParts = append(headerParts, m.styles.Info.Render(fmt.Sprintf("(%d marked files)", m.diffViewState.markedFiles)))
+		} else if m.diffViewState.isStaged {
 			headerParts = append(headerParts, m.styles.Success.Render("(staged)"))
 		} else {
 			headerParts = append(headerParts, m.styles.Warning.Render("(unstaged)"))
@@ -321,6 +333,7 @@ func (m *Model) renderEnhancedLogView() string {
 		isSelected := (i + m.logViewState.scrollOffset) == m.cursor
 		renderedCommit := m.renderCommitEntry(commit, isSelected, i+m.logViewState.scrollOffset)
 		content.WriteString(renderedCommit)
+		content.WriteString(m.graphPrefix(i+m.logViewState.scrollOffset, -1))
 		content.WriteString("\n")
 	}
 
@@ -356,8 +369,8 @@ func (m *Model) renderLogHeader() string {
 		headerParts = append(headerParts, fmt.Sprintf("(%d commits)", len(m.commitHistory)))
 	}
 
-	if m.logViewState.filterAuthor != "" {
-		headerParts = append(headerParts, fmt.Sprintf("Author: %s", m.logViewState.filterAuthor))
+	if m.logViewState.filter.IsFiltered() {
+		headerParts = append(headerParts, describeCommitQuery(m.logViewState.filter))
 	}
 
 	header := strings.Join(headerParts, " - ")
@@ -367,19 +380,31 @@ func (m *Model) renderLogHeader() string {
 // renderCommitEntry renders a single commit entry
 func (m *Model) renderCommitEntry(commit git.CommitInfo, selected bool, index int) string {
 	var content strings.Builder
+	line := 0
 
 	// Commit hash and date
 	hashLine := fmt.Sprintf("%s %s",
 		commit.ShortHash,
 		commit.Date.Format("2006-01-02 15:04"))
+	if len(commit.Refs) > 0 {
+		hashLine += fmt.Sprintf(" (%s)", strings.Join(commit.Refs, ", "))
+	}
+	if signature := describeSignature(commit.Signature); signature != "" {
+		hashLine += fmt.Sprintf(" [signature: %s]", signature)
+	}
+	if m.isInCommitRange(index) {
+		hashLine = "~ " + hashLine
+	}
 
 	if selected {
 		hashLine = m.styles.Selected.Render(hashLine)
 	} else {
 		hashLine = m.styles.Warning.Render(hashLine)
 	}
+	content.WriteString(m.graphPrefix(index, line))
 	content.WriteString(hashLine)
 	content.WriteString("\n")
+	line++
 
 	// Author
 	authorLine := fmt.Sprintf("Author: %s", commit.Author)
@@ -388,8 +413,10 @@ func (m *Model) renderCommitEntry(commit git.CommitInfo, selected bool, index in
 	} else {
 		authorLine = m.styles.Info.Render(authorLine)
 	}
+	content.WriteString(m.graphPrefix(index, line))
 	content.WriteString(authorLine)
 	content.WriteString("\n")
+	line++
 
 	// Subject
 	subjectLine := commit.Subject
@@ -402,17 +429,21 @@ func (m *Model) renderCommitEntry(commit git.CommitInfo, selected bool, index in
 	} else {
 		subjectLine = m.styles.Base.Render("Message: " + subjectLine)
 	}
+	content.WriteString(m.graphPrefix(index, line))
 	content.WriteString(subjectLine)
 	content.WriteString("\n")
+	line++
 
 	// Body if selected and available
 	if selected && m.logViewState.showDetails && commit.Body != "" {
 		bodyLines := strings.Split(commit.Body, "\n")
-		for _, line := range bodyLines {
-			if strings.TrimSpace(line) != "" {
-				bodyLine := m.styles.Help.Render("   " + line)
+		for _, bodyText := range bodyLines {
+			if strings.TrimSpace(bodyText) != "" {
+				bodyLine := m.styles.Help.Render("   " + bodyText)
+				content.WriteString(m.graphPrefix(index, line))
 				content.WriteString(bodyLine)
 				content.WriteString("\n")
+				line++
 			}
 		}
 	}
@@ -420,6 +451,21 @@ func (m *Model) renderCommitEntry(commit git.CommitInfo, selected bool, index in
 	return content.String()
 }
 
+// graphPrefix returns the commit graph columns for a line of a commit entry,
+// or an empty string when the graph is hidden. A negative line selects the
+// continuation row used for separators.
+func (m *Model) graphPrefix(index, line int) string {
+	if !m.logViewState.showGraph || index < 0 || index >= len(m.logViewState.graphRows) {
+		return ""
+	}
+
+	row := m.logViewState.graphRows[index]
+	if line < 0 {
+		return m.styles.Info.Render(row.padding)
+	}
+	return m.styles.Info.Render(row.line(line))
+}
+
 // Helper methods
 
 func (m *Model) getStatusText(status string) string {
@@ -434,6 +480,8 @@ func (m *Model) getStatusText(status string) string {
 		return m.styles.Warning.Render("Renamed")
 	case "C":
 		return m.styles.Info.Render("Copied")
+	case "U":
+		return m.styles.Error.Render("Conflict")
 	default:
 		return m.styles.Base.Render("Changed")
 	}
@@ -558,10 +606,17 @@ func (m *Model) renderLogScrollIndicator() string {
 	progress := float64(m.logViewState.scrollOffset) / float64(len(m.commitHistory)-maxVisible)
 	percentage := int(progress * 100)
 
-	indicator := fmt.Sprintf("── %d%% (%d/%d commits) ──",
+	total := fmt.Sprintf("%d", len(m.commitHistory))
+	if m.logViewState.loadingMore {
+		total += ", loading more"
+	} else if !m.logViewState.exhausted {
+		total += "+"
+	}
+
+	indicator := fmt.Sprintf("── %d%% (%d/%s commits) ──",
 		percentage,
 		m.logViewState.scrollOffset+maxVisible,
-		len(m.commitHistory))
+		total)
 
 	return m.styles.Help.Render(indicator)
 }
@@ -11,11 +11,14 @@ import (
 func (m *Model) toggleCurrentSection() tea.Cmd {
 	// Determine which section the cursor is in
 	staged, unstaged, _ := m.groupFiles()
+	unmerged := len(m.unmergedFiles())
 
 	var sectionKey string
-	if m.cursor < len(staged) {
+	if m.cursor < unmerged {
+		sectionKey = "unmerged_paths"
+	} else if m.cursor < unmerged+len(staged) {
 		sectionKey = "staged_changes"
-	} else if m.cursor < len(staged)+len(unstaged) {
+	} else if m.cursor < unmerged+len(staged)+len(unstaged) {
 		sectionKey = "unstaged_changes"
 	} else {
 		sectionKey = "untracked_files"
@@ -58,79 +61,170 @@ func (m *Model) unstageAllFiles() tea.Cmd {
 	}
 }
 
-// discardCurrentFileChanges discards changes to the current file with confirmation
+// discardCurrentFileChanges discards changes to the marked files, or the current file
+// when nothing is marked
 func (m *Model) discardCurrentFileChanges() tea.Cmd {
-	file := m.getCurrentFile()
-	if file == nil {
+	targets := m.getTargetFiles()
+	if len(targets) == 0 {
 		return func() tea.Msg {
 			return errorMsg{error: "No file selected"}
 		}
 	}
 
-	// For now, we'll implement without a modal dialog
-	// In a real implementation, you might want to add a confirmation modal
+	batch := len(m.getMarkedFiles()) > 0
+
+	// Confirmation is handled by the modal in executeAction; the discarded
+	// content is journaled by the repository so it can be undone
 	return func() tea.Msg {
-		err := m.repo.DiscardChanges(file.Path)
-		if err != nil {
-			return errorMsg{error: fmt.Sprintf("Failed to discard changes: %v", err)}
+		for _, file := range targets {
+			err := m.repo.DiscardChanges(file.Path)
+			if err != nil {
+				return errorMsg{error: fmt.Sprintf("Failed to discard changes: %v", err)}
+			}
+
+			logger.LogUIAction("file_changes_discarded", map[string]interface{}{
+				"file":   file.Path,
+				"status": file.Status,
+				"staged": file.Staged,
+			})
 		}
 
-		logger.LogUIAction("file_changes_discarded", map[string]interface{}{
-			"file":   file.Path,
-			"status": file.Status,
-			"staged": file.Staged,
-		})
-
 		var message string
-		switch file.Status {
-		case "??":
-			message = fmt.Sprintf("Deleted: %s", file.Path)
+		switch {
+		case len(targets) > 1:
+			message = fmt.Sprintf("Discarded changes: %d files", len(targets))
+		case targets[0].Status == "??":
+			message = fmt.Sprintf("Deleted: %s", targets[0].Path)
 		default:
-			message = fmt.Sprintf("Discarded changes: %s", file.Path)
+			message = fmt.Sprintf("Discarded changes: %s", targets[0].Path)
 		}
 
-		return operationCompletedMsg{message: message}
+		return operationCompletedMsg{message: message, clearSelection: batch}
 	}
 }
 
-// resetCurrentFile resets (discards changes to) the current file
+// resetCurrentFile resets (discards changes to) the marked files, or the current
+// file when nothing is marked
 func (m *Model) resetCurrentFile() tea.Cmd {
-	file := m.getCurrentFile()
-	if file == nil {
+	targets := m.getTargetFiles()
+	if len(targets) == 0 {
 		return func() tea.Msg {
 			return errorMsg{error: "No file selected"}
 		}
 	}
 
-	if file.Status == "??" {
+	var paths []string
+	for _, file := range targets {
+		if file.Status != "??" {
+			paths = append(paths, file.Path)
+		}
+	}
+	if len(paths) == 0 {
 		return func() tea.Msg {
 			return errorMsg{error: "Cannot reset untracked file"}
 		}
 	}
 
+	batch := len(m.getMarkedFiles()) > 0
+	return func() tea.Msg {
+		for _, path := range paths {
+			// Snapshot and check out the HEAD version of the file
+			err := m.repo.ResetFile(path)
+			if err != nil {
+				return errorMsg{error: fmt.Sprintf("Failed to reset file: %v", err)}
+			}
+
+			logger.LogUIAction("file_reset", map[string]interface{}{
+				"file": path,
+			})
+		}
+
+		return operationCompletedMsg{message: fmt.Sprintf("Reset: %s", describeTargets(paths)), clearSelection: batch}
+	}
+}
+
+// undoLastOperation restores the most recent discard, reset or amend from the journal
+func (m *Model) undoLastOperation() tea.Cmd {
 	return func() tea.Msg {
-		// Use git checkout to discard changes
-		_, err := m.repo.RunGitCommand("checkout", "HEAD", "--", file.Path)
+		undone, err := m.repo.Undo(1)
 		if err != nil {
-			return errorMsg{error: fmt.Sprintf("Failed to reset file: %v", err)}
+			return errorMsg{error: fmt.Sprintf("Failed to undo: %v", err)}
 		}
 
-		logger.LogUIAction("file_reset", map[string]interface{}{
-			"file": file.Path,
-		})
+		for _, entry := range undone {
+			logger.LogUIAction("operation_undone", map[string]interface{}{
+				"operation": entry.Operation,
+				"path":      entry.Path,
+			})
+		}
+
+		return operationCompletedMsg{message: fmt.Sprintf("Undid %s", undone[0].Description())}
+	}
+}
+
+// toggleMark marks or unmarks the current file and moves the cursor down
+func (m *Model) toggleMark() tea.Cmd {
+	file := m.getCurrentFile()
+	if file == nil {
+		return nil
+	}
+
+	if m.selected[file.Path] {
+		delete(m.selected, file.Path)
+	} else {
+		m.selected[file.Path] = true
+	}
+
+	if m.cursor < len(m.getDisplayedFiles())-1 {
+		m.cursor++
+	}
+
+	logger.LogUIAction("file_mark_toggled", map[string]interface{}{
+		"file":   file.Path,
+		"marked": m.selected[file.Path],
+	})
+
+	return nil
+}
+
+// markRange starts a range selection at the cursor, or marks every file between
+// the anchor and the cursor when a range is already started
+func (m *Model) markRange() tea.Cmd {
+	if m.mainViewState.markAnchor < 0 {
+		m.mainViewState.markAnchor = m.cursor
+		m.statusMessage = "Range start set; move the cursor and press again to mark"
+		return nil
+	}
+
+	start, end := m.mainViewState.markAnchor, m.cursor
+	if start > end {
+		start, end = end, start
+	}
 
-		return operationCompletedMsg{message: fmt.Sprintf("Reset: %s", file.Path)}
+	files := m.getDisplayedFiles()
+	count := 0
+	for i := start; i <= end && i < len(files); i++ {
+		m.selected[files[i].Path] = true
+		count++
 	}
+	m.mainViewState.markAnchor = -1
+	m.statusMessage = fmt.Sprintf("Marked %d files", count)
+
+	logger.LogUIAction("file_range_marked", map[string]interface{}{
+		"count": count,
+	})
+
+	return nil
 }
 
 // selectAllFiles selects all files
 func (m *Model) selectAllFiles() tea.Cmd {
-	for i := range m.fileStatus {
-		m.selected[i] = true
+	for _, file := range m.getDisplayedFiles() {
+		m.selected[file.Path] = true
 	}
 
 	logger.LogUIAction("all_files_selected", map[string]interface{}{
-		"count": len(m.fileStatus),
+		"count": len(m.selected),
 	})
 
 	return nil
@@ -138,9 +232,23 @@ func (m *Model) selectAllFiles() tea.Cmd {
 
 // clearSelection clears all file selections
 func (m *Model) clearSelection() tea.Cmd {
-	m.selected = make(map[int]bool)
+	m.selected = make(map[string]bool)
+	m.mainViewState.markAnchor = -1
 
 	logger.LogUIAction("selection_cleared", nil)
 
 	return nil
 }
+
+// pruneSelection drops marks for files that no longer have changes
+func (m *Model) pruneSelection() {
+	present := make(map[string]bool, len(m.fileStatus))
+	for _, file := range m.fileStatus {
+		present[file.Path] = true
+	}
+	for path := range m.selected {
+		if !present[path] {
+			delete(m.selected, path)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/mopemope/git-rovo/internal/git"
+	"github.com/mopemope/git-rovo/internal/logger"
+)
+
+type fixupTargetsMsg struct {
+	candidates []git.FixupCandidate
+}
+
+// fixupCommit creates a "fixup!" commit of the staged changes. In the log
+// view the selected commit is the target; in the status view the target is
+// suggested by blaming the changed lines and confirmed first.
+func (m *Model) fixupCommit() (tea.Model, tea.Cmd) {
+	if m.repoState.InProgress() {
+		m.errorMessage = fmt.Sprintf("Finish or abort the %s in progress first", m.repoState.Operation)
+		return m, nil
+	}
+
+	if m.currentView == ViewModeLog {
+		if m.cursor >= len(m.commitHistory) {
+			return m, nil
+		}
+		return m, m.commitFixup(m.commitHistory[m.cursor])
+	}
+
+	m.loading = true
+	m.loadingMessage = "Finding the commit to fix up..."
+	return m, func() tea.Msg {
+		hasStaged, err := m.repo.HasStagedChanges()
+		if err != nil {
+			return errorMsg{error: fmt.Sprintf("Failed to check staged changes: %v", err)}
+		}
+		if !hasStaged {
+			return errorMsg{error: "No staged changes to fix up a commit with"}
+		}
+
+		candidates, err := m.repo.SuggestFixupTargets()
+		if err != nil {
+			return errorMsg{error: fmt.Sprintf("No fixup target found: %v; select the commit in the log view and press F", err)}
+		}
+		return fixupTargetsMsg{candidates: candidates}
+	}
+}
+
+// showFixupTargets asks to fix up the most recent commit that touched the
+// staged lines, listing the other commits that touched them
+func (m *Model) showFixupTargets(msg fixupTargetsMsg) {
+	m.loading = false
+	target := msg.candidates[0].Commit
+
+	var details []string
+	for i, candidate := range msg.candidates {
+		marker := "  "
+		if i == 0 {
+			marker = "→ "
+		}
+		details = append(details, fmt.Sprintf("%s%s %s (%d lines)", marker, candidate.Commit.ShortHash, candidate.Commit.Subject, candidate.Lines))
+	}
+	if len(msg.candidates) > 1 {
+		details = append(details, "", "To fix up another commit, select it in the log view and press F")
+	}
+
+	m.modal = NewConfirmModal("fixup_commit", "Fixup "+target.ShortHash+" "+target.Subject,
+		"Commit the staged changes as a fixup of the most recent commit touching the same lines?", details, m.commitFixup(target))
+}
+
+// commitFixup commits the staged changes with git commit --fixup
+func (m *Model) commitFixup(target git.CommitInfo) tea.Cmd {
+	return func() tea.Msg {
+		hasStaged, err := m.repo.HasStagedChanges()
+		if err != nil {
+			return errorMsg{error: fmt.Sprintf("Failed to check staged changes: %v", err)}
+		}
+		if !hasStaged {
+			return errorMsg{error: "No staged changes to fix up a commit with"}
+		}
+
+		if err := m.repo.CommitFixup(target.Hash, git.CommitOptions{Sign: m.signMode}); err != nil {
+			return m.commitFailed(err)
+		}
+
+		logger.LogUIAction("fixup_created", map[string]interface{}{
+			"target": target.Hash,
+		})
+
+		m.signMode = git.SignDefault
+		return operationCompletedMsg{message: fmt.Sprintf("Created fixup commit for %s; press ctrl+f to squash it", target.ShortHash)}
+	}
+}
+
+// autosquash folds the fixup commits into their targets after confirmation
+func (m *Model) autosquash() (tea.Model, tea.Cmd) {
+	if m.repoState.InProgress() {
+		m.errorMessage = fmt.Sprintf("Finish or abort the %s in progress first", m.repoState.Operation)
+		return m, nil
+	}
+
+	cmd := func() tea.Msg {
+		err := m.repo.AutosquashRebase()
+		if err == nil {
+			logger.LogUIAction("autosquash_completed", nil)
+			return rebaseFinishedMsg{message: "Squashed fixup commits into their targets"}
+		}
+		state, stateErr := m.repo.GetRepositoryState()
+		return rebaseFinishedMsg{err: err, stopped: stateErr == nil && state.InProgress()}
+	}
+	return m.withConfirmation("autosquash", cmd, m.autosquashConfirmation)
+}
+
+// autosquashConfirmation lists the loaded fixup commits to be squashed
+func (m *Model) autosquashConfirmation() *ConfirmModal {
+	var details []string
+	for _, commit := range m.commitHistory {
+		if git.IsFixupSubject(commit.Subject) {
+			details = append(details, commit.ShortHash+" "+commit.Subject)
+		}
+	}
+	return NewConfirmModal("autosquash", "Autosquash fixup commits",
+		"Fold the fixup commits into their targets with git rebase -i --autosquash?", details, nil)
+}
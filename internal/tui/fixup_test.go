@@ -0,0 +1,101 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/mopemope/git-rovo/internal/config"
+)
+
+func TestFixupFromStatusAndLog(t *testing.T) {
+	model := setupRebaseRepoTest(t)
+	model.config.UI.ConfirmActions = config.DefaultConfirmActions()
+	dir := model.repo.GetWorkDir()
+	stage := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := model.repo.StageFiles(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	confirm := func() tea.Cmd {
+		t.Helper()
+		if model.modal == nil {
+			t.Fatal("Expected a confirmation")
+		}
+		_, cmd := model.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+		return cmd
+	}
+
+	// The status view suggests the commit that added the changed line
+	model.currentView = ViewModeStatus
+	stage("b.txt", "B\n")
+	_, cmd := model.executeAction("fixup_commit")
+	model.Update(cmd())
+	if model.modal == nil || !strings.Contains(model.modal.title, "Add b") {
+		t.Fatalf("Expected the suggested target, got %+v (%s)", model.modal, model.errorMessage)
+	}
+	model.Update(confirm()())
+	if subject, _ := model.repo.RunGitCommand("log", "-1", "--format=%s"); subject != "fixup! Add b" {
+		t.Errorf("Unexpected fixup subject %q", subject)
+	}
+
+	// The log view fixes up the selected commit
+	model.currentView = ViewModeLog
+	model.Update(model.refreshCommitHistory()())
+	stage("a.txt", "A\n")
+	model.cursor = commitIndex(t, model, "Add a")
+	_, cmd = model.executeAction("fixup_commit")
+	model.Update(cmd())
+	if subject, _ := model.repo.RunGitCommand("log", "-1", "--format=%s"); subject != "fixup! Add a" {
+		t.Errorf("Unexpected fixup subject %q (%s)", subject, model.errorMessage)
+	}
+
+	model.Update(model.refreshCommitHistory()())
+	_, cmd = model.executeAction("autosquash")
+	if model.modal == nil || len(model.modal.details) != 2 {
+		t.Fatalf("Expected the fixup commits to be listed, got %+v", model.modal)
+	}
+	model.Update(confirm()())
+
+	if model.errorMessage != "" || !strings.Contains(model.statusMessage, "Squashed") {
+		t.Fatalf("Expected the autosquash to succeed, got %q", model.errorMessage)
+	}
+	log, _ := model.repo.RunGitCommand("log", "--format=%s")
+	if log != "Add d\nAdd c\nAdd b\nAdd a\nInitial commit" {
+		t.Errorf("Unexpected history %q", log)
+	}
+	for name, want := range map[string]string{"a.txt": "A", "b.txt": "B"} {
+		if content, _ := model.repo.RunGitCommand("show", "HEAD:"+name); content != want {
+			t.Errorf("Expected %s to be %q, got %q", name, want, content)
+		}
+	}
+}
+
+func TestFixupWithoutTarget(t *testing.T) {
+	model := setupRebaseRepoTest(t)
+	model.currentView = ViewModeStatus
+
+	_, cmd := model.executeAction("fixup_commit")
+	model.Update(cmd())
+	if !strings.Contains(model.errorMessage, "No staged changes") {
+		t.Errorf("Expected staged changes to be required, got %q", model.errorMessage)
+	}
+
+	if err := os.WriteFile(filepath.Join(model.repo.GetWorkDir(), "new.txt"), []byte("new\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := model.repo.StageFiles("new.txt"); err != nil {
+		t.Fatal(err)
+	}
+	_, cmd = model.executeAction("fixup_commit")
+	model.Update(cmd())
+	if model.modal != nil || !strings.Contains(model.errorMessage, "select the commit in the log view") {
+		t.Errorf("Expected no suggestion for a new file, got %q", model.errorMessage)
+	}
+}
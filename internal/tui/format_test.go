@@ -41,6 +41,26 @@ func TestFormatCommitMessage(t *testing.T) {
 			input:    "fix: resolve issue\nThis fixes the problem with XYZ",
 			expected: "fix: resolve issue\n\nThis fixes the problem with XYZ",
 		},
+		{
+			name:     "Trailers after the body",
+			input:    "feat: add login\n\nAdd the login form\nCo-authored-by: Jane Doe <jane@example.com>\nSigned-off-by: Test User <test@example.com>",
+			expected: "feat: add login\n\nAdd the login form\n\nCo-authored-by: Jane Doe <jane@example.com>\nSigned-off-by: Test User <test@example.com>",
+		},
+		{
+			name:     "Trailers with empty lines removed",
+			input:    "feat!: drop v1 API\nRemove the old handlers\nBREAKING CHANGE: v1 clients must upgrade\nRefs: PROJ-1",
+			expected: "feat!: drop v1 API\n\nRemove the old handlers\n\nBREAKING CHANGE: v1 clients must upgrade\nRefs: PROJ-1",
+		},
+		{
+			name:     "Trailer block already separated",
+			input:    "fix: crash\n\nBody\n\nReviewed-by: Jane Doe <jane@example.com>",
+			expected: "fix: crash\n\nBody\n\nReviewed-by: Jane Doe <jane@example.com>",
+		},
+		{
+			name:     "Only trailers after the subject",
+			input:    "fix: crash\nRefs: PROJ-1",
+			expected: "fix: crash\n\nRefs: PROJ-1",
+		},
 	}
 
 	for _, tt := range tests {
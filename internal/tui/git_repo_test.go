@@ -0,0 +1,52 @@
+package tui
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/mopemope/git-rovo/internal/git"
+)
+
+// setupGitRepo creates an empty repository on main with a test identity. It
+// returns the directory and a function that runs git there and returns the
+// output, failing the test when git fails.
+func setupGitRepo(t *testing.T) (string, func(args ...string) string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	run := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+		return string(output)
+	}
+	run("init", "-q", "-b", "main")
+	run("config", "user.name", "Test User")
+	run("config", "user.email", "test@example.com")
+	return dir, run
+}
+
+// writeRepoFile writes content to a file of the repository in dir
+func writeRepoFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+}
+
+// openGitRepo opens the repository in dir as the repository of the model
+func openGitRepo(t *testing.T, model *Model, dir string) *git.Repository {
+	t.Helper()
+	repo, err := git.New(dir)
+	if err != nil {
+		t.Fatalf("Failed to open repository: %v", err)
+	}
+	model.repo = repo
+	return repo
+}
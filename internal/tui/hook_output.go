@@ -0,0 +1,140 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mopemope/git-rovo/internal/git"
+	"github.com/mopemope/git-rovo/internal/logger"
+)
+
+// HookOutputModal shows the output of the hook that rejected a commit
+type HookOutputModal struct {
+	err      *git.HookError
+	restaged []string // Files modified by the hooks and staged again
+	lines    []string
+	offset   int // First visible line
+}
+
+// hookFailedMsg reports a commit rejected by a hook
+type hookFailedMsg struct {
+	err      *git.HookError
+	restaged []string
+}
+
+// commitFailed turns a commit error into a message. Files changed by a hook
+// that rejected the commit, e.g. by a formatter, are staged again so a retry
+// commits them.
+func (m *Model) commitFailed(err error) tea.Msg {
+	var hookErr *git.HookError
+	if !errors.As(err, &hookErr) {
+		return errorMsg{error: commitErrorMessage(err)}
+	}
+
+	msg := hookFailedMsg{err: hookErr}
+	if len(hookErr.Modified) > 0 {
+		if stageErr := m.repo.StageFiles(hookErr.Modified...); stageErr != nil {
+			logger.Warn("Failed to re-stage files modified by hooks", "error", stageErr)
+		} else {
+			msg.restaged = hookErr.Modified
+		}
+	}
+
+	logger.LogUIAction("commit_rejected_by_hook", map[string]interface{}{
+		"hooks":    hookErr.Hooks,
+		"restaged": len(msg.restaged),
+	})
+	return msg
+}
+
+// hookOutputHeight returns the number of output lines shown at once
+func (m *Model) hookOutputHeight() int {
+	return max(m.height-18, 5)
+}
+
+// handleHookOutputKeyPress handles key presses while the hook output is shown
+func (m *Model) handleHookOutputKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	modal := m.hookOutput
+	lastOffset := max(len(modal.lines)-m.hookOutputHeight(), 0)
+
+	switch msg.String() {
+	case "up", "k":
+		modal.offset = max(modal.offset-1, 0)
+	case "down", "j":
+		modal.offset = min(modal.offset+1, lastOffset)
+	case "pgup", "ctrl+u":
+		modal.offset = max(modal.offset-m.hookOutputHeight(), 0)
+	case "pgdown", "ctrl+d":
+		modal.offset = min(modal.offset+m.hookOutputHeight(), lastOffset)
+	case "home":
+		modal.offset = 0
+	case "end":
+		modal.offset = lastOffset
+	case "r":
+		m.hookOutput = nil
+		m.errorMessage = ""
+		return m, m.commitStagedChanges()
+	case "n":
+		m.hookOutput = nil
+		m.errorMessage = ""
+		return m.withConfirmation("commit_no_verify", m.commitStagedChangesWith(true), m.noVerifyConfirmation)
+	case "esc", "q", "ctrl+c":
+		m.hookOutput = nil
+	}
+	return m, nil
+}
+
+// noVerifyConfirmation builds the confirmation modal for committing without hooks
+func (m *Model) noVerifyConfirmation() *ConfirmModal {
+	subject, _, _ := strings.Cut(m.generatedMessage, "\n")
+	return NewConfirmModal("commit_no_verify", "Commit without hooks?",
+		"The pre-commit and commit-msg hooks will be skipped (--no-verify).",
+		[]string{subject}, nil)
+}
+
+// renderHookOutput renders the output of the rejecting hook centered in the
+// content area
+func (m *Model) renderHookOutput() string {
+	modal := m.hookOutput
+	var content strings.Builder
+
+	content.WriteString(m.styles.Error.Render("✗ " + modal.err.Error()))
+	content.WriteString("\n")
+	if len(modal.restaged) > 0 {
+		content.WriteString(m.styles.Info.Render("Re-staged files modified by the hooks: " + strings.Join(modal.restaged, ", ")))
+		content.WriteString("\n")
+	}
+	content.WriteString("\n")
+
+	if strings.TrimSpace(modal.err.Output) == "" {
+		content.WriteString(m.styles.Help.Render("The hook printed no output"))
+		content.WriteString("\n")
+	}
+	height := m.hookOutputHeight()
+	end := min(modal.offset+height, len(modal.lines))
+	for _, line := range modal.lines[modal.offset:end] {
+		content.WriteString(line)
+		content.WriteString("\n")
+	}
+	if len(modal.lines) > height {
+		content.WriteString(m.styles.Help.Render(fmt.Sprintf("lines %d-%d of %d", modal.offset+1, end, len(modal.lines))))
+		content.WriteString("\n")
+	}
+
+	content.WriteString("\n")
+	content.WriteString(m.styles.Help.Render("r: retry • n: commit with --no-verify • esc: close"))
+
+	boxWidth := min(max(m.width-10, 20), 120)
+
+	box := m.styles.Base.
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(CatppuccinRed)).
+		Padding(1, 2).
+		Width(boxWidth).
+		Render(content.String())
+
+	return lipgloss.Place(m.width, lipgloss.Height(box)+2, lipgloss.Center, lipgloss.Center, box)
+}
@@ -0,0 +1,87 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/mopemope/git-rovo/internal/config"
+)
+
+func TestCommitRejectedByHook(t *testing.T) {
+	model := setupMainViewTest(t)
+	model.width = 120
+	model.height = 40
+	model.config.UI.ConfirmActions = config.DefaultConfirmActions()
+	dir, run := setupGitRepo(t)
+	writeHook := func(name, script string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, ".git", "hooks", name), []byte(script), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	run("commit", "-q", "--allow-empty", "-m", "Initial commit")
+	// A formatter that fixes the staged file and rejects the commit once
+	writeHook("pre-commit", "#!/bin/sh\nif ! git show :code.txt | grep -q formatted; then\n  echo formatted >> code.txt\n  seq 1 40 >&2\n  echo 'code.txt was reformatted' >&2\n  exit 1\nfi\n")
+	writeRepoFile(t, dir, "code.txt", "code\n")
+	run("add", "code.txt")
+	openGitRepo(t, model, dir)
+	model.generatedMessage = "Add code"
+
+	model.Update(model.commitStagedChanges()())
+	modal := model.hookOutput
+	if modal == nil {
+		t.Fatalf("Expected the hook output to be shown, got error %q", model.errorMessage)
+	}
+	if !contains(model.errorMessage, "pre-commit hook") || !contains(model.errorMessage, "re-staged 1 files") {
+		t.Errorf("Unexpected error message %q", model.errorMessage)
+	}
+	if staged := run("show", ":code.txt"); staged != "code\nformatted\n" {
+		t.Errorf("Expected the formatted file to be re-staged, got %q", staged)
+	}
+
+	// The output scrolls
+	if output := model.View(); !contains(output, "Re-staged files modified by the hooks: code.txt") || !contains(output, "lines 1-22 of 41") {
+		t.Errorf("Expected the first page of the output, got %q", output)
+	}
+	model.Update(tea.KeyMsg{Type: tea.KeyEnd})
+	if output := model.View(); !contains(output, "code.txt was reformatted") {
+		t.Errorf("Expected the end of the output, got %q", output)
+	}
+
+	// Retrying commits the re-staged file
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	if model.hookOutput != nil || cmd == nil {
+		t.Fatal("Expected r to close the output and retry")
+	}
+	if msg, ok := cmd().(errorMsg); ok {
+		t.Fatalf("Retry failed: %s", msg.error)
+	}
+	if log := run("log", "-1", "--format=%s"); strings.TrimSpace(log) != "Add code" {
+		t.Errorf("Expected the commit after retrying, got %q", log)
+	}
+
+	// Committing with --no-verify is confirmed first
+	writeHook("commit-msg", "#!/bin/sh\necho 'missing ticket' >&2\nexit 1\n")
+	writeRepoFile(t, dir, "more.txt", "more\n")
+	run("add", "more.txt")
+	model.generatedMessage = "Add more"
+	model.Update(model.commitStagedChanges()())
+	if model.hookOutput == nil || len(model.hookOutput.restaged) != 0 {
+		t.Fatalf("Expected the commit-msg rejection, got %+v", model.hookOutput)
+	}
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if model.modal == nil || model.modal.action != "commit_no_verify" {
+		t.Fatal("Expected a confirmation before skipping the hooks")
+	}
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if msg, ok := cmd().(errorMsg); ok {
+		t.Fatalf("Commit with --no-verify failed: %s", msg.error)
+	}
+	if log := run("log", "-1", "--format=%s"); strings.TrimSpace(log) != "Add more" {
+		t.Errorf("Expected the commit without hooks, got %q", log)
+	}
+}
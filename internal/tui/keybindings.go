@@ -36,23 +36,28 @@ func NewKeyBindingManager(cfg *config.Config) *KeyBindingManager {
 func (kbm *KeyBindingManager) initializeDefaultBindings() {
 	defaultBindings := []KeyBinding{
 		// Global bindings
-		{"q", "quit", "Quit application", []ViewMode{ViewModeStatus, ViewModeDiff, ViewModeLog, ViewModeHelp}},
-		{"ctrl+c", "quit", "Quit application", []ViewMode{ViewModeStatus, ViewModeDiff, ViewModeLog, ViewModeHelp}},
-		{"h", "help", "Show help", []ViewMode{ViewModeStatus, ViewModeDiff, ViewModeLog}},
-		{"r", "refresh", "Refresh current view", []ViewMode{ViewModeStatus, ViewModeDiff, ViewModeLog}},
-		{"s", "status", "Switch to status view", []ViewMode{ViewModeDiff, ViewModeLog, ViewModeHelp}},
+		{"q", "quit", "Quit application", []ViewMode{ViewModeStatus, ViewModeDiff, ViewModeLog, ViewModeHelp, ViewModeCommitDetail, ViewModeBranches, ViewModeStash, ViewModeRebase}},
+		{"ctrl+c", "quit", "Quit application", []ViewMode{ViewModeStatus, ViewModeDiff, ViewModeLog, ViewModeHelp, ViewModeCommitDetail, ViewModeBranches, ViewModeStash, ViewModeRebase}},
+		{"h", "help", "Show help", []ViewMode{ViewModeStatus, ViewModeDiff, ViewModeLog, ViewModeCommitDetail, ViewModeBranches, ViewModeStash}},
+		{"r", "refresh", "Refresh current view", []ViewMode{ViewModeStatus, ViewModeDiff, ViewModeLog, ViewModeBranches, ViewModeStash}},
+		{"s", "status", "Switch to status view", []ViewMode{ViewModeDiff, ViewModeLog, ViewModeHelp, ViewModeCommitDetail, ViewModeBranches, ViewModeStash}},
 		{"d", "diff", "Switch to diff view", []ViewMode{ViewModeStatus, ViewModeLog, ViewModeHelp}},
-		{"l", "log", "Switch to log view", []ViewMode{ViewModeStatus, ViewModeDiff, ViewModeHelp}},
+		{"l", "log", "Switch to log view", []ViewMode{ViewModeStatus, ViewModeDiff, ViewModeHelp, ViewModeBranches, ViewModeStash}},
+		{"b", "branches", "Switch to branches view", []ViewMode{ViewModeStatus, ViewModeDiff, ViewModeLog, ViewModeStash}},
+		{"S", "stashes", "Switch to stash view", []ViewMode{ViewModeStatus, ViewModeDiff, ViewModeLog, ViewModeBranches}},
+		{"P", "push", "Push current branch", []ViewMode{ViewModeStatus, ViewModeLog, ViewModeBranches}},
+		{"p", "pull", "Pull from upstream", []ViewMode{ViewModeStatus, ViewModeLog, ViewModeBranches}},
+		{"f", "fetch", "Fetch from remote", []ViewMode{ViewModeStatus, ViewModeLog, ViewModeBranches}},
 
 		// Navigation bindings
-		{"up", "nav_up", "Move cursor up", []ViewMode{ViewModeStatus, ViewModeLog}},
-		{"down", "nav_down", "Move cursor down", []ViewMode{ViewModeStatus, ViewModeLog}},
-		{"home", "nav_home", "Go to top", []ViewMode{ViewModeStatus, ViewModeDiff, ViewModeLog}},
-		{"end", "nav_end", "Go to bottom", []ViewMode{ViewModeStatus, ViewModeDiff, ViewModeLog}},
-		{"pgup", "nav_page_up", "Page up", []ViewMode{ViewModeStatus, ViewModeDiff, ViewModeLog}},
-		{"ctrl+u", "nav_page_up", "Page up", []ViewMode{ViewModeStatus, ViewModeDiff, ViewModeLog}},
-		{"pgdown", "nav_page_down", "Page down", []ViewMode{ViewModeStatus, ViewModeDiff, ViewModeLog}},
-		{"ctrl+d", "nav_page_down", "Page down", []ViewMode{ViewModeStatus, ViewModeDiff, ViewModeLog}},
+		{"up", "nav_up", "Move cursor up", []ViewMode{ViewModeStatus, ViewModeLog, ViewModeCommitDetail, ViewModeBranches, ViewModeStash, ViewModeRebase}},
+		{"down", "nav_down", "Move cursor down", []ViewMode{ViewModeStatus, ViewModeLog, ViewModeCommitDetail, ViewModeBranches, ViewModeStash, ViewModeRebase}},
+		{"home", "nav_home", "Go to top", []ViewMode{ViewModeStatus, ViewModeDiff, ViewModeLog, ViewModeCommitDetail, ViewModeBranches, ViewModeStash, ViewModeRebase}},
+		{"end", "nav_end", "Go to bottom", []ViewMode{ViewModeStatus, ViewModeDiff, ViewModeLog, ViewModeCommitDetail, ViewModeBranches, ViewModeStash, ViewModeRebase}},
+		{"pgup", "nav_page_up", "Page up", []ViewMode{ViewModeStatus, ViewModeDiff, ViewModeLog, ViewModeCommitDetail, ViewModeBranches, ViewModeStash, ViewModeRebase}},
+		{"ctrl+u", "nav_page_up", "Page up", []ViewMode{ViewModeStatus, ViewModeDiff, ViewModeLog, ViewModeCommitDetail, ViewModeBranches, ViewModeStash, ViewModeRebase}},
+		{"pgdown", "nav_page_down", "Page down", []ViewMode{ViewModeStatus, ViewModeDiff, ViewModeLog, ViewModeCommitDetail, ViewModeBranches, ViewModeStash, ViewModeRebase}},
+		{"ctrl+d", "nav_page_down", "Page down", []ViewMode{ViewModeStatus, ViewModeDiff, ViewModeLog, ViewModeCommitDetail, ViewModeBranches, ViewModeStash, ViewModeRebase}},
 
 		// Status view specific
 		{"space", "toggle_file", "Toggle file staging", []ViewMode{ViewModeStatus}},
@@ -68,9 +73,26 @@ func (kbm *KeyBindingManager) initializeDefaultBindings() {
 		{"G", "regenerate_message", "Regenerate commit message", []ViewMode{ViewModeStatus}},
 		{"R", "reset_file", "Reset current file", []ViewMode{ViewModeStatus}},
 		{"k", "discard_changes", "Discard changes to current file", []ViewMode{ViewModeStatus}},
+		{"U", "undo", "Undo last discard, reset, amend or reword", []ViewMode{ViewModeStatus, ViewModeLog}},
 		{"tab", "toggle_section", "Toggle section", []ViewMode{ViewModeStatus}},
+		{"m", "mark_file", "Mark/unmark current file", []ViewMode{ViewModeStatus}},
+		{"v", "mark_range", "Start/finish range selection", []ViewMode{ViewModeStatus}},
 		{"ctrl+a", "select_all", "Select all files", []ViewMode{ViewModeStatus}},
 		{"ctrl+n", "clear_selection", "Clear selection", []ViewMode{ViewModeStatus}},
+		{"y", "copy_file_path", "Copy file path", []ViewMode{ViewModeStatus, ViewModeDiff}},
+		{"Y", "copy_commit_message", "Copy generated commit message", []ViewMode{ViewModeStatus}},
+		{"o", "resolve_ours", "Resolve conflict with our version", []ViewMode{ViewModeStatus}},
+		{"t", "resolve_theirs", "Resolve conflict with their version", []ViewMode{ViewModeStatus}},
+		{"B", "resolve_both", "Resolve conflict keeping both sides", []ViewMode{ViewModeStatus}},
+		{"M", "continue_operation", "Continue merge, rebase, cherry-pick or revert", []ViewMode{ViewModeStatus}},
+		{"X", "abort_operation", "Abort merge, rebase, cherry-pick or revert", []ViewMode{ViewModeStatus}},
+		{"z", "stash_changes", "Stash all or marked changes", []ViewMode{ViewModeStatus}},
+		{"Z", "stash_staged", "Stash staged changes only", []ViewMode{ViewModeStatus}},
+		{"T", "edit_trailers", "Edit co-author, reviewer and sign-off trailers", []ViewMode{ViewModeStatus}},
+		{"O", "toggle_signoff", "Toggle Signed-off-by trailer", []ViewMode{ViewModeStatus}},
+		{"K", "toggle_signing", "Sign or skip signing the next commit (-S/--no-gpg-sign)", []ViewMode{ViewModeStatus}},
+		{"F", "fixup_commit", "Commit staged changes as a fixup of the suggested (status) or selected (log) commit", []ViewMode{ViewModeStatus, ViewModeLog}},
+		{"ctrl+f", "autosquash", "Squash fixup commits into their targets", []ViewMode{ViewModeStatus, ViewModeLog}},
 
 		// Diff view specific
 		{"left", "diff_prev_file", "Previous file", []ViewMode{ViewModeDiff}},
@@ -80,12 +102,60 @@ func (kbm *KeyBindingManager) initializeDefaultBindings() {
 		{"m", "cycle_diff_mode", "Cycle diff view mode", []ViewMode{ViewModeDiff}},
 		{"t", "toggle_stats", "Toggle statistics", []ViewMode{ViewModeDiff}},
 		{"T", "toggle_staged_unstaged", "Toggle staged/unstaged diff", []ViewMode{ViewModeDiff}},
+		{"]", "next_conflict", "Jump to next conflict", []ViewMode{ViewModeDiff}},
+		{"[", "prev_conflict", "Jump to previous conflict", []ViewMode{ViewModeDiff}},
+		{"g", "suggest_resolution", "Suggest a resolution for the current conflict", []ViewMode{ViewModeDiff}},
+		{"a", "accept_resolution", "Accept the suggested resolution", []ViewMode{ViewModeDiff}},
+		{"esc", "reject_resolution", "Reject the suggested resolution", []ViewMode{ViewModeDiff}},
 		{"D", "show_staged_diff", "Show staged diff", []ViewMode{ViewModeStatus}},
 
 		// Log view specific
 		{"enter", "show_commit_details", "Show commit details", []ViewMode{ViewModeLog}},
-		{"ctrl+c", "copy_commit_hash", "Copy commit hash", []ViewMode{ViewModeLog}},
+		{"y", "copy_commit_hash", "Copy full commit hash", []ViewMode{ViewModeLog, ViewModeCommitDetail}},
+		{"Y", "copy_commit_subject", "Copy commit subject", []ViewMode{ViewModeLog, ViewModeCommitDetail}},
 		{"ctrl+g", "toggle_graph", "Toggle commit graph", []ViewMode{ViewModeLog}},
+		{"v", "mark_commit_range", "Start/clear commit range selection", []ViewMode{ViewModeLog}},
+		{"M", "squash_message", "Generate squash message for range or merge message", []ViewMode{ViewModeLog}},
+		{"/", "filter_log", "Filter commits", []ViewMode{ViewModeLog}},
+		{"esc", "clear_log_filter", "Clear commit filter", []ViewMode{ViewModeLog}},
+		{"i", "rebase_interactive", "Interactive rebase onto selected commit", []ViewMode{ViewModeLog}},
+		{"R", "reword_commit", "Reword selected commit with a regenerated message", []ViewMode{ViewModeLog}},
+
+		// Rebase view specific
+		{"p", "rebase_pick", "Pick selected commit", []ViewMode{ViewModeRebase}},
+		{"r", "rebase_reword", "Reword selected commit", []ViewMode{ViewModeRebase}},
+		{"s", "rebase_squash", "Squash selected commit into the previous one", []ViewMode{ViewModeRebase}},
+		{"f", "rebase_fixup", "Fixup selected commit into the previous one, discarding its message", []ViewMode{ViewModeRebase}},
+		{"d", "rebase_drop", "Drop selected commit", []ViewMode{ViewModeRebase}},
+		{"K", "rebase_move_up", "Move selected commit up", []ViewMode{ViewModeRebase}},
+		{"J", "rebase_move_down", "Move selected commit down", []ViewMode{ViewModeRebase}},
+		{"e", "rebase_edit_message", "Edit new message of selected commit", []ViewMode{ViewModeRebase}},
+		{"g", "rebase_generate", "Generate new message of selected commit", []ViewMode{ViewModeRebase}},
+		{"G", "rebase_generate_all", "Generate messages of reworded commits without one", []ViewMode{ViewModeRebase}},
+		{"enter", "run_rebase", "Run the rebase", []ViewMode{ViewModeRebase}},
+		{"esc", "cancel_rebase", "Cancel the rebase and return to log view", []ViewMode{ViewModeRebase}},
+
+		// Commit detail view specific
+		{"enter", "show_file_diff", "Show diff of selected file", []ViewMode{ViewModeCommitDetail}},
+		{"d", "show_commit_diff", "Show diff of the whole commit", []ViewMode{ViewModeCommitDetail}},
+		{"esc", "back_to_log", "Return to log view", []ViewMode{ViewModeCommitDetail}},
+		{"l", "back_to_log", "Return to log view", []ViewMode{ViewModeCommitDetail}},
+		{"p", "copy_file_path", "Copy path of selected file", []ViewMode{ViewModeCommitDetail}},
+		{"n", "create_branch", "Create branch at selected commit", []ViewMode{ViewModeLog, ViewModeCommitDetail}},
+
+		// Branches view specific
+		{"enter", "checkout_branch", "Checkout selected branch", []ViewMode{ViewModeBranches}},
+		{"n", "create_branch", "Create branch from selected branch", []ViewMode{ViewModeBranches}},
+		{"R", "rename_branch", "Rename selected branch", []ViewMode{ViewModeBranches}},
+		{"D", "delete_branch", "Delete selected branch", []ViewMode{ViewModeBranches}},
+		{"u", "set_upstream", "Set upstream of selected branch", []ViewMode{ViewModeBranches}},
+		{"g", "pr_description", "Generate pull request description against selected branch", []ViewMode{ViewModeBranches}},
+
+		// Stash view specific
+		{"enter", "show_stash_diff", "Show diff of selected stash", []ViewMode{ViewModeStash}},
+		{"a", "apply_stash", "Apply selected stash", []ViewMode{ViewModeStash}},
+		{"p", "pop_stash", "Pop selected stash", []ViewMode{ViewModeStash}},
+		{"D", "drop_stash", "Drop selected stash", []ViewMode{ViewModeStash}},
 
 		// Help view
 		{"any", "return_to_status", "Return to status view", []ViewMode{ViewModeHelp}},
@@ -227,7 +297,7 @@ func (kbm *KeyBindingManager) GetFooterText(view ViewMode) string {
 
 	switch view {
 	case ViewModeStatus:
-		importantActions := []string{"toggle_file", "stage_file", "stage_all", "commit", "amend_commit", "generate_message", "discard_changes", "diff", "log", "help", "quit"}
+		importantActions := []string{"toggle_file", "mark_file", "stage_file", "stage_all", "commit", "amend_commit", "generate_message", "edit_trailers", "discard_changes", "push", "pull", "diff", "log", "help", "quit"}
 		for _, action := range importantActions {
 			if key := kbm.getKeyForAction(action, view); key != "" {
 				desc := kbm.getDescriptionForAction(action)
@@ -235,7 +305,7 @@ func (kbm *KeyBindingManager) GetFooterText(view ViewMode) string {
 			}
 		}
 	case ViewModeDiff:
-		importantActions := []string{"status", "diff_prev_file", "diff_next_file", "toggle_line_numbers", "cycle_diff_mode", "help", "quit"}
+		importantActions := []string{"status", "diff_prev_file", "diff_next_file", "next_conflict", "suggest_resolution", "toggle_line_numbers", "cycle_diff_mode", "help", "quit"}
 		for _, action := range importantActions {
 			if key := kbm.getKeyForAction(action, view); key != "" {
 				desc := kbm.getDescriptionForAction(action)
@@ -243,7 +313,39 @@ func (kbm *KeyBindingManager) GetFooterText(view ViewMode) string {
 			}
 		}
 	case ViewModeLog:
-		importantActions := []string{"show_commit_details", "copy_commit_hash", "status", "diff", "help", "quit"}
+		importantActions := []string{"show_commit_details", "copy_commit_hash", "squash_message", "rebase_interactive", "reword_commit", "filter_log", "status", "diff", "help", "quit"}
+		for _, action := range importantActions {
+			if key := kbm.getKeyForAction(action, view); key != "" {
+				desc := kbm.getDescriptionForAction(action)
+				footerBindings = append(footerBindings, fmt.Sprintf("%s:%s", key, desc))
+			}
+		}
+	case ViewModeCommitDetail:
+		importantActions := []string{"show_file_diff", "show_commit_diff", "back_to_log", "status", "help", "quit"}
+		for _, action := range importantActions {
+			if key := kbm.getKeyForAction(action, view); key != "" {
+				desc := kbm.getDescriptionForAction(action)
+				footerBindings = append(footerBindings, fmt.Sprintf("%s:%s", key, desc))
+			}
+		}
+	case ViewModeBranches:
+		importantActions := []string{"checkout_branch", "create_branch", "rename_branch", "delete_branch", "set_upstream", "pr_description", "status", "log", "quit"}
+		for _, action := range importantActions {
+			if key := kbm.getKeyForAction(action, view); key != "" {
+				desc := kbm.getDescriptionForAction(action)
+				footerBindings = append(footerBindings, fmt.Sprintf("%s:%s", key, desc))
+			}
+		}
+	case ViewModeStash:
+		importantActions := []string{"show_stash_diff", "apply_stash", "pop_stash", "drop_stash", "status", "quit"}
+		for _, action := range importantActions {
+			if key := kbm.getKeyForAction(action, view); key != "" {
+				desc := kbm.getDescriptionForAction(action)
+				footerBindings = append(footerBindings, fmt.Sprintf("%s:%s", key, desc))
+			}
+		}
+	case ViewModeRebase:
+		importantActions := []string{"rebase_pick", "rebase_reword", "rebase_squash", "rebase_fixup", "rebase_drop", "rebase_move_up", "rebase_move_down", "rebase_edit_message", "rebase_generate", "run_rebase", "cancel_rebase"}
 		for _, action := range importantActions {
 			if key := kbm.getKeyForAction(action, view); key != "" {
 				desc := kbm.getDescriptionForAction(action)
@@ -281,7 +383,10 @@ func (kbm *KeyBindingManager) getDescriptionForAction(action string) string {
 		"commit":              "commit",
 		"amend_commit":        "amend",
 		"generate_message":    "generate",
+		"edit_trailers":       "trailers",
 		"discard_changes":     "discard",
+		"undo":                "undo",
+		"mark_file":           "mark",
 		"diff":                "diff",
 		"log":                 "log",
 		"help":                "help",
@@ -293,6 +398,43 @@ func (kbm *KeyBindingManager) getDescriptionForAction(action string) string {
 		"status":              "back",
 		"show_commit_details": "details",
 		"copy_commit_hash":    "copy",
+		"filter_log":          "filter",
+		"show_file_diff":      "file diff",
+		"show_commit_diff":    "commit diff",
+		"back_to_log":         "back",
+		"branches":            "branches",
+		"checkout_branch":     "checkout",
+		"create_branch":       "new",
+		"rename_branch":       "rename",
+		"delete_branch":       "delete",
+		"set_upstream":        "upstream",
+		"push":                "push",
+		"pull":                "pull",
+		"fetch":               "fetch",
+		"stashes":             "stashes",
+		"stash_changes":       "stash",
+		"show_stash_diff":     "diff",
+		"apply_stash":         "apply",
+		"pop_stash":           "pop",
+		"drop_stash":          "drop",
+		"next_conflict":       "conflict",
+		"suggest_resolution":  "resolve",
+		"squash_message":      "squash msg",
+		"pr_description":      "pr desc",
+		"rebase_interactive":  "rebase",
+		"fixup_commit":        "fixup",
+		"reword_commit":       "reword",
+		"rebase_pick":         "pick",
+		"rebase_reword":       "reword",
+		"rebase_squash":       "squash",
+		"rebase_fixup":        "fixup",
+		"rebase_drop":         "drop",
+		"rebase_move_up":      "up",
+		"rebase_move_down":    "down",
+		"rebase_edit_message": "edit msg",
+		"rebase_generate":     "generate",
+		"run_rebase":          "run",
+		"cancel_rebase":       "cancel",
 	}
 
 	if desc, exists := shortDescriptions[action]; exists {
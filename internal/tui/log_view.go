@@ -0,0 +1,155 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/mopemope/git-rovo/internal/git"
+	"github.com/mopemope/git-rovo/internal/logger"
+)
+
+// setCommitHistory replaces the loaded commits with a freshly loaded first page
+func (m *Model) setCommitHistory(commits []git.CommitInfo, exhausted bool) {
+	m.commitHistory = commits
+	m.logViewState.exhausted = exhausted
+	m.logViewState.loadingMore = false
+	m.logViewState.rangeAnchor = -1
+	m.rebuildCommitGraph()
+
+	if m.currentView == ViewModeLog && m.cursor >= len(commits) {
+		m.cursor = max(len(commits)-1, 0)
+		m.logViewState.scrollOffset = min(m.logViewState.scrollOffset, m.cursor)
+	}
+}
+
+// appendCommitPage appends a lazily loaded page to the commit history
+func (m *Model) appendCommitPage(msg commitPageLoadedMsg) {
+	m.logViewState.loadingMore = false
+
+	// Ignore pages requested before the history was reloaded
+	if msg.skip != len(m.commitHistory) {
+		return
+	}
+
+	m.commitHistory = append(m.commitHistory, msg.commits...)
+	m.logViewState.exhausted = msg.exhausted
+	m.rebuildCommitGraph()
+}
+
+// rebuildCommitGraph recomputes the commit graph. Filtered histories leave out
+// parents, so the graph is only drawn for the unfiltered history.
+func (m *Model) rebuildCommitGraph() {
+	if m.logViewState.filter.IsFiltered() {
+		m.logViewState.graphRows = nil
+		return
+	}
+	m.logViewState.graphRows = buildCommitGraph(m.commitHistory)
+}
+
+// loadMoreCommits loads the next page of commits once the cursor reaches the
+// end of the loaded history
+func (m *Model) loadMoreCommits() tea.Cmd {
+	if m.logViewState.exhausted || m.logViewState.loadingMore || m.cursor < len(m.commitHistory)-1 {
+		return nil
+	}
+	m.logViewState.loadingMore = true
+
+	query := m.logViewState.filter
+	query.Skip = len(m.commitHistory)
+	query.Limit = m.logViewState.maxCommits
+	query.Signatures = true
+
+	return func() tea.Msg {
+		commits, err := m.repo.QueryCommits(query)
+		if err != nil {
+			return errorMsg{error: fmt.Sprintf("Failed to load commits: %v", err)}
+		}
+
+		logger.LogUIAction("commit_page_loaded", map[string]interface{}{
+			"skip":  query.Skip,
+			"count": len(commits),
+		})
+
+		return commitPageLoadedMsg{
+			commits:   commits,
+			skip:      query.Skip,
+			exhausted: query.Limit == 0 || len(commits) < query.Limit,
+		}
+	}
+}
+
+// openLogFilterPrompt opens the log filter prompt prefilled with the active filter
+func (m *Model) openLogFilterPrompt() tea.Cmd {
+	filter := m.logViewState.filter
+	fields := []promptField{
+		{label: "Author", value: filter.Author, placeholder: "name or email pattern"},
+		{label: "Message", value: filter.Grep, placeholder: "--grep pattern"},
+		{label: "Path", value: filter.Path, placeholder: "file or directory"},
+		{label: "Since", value: filter.Since, placeholder: "2024-01-01, 2 weeks ago"},
+		{label: "Until", value: filter.Until, placeholder: "2024-12-31, yesterday"},
+		{label: "Pickaxe", value: filter.Pickaxe, placeholder: "-S string added or removed"},
+	}
+
+	m.prompt = NewInputPrompt("log_filter", "Filter commits", fields, func(values []string) tea.Cmd {
+		return m.applyLogFilter(git.CommitQuery{
+			Author:  values[0],
+			Grep:    values[1],
+			Path:    values[2],
+			Since:   values[3],
+			Until:   values[4],
+			Pickaxe: values[5],
+		})
+	})
+
+	return nil
+}
+
+// applyLogFilter sets the log filter and reloads the history from the top
+func (m *Model) applyLogFilter(filter git.CommitQuery) tea.Cmd {
+	m.logViewState.filter = filter
+	m.logViewState.exhausted = false
+	m.cursor = 0
+	m.logViewState.scrollOffset = 0
+
+	if filter.IsFiltered() {
+		m.statusMessage = "Filter: " + describeCommitQuery(filter)
+	} else {
+		m.statusMessage = "Filter cleared"
+	}
+
+	logger.LogUIAction("log_filter_applied", map[string]interface{}{
+		"author":  filter.Author,
+		"grep":    filter.Grep,
+		"path":    filter.Path,
+		"since":   filter.Since,
+		"until":   filter.Until,
+		"pickaxe": filter.Pickaxe,
+	})
+
+	return m.refreshCommitHistory()
+}
+
+// describeCommitQuery returns a short summary of the active filter criteria
+func describeCommitQuery(q git.CommitQuery) string {
+	var parts []string
+	if q.Author != "" {
+		parts = append(parts, "Author: "+q.Author)
+	}
+	if q.Grep != "" {
+		parts = append(parts, "Message: "+q.Grep)
+	}
+	if q.Path != "" {
+		parts = append(parts, "Path: "+q.Path)
+	}
+	if q.Since != "" {
+		parts = append(parts, "Since: "+q.Since)
+	}
+	if q.Until != "" {
+		parts = append(parts, "Until: "+q.Until)
+	}
+	if q.Pickaxe != "" {
+		parts = append(parts, "-S: "+q.Pickaxe)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,156 @@
+package tui
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/mopemope/git-rovo/internal/git"
+)
+
+func makeTestCommits(n int) []git.CommitInfo {
+	commits := make([]git.CommitInfo, n)
+	for i := range commits {
+		hash := fmt.Sprintf("%040d", i)
+		commits[i] = git.CommitInfo{Hash: hash, ShortHash: hash[:8], Subject: fmt.Sprintf("commit %d", i)}
+		if i > 0 {
+			commits[i-1].Parents = []string{hash}
+		}
+	}
+	return commits
+}
+
+func typeText(model *Model, text string) {
+	for _, r := range text {
+		model.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func TestLogFilterPrompt(t *testing.T) {
+	model := setupMainViewTest(t)
+	model.currentView = ViewModeLog
+	model.cursor = 3
+
+	model.executeAction("filter_log")
+	if model.prompt == nil {
+		t.Fatal("Expected filter prompt to open")
+	}
+
+	typeText(model, "alice")
+	model.handleKeyPress(tea.KeyMsg{Type: tea.KeyTab})
+	typeText(model, "fix")
+	model.handleKeyPress(tea.KeyMsg{Type: tea.KeyBackspace})
+	model.handleKeyPress(tea.KeyMsg{Type: tea.KeyTab})
+	typeText(model, "docs")
+	model.handleKeyPress(tea.KeyMsg{Type: tea.KeyShiftTab})
+	model.handleKeyPress(tea.KeyMsg{Type: tea.KeyShiftTab})
+	model.handleKeyPress(tea.KeyMsg{Type: tea.KeyShiftTab})
+	typeText(model, "x")
+
+	_, cmd := model.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	if model.prompt != nil {
+		t.Error("Expected prompt to close on enter")
+	}
+	if cmd == nil {
+		t.Error("Expected history reload command")
+	}
+
+	filter := model.logViewState.filter
+	if filter.Author != "alice" || filter.Grep != "fi" || filter.Path != "docs" || filter.Pickaxe != "x" {
+		t.Errorf("Unexpected filter %+v", filter)
+	}
+	if model.cursor != 0 {
+		t.Errorf("Expected cursor reset, got %d", model.cursor)
+	}
+	if !contains(model.renderLogHeader(), "Author: alice") {
+		t.Error("Expected filter in log header")
+	}
+
+	// Esc in the log view clears the filter
+	model.executeAction("clear_log_filter")
+	if model.logViewState.filter.IsFiltered() {
+		t.Error("Expected filter to be cleared")
+	}
+}
+
+func TestLogFilterPromptCancel(t *testing.T) {
+	model := setupMainViewTest(t)
+	model.currentView = ViewModeLog
+	model.logViewState.filter = git.CommitQuery{Author: "bob"}
+
+	model.executeAction("filter_log")
+	if model.prompt.fields[0].value != "bob" {
+		t.Errorf("Expected prompt prefilled with active filter, got %q", model.prompt.fields[0].value)
+	}
+
+	model.handleKeyPress(tea.KeyMsg{Type: tea.KeyCtrlU})
+	model.handleKeyPress(tea.KeyMsg{Type: tea.KeyEsc})
+	if model.prompt != nil {
+		t.Error("Expected prompt to close on esc")
+	}
+	if model.logViewState.filter.Author != "bob" {
+		t.Error("Expected filter unchanged after cancel")
+	}
+}
+
+func TestLoadMoreCommits(t *testing.T) {
+	model := setupMainViewTest(t)
+	model.currentView = ViewModeLog
+	model.setCommitHistory(makeTestCommits(5), false)
+
+	model.cursor = 2
+	if cmd := model.loadMoreCommits(); cmd != nil {
+		t.Error("Expected no page load before the end of the history")
+	}
+
+	model.cursor = 4
+	if cmd := model.loadMoreCommits(); cmd == nil {
+		t.Fatal("Expected page load at the end of the history")
+	}
+	if cmd := model.loadMoreCommits(); cmd != nil {
+		t.Error("Expected no second load while a page is loading")
+	}
+
+	// A stale page is ignored
+	model.Update(commitPageLoadedMsg{commits: makeTestCommits(2), skip: 3})
+	if len(model.commitHistory) != 5 {
+		t.Errorf("Expected stale page to be ignored, got %d commits", len(model.commitHistory))
+	}
+
+	model.Update(commitPageLoadedMsg{commits: makeTestCommits(2), skip: 5, exhausted: true})
+	if len(model.commitHistory) != 7 {
+		t.Errorf("Expected 7 commits after loading a page, got %d", len(model.commitHistory))
+	}
+
+	model.cursor = 6
+	if cmd := model.loadMoreCommits(); cmd != nil {
+		t.Error("Expected no page load once the history is exhausted")
+	}
+}
+
+func TestUnlimitedHistoryIsExhausted(t *testing.T) {
+	model := setupMainViewTest(t)
+	dir, run := setupGitRepo(t)
+	run("commit", "-q", "--allow-empty", "-m", "Initial commit")
+	openGitRepo(t, model, dir)
+	model.logViewState.maxCommits = 0
+
+	msg, ok := model.refreshCommitHistory()().(commitHistoryRefreshedMsg)
+	if !ok || len(msg.commits) != 1 || !msg.exhausted {
+		t.Errorf("Expected the whole history without further pages, got %+v", msg)
+	}
+}
+
+func TestFilteredHistoryHasNoGraph(t *testing.T) {
+	model := setupMainViewTest(t)
+	model.setCommitHistory(makeTestCommits(3), true)
+	if len(model.logViewState.graphRows) != 3 {
+		t.Errorf("Expected graph rows for unfiltered history, got %d", len(model.logViewState.graphRows))
+	}
+
+	model.logViewState.filter = git.CommitQuery{Grep: "fix"}
+	model.setCommitHistory(makeTestCommits(3), true)
+	if model.logViewState.graphRows != nil {
+		t.Error("Expected no graph for filtered history")
+	}
+}
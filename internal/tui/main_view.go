@@ -16,6 +16,7 @@ type MainViewState struct {
 	expandedSections     map[string]bool
 	sortBy               SortBy
 	showStats            bool
+	markAnchor           int // Cursor index where a range selection started, -1 if none
 }
 
 // SortBy represents different sorting options
@@ -37,6 +38,7 @@ func NewMainViewState() *MainViewState {
 		expandedSections:     make(map[string]bool),
 		sortBy:               SortByName,
 		showStats:            true,
+		markAnchor:           -1,
 	}
 }
 
@@ -58,6 +60,12 @@ func (m *Model) renderEnhancedStatusView() string {
 	// Render sections
 	currentIndex := 0
 
+	if unmerged := m.unmergedFiles(); len(unmerged) > 0 {
+		sectionContent, newIndex := m.renderFileSection("Unmerged Paths", unmerged, currentIndex, m.styles.Error)
+		content.WriteString(sectionContent)
+		currentIndex = newIndex
+	}
+
 	if m.mainViewState.showStagedSection && len(staged) > 0 {
 		sectionContent, newIndex := m.renderFileSection("Staged Changes", staged, currentIndex, m.styles.Success)
 		content.WriteString(sectionContent)
@@ -143,6 +151,15 @@ func (m *Model) renderRepositorySummary() string {
 
 	summaryLine := fmt.Sprintf(" %s [%s] • %d staged, %d modified, %d untracked",
 		workDir, branch, len(staged), len(unstaged), len(untracked))
+	if unmerged := len(m.unmergedFiles()); unmerged > 0 {
+		summaryLine += fmt.Sprintf(", %d unmerged", unmerged)
+	}
+	if marked := len(m.getMarkedFiles()); marked > 0 {
+		summaryLine += fmt.Sprintf(", %d marked", marked)
+	}
+	if len(m.pendingTrailers) > 0 {
+		summaryLine += " • " + formatTrailers(m.pendingTrailers)
+	}
 
 	content.WriteString(m.styles.Info.Render(summaryLine))
 
@@ -184,7 +201,7 @@ func (m *Model) renderFileSection(title string, files []git.FileStatus, startInd
 
 	if expanded {
 		for _, file := range files {
-			line := m.renderEnhancedFileStatusLine(file, currentIndex == m.cursor)
+			line := m.renderEnhancedFileStatusLine(file, currentIndex)
 			// Add the line directly without additional spacing
 			content.WriteString(line)
 			content.WriteString("\n")
@@ -197,12 +214,16 @@ func (m *Model) renderFileSection(title string, files []git.FileStatus, startInd
 }
 
 // renderEnhancedFileStatusLine renders an enhanced file status line with more information
-func (m *Model) renderEnhancedFileStatusLine(file git.FileStatus, selected bool) string {
+func (m *Model) renderEnhancedFileStatusLine(file git.FileStatus, index int) string {
+	selected := index == m.cursor
 	var statusIcon string
 	var statusText string
 
 	// Determine status icon and text
 	switch {
+	case file.Unmerged():
+		statusIcon = "U"
+		statusText = file.ConflictDescription()
 	case file.Staged && strings.Contains(file.Status, "A"):
 		statusIcon = "+"
 		statusText = "added"
@@ -237,17 +258,37 @@ func (m *Model) renderEnhancedFileStatusLine(file git.FileStatus, selected bool)
 		prefix = "  "
 	}
 
+	// Mark indicator: '*' for marked files, '~' inside a pending range selection
+	mark := " "
+	if m.selected[file.Path] {
+		mark = "*"
+	} else if m.isInMarkRange(index) {
+		mark = "~"
+	}
+
 	// Create the basic line without any styling first
-	basicLine := fmt.Sprintf("%s%s %s (%s)", prefix, statusIcon, file.Path, statusText)
+	basicLine := fmt.Sprintf("%s%s%s %s (%s)", prefix, mark, statusIcon, file.Path, statusText)
 
 	// Apply styling only if needed and only to the entire line
 	if selected {
 		return m.styles.Selected.Render(basicLine)
 	}
+	if m.selected[file.Path] {
+		return m.styles.Info.Render(basicLine)
+	}
 
 	return basicLine
 }
 
+// isInMarkRange reports whether index lies between the range anchor and the cursor
+func (m *Model) isInMarkRange(index int) bool {
+	anchor := m.mainViewState.markAnchor
+	if anchor < 0 {
+		return false
+	}
+	return (index >= anchor && index <= m.cursor) || (index <= anchor && index >= m.cursor)
+}
+
 // renderCommitMessageSection renders the generated commit message section
 func (m *Model) renderCommitMessageSection() string {
 	var content strings.Builder
@@ -266,6 +307,11 @@ func (m *Model) renderCommitMessageSection() string {
 
 	content.WriteString(messageBox)
 	content.WriteString("\n")
+	content.WriteString(m.renderBreakingChanges())
+	if len(m.pendingTrailers) > 0 {
+		content.WriteString(m.styles.Info.Render(" Trailers to add on commit: " + formatTrailers(m.pendingTrailers)))
+		content.WriteString("\n")
+	}
 
 	// Confidence and actions with proper spacing
 	confidenceText := fmt.Sprintf(" Confidence: %.1f%% • Press 'c' to commit or 'g' to regenerate",
@@ -295,6 +341,8 @@ func (m *Model) renderQuickActions() string {
 		{"g", "Generate commit message"},
 		{"c", "Commit changes"},
 		{"1", "Amend last commit"},
+		{"U", "Undo last discard"},
+		{"m", "Mark/unmark file"},
 		{"d", "View diff"},
 	}
 
@@ -323,10 +371,13 @@ func (m *Model) renderQuickActions() string {
 	return content.String()
 }
 
-// groupFiles groups files by their status
+// groupFiles groups files by their status. Unmerged files are listed
+// separately by unmergedFiles.
 func (m *Model) groupFiles() (staged, unstaged, untracked []git.FileStatus) {
 	for _, file := range m.fileStatus {
-		if file.Staged {
+		if file.Unmerged() {
+			continue
+		} else if file.Staged {
 			staged = append(staged, file)
 		} else if file.Status == "??" {
 			untracked = append(untracked, file)
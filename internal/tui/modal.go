@@ -0,0 +1,239 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mopemope/git-rovo/internal/git"
+	"github.com/mopemope/git-rovo/internal/logger"
+)
+
+// ConfirmModal represents a y/N confirmation dialog shown before a destructive action
+type ConfirmModal struct {
+	action    string
+	title     string
+	message   string
+	details   []string
+	onConfirm tea.Cmd
+}
+
+// NewConfirmModal creates a new confirmation modal for the given action
+func NewConfirmModal(action, title, message string, details []string, onConfirm tea.Cmd) *ConfirmModal {
+	return &ConfirmModal{
+		action:    action,
+		title:     title,
+		message:   message,
+		details:   details,
+		onConfirm: onConfirm,
+	}
+}
+
+// withConfirmation runs cmd directly, or opens a confirmation modal when the action requires it
+func (m *Model) withConfirmation(action string, cmd tea.Cmd, buildModal func() *ConfirmModal) (tea.Model, tea.Cmd) {
+	if cmd == nil || m.config == nil || !m.config.UI.RequiresConfirmation(action) {
+		return m, cmd
+	}
+
+	modal := buildModal()
+	if modal == nil {
+		return m, cmd
+	}
+	modal.onConfirm = cmd
+	m.modal = modal
+
+	logger.LogUIAction("confirmation_requested", map[string]interface{}{
+		"action": action,
+	})
+
+	return m, nil
+}
+
+// handleModalKeyPress handles key presses while a modal is open
+func (m *Model) handleModalKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	modal := m.modal
+
+	switch msg.String() {
+	case "y", "Y":
+		m.modal = nil
+		logger.LogUIAction("confirmation_accepted", map[string]interface{}{
+			"action": modal.action,
+		})
+		return m, modal.onConfirm
+	case "n", "N", "esc", "enter", "q", "ctrl+c":
+		m.modal = nil
+		m.statusMessage = "Cancelled: " + modal.title
+		logger.LogUIAction("confirmation_cancelled", map[string]interface{}{
+			"action": modal.action,
+		})
+		return m, nil
+	}
+
+	// Any other key keeps the modal open
+	return m, nil
+}
+
+// renderModal renders the active modal centered in the content area
+func (m *Model) renderModal() string {
+	var content strings.Builder
+
+	content.WriteString(m.styles.Warning.Render(m.modal.title))
+	content.WriteString("\n\n")
+
+	if m.modal.message != "" {
+		content.WriteString(m.modal.message)
+		content.WriteString("\n")
+	}
+
+	if len(m.modal.details) > 0 {
+		content.WriteString("\n")
+		for _, detail := range m.modal.details {
+			content.WriteString("  " + detail)
+			content.WriteString("\n")
+		}
+	}
+
+	content.WriteString("\n")
+	content.WriteString(m.styles.Help.Render("Proceed? [y/N]"))
+
+	boxWidth := m.width - 10
+	if boxWidth > 80 {
+		boxWidth = 80
+	}
+	if boxWidth < 20 {
+		boxWidth = 20
+	}
+
+	box := m.styles.Base.
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(CatppuccinRed)).
+		Padding(1, 2).
+		Width(boxWidth).
+		Render(content.String())
+
+	return lipgloss.Place(m.width, lipgloss.Height(box)+2, lipgloss.Center, lipgloss.Center, box)
+}
+
+// describeFileChanges returns a one-line summary of what would be lost for a file
+func (m *Model) describeFileChanges(file git.FileStatus) string {
+	additions, deletions := 0, 0
+
+	if file.Status == "??" {
+		diffs, err := m.repo.GetUntrackedFileDiff(file.Path)
+		if err == nil {
+			for _, diff := range diffs {
+				additions += diff.Additions
+			}
+		}
+	} else {
+		for _, staged := range []bool{true, false} {
+			diffs, err := m.repo.GetDiff(staged, file.Path)
+			if err != nil {
+				continue
+			}
+			for _, diff := range diffs {
+				additions += diff.Additions
+				deletions += diff.Deletions
+			}
+		}
+	}
+
+	return fmt.Sprintf("%s [%s] +%d -%d", file.Path, describeFileStatus(file), additions, deletions)
+}
+
+// describeFileStatus returns a human readable description of a file status
+func describeFileStatus(file git.FileStatus) string {
+	switch {
+	case file.Status == "??":
+		return "untracked"
+	case file.Staged && file.Modified:
+		return "staged+modified"
+	case file.Staged:
+		return "staged"
+	default:
+		return "modified"
+	}
+}
+
+// discardConfirmation builds the confirmation modal for discarding the target files
+func (m *Model) discardConfirmation() *ConfirmModal {
+	targets := m.getTargetFiles()
+	if len(targets) == 0 {
+		return nil
+	}
+
+	message := "All uncommitted changes to these files will be lost."
+	if len(targets) == 1 {
+		message = "All uncommitted changes to this file will be lost."
+		if targets[0].Status == "??" {
+			message = "This untracked file will be deleted from disk."
+		}
+	}
+
+	var details []string
+	for _, file := range targets {
+		details = append(details, m.describeFileChanges(file))
+	}
+
+	return NewConfirmModal("discard_changes", "Discard changes?", message, details, nil)
+}
+
+// resetConfirmation builds the confirmation modal for resetting the target files
+func (m *Model) resetConfirmation() *ConfirmModal {
+	var details []string
+	for _, file := range m.getTargetFiles() {
+		if file.Status != "??" {
+			details = append(details, m.describeFileChanges(file))
+		}
+	}
+	if len(details) == 0 {
+		return nil
+	}
+
+	return NewConfirmModal("reset_file", "Reset to HEAD?",
+		"Working tree changes will be replaced with the HEAD version.",
+		details, nil)
+}
+
+// unstageAllConfirmation builds the confirmation modal for unstaging all files
+func (m *Model) unstageAllConfirmation() *ConfirmModal {
+	staged, _, _ := m.groupFiles()
+	if len(staged) == 0 {
+		return nil
+	}
+
+	var details []string
+	for _, file := range staged {
+		details = append(details, m.describeFileChanges(file))
+	}
+
+	return NewConfirmModal("unstage_all", "Unstage all files?",
+		fmt.Sprintf("%d staged files will be moved back to the working tree.", len(staged)),
+		details, nil)
+}
+
+// amendConfirmation builds the confirmation modal for amending the last commit
+func (m *Model) amendConfirmation() *ConfirmModal {
+	hash, err := m.repo.GetLastCommitHash()
+	if err != nil {
+		return nil
+	}
+
+	subject, _ := m.repo.GetLastCommitMessage()
+	details := []string{fmt.Sprintf("HEAD %s %s", git.ShortHash(hash), subject)}
+
+	if m.generatedMessage != "" {
+		newSubject := strings.SplitN(m.generatedMessage, "\n", 2)[0]
+		details = append(details, "New message: "+newSubject)
+	}
+
+	staged, _, _ := m.groupFiles()
+	for _, file := range staged {
+		details = append(details, m.describeFileChanges(file))
+	}
+
+	return NewConfirmModal("amend_commit", "Amend last commit?",
+		"The last commit will be rewritten and its hash will change.",
+		details, nil)
+}
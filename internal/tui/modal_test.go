@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mopemope/git-rovo/internal/config"
+	"github.com/mopemope/git-rovo/internal/logger"
+)
+
+func TestWithConfirmation(t *testing.T) {
+	model := setupMainViewTest(t)
+	defer func() { _ = logger.Close() }()
+
+	called := false
+	cmd := func() tea.Msg {
+		called = true
+		return operationCompletedMsg{message: "done"}
+	}
+	buildModal := func() *ConfirmModal {
+		return NewConfirmModal("discard_changes", "Discard changes?", "", []string{"file.txt [modified] +1 -0"}, nil)
+	}
+
+	// Without configured confirmation the command runs directly
+	_, returned := model.withConfirmation("discard_changes", cmd, buildModal)
+	if returned == nil || model.modal != nil {
+		t.Fatal("Expected command to be returned without a modal")
+	}
+
+	// With confirmation configured a modal is opened instead
+	model.config.UI.ConfirmActions = config.DefaultConfirmActions()
+	_, returned = model.withConfirmation("discard_changes", cmd, buildModal)
+	if returned != nil {
+		t.Error("Expected no command while waiting for confirmation")
+	}
+	if model.modal == nil {
+		t.Fatal("Expected confirmation modal to be opened")
+	}
+
+	// Unrelated keys keep the modal open
+	_, returned = model.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if returned != nil || model.modal == nil {
+		t.Error("Expected modal to stay open on unrelated key")
+	}
+
+	// 'y' confirms and returns the pending command
+	_, returned = model.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if model.modal != nil {
+		t.Error("Expected modal to be closed after confirmation")
+	}
+	if returned == nil {
+		t.Fatal("Expected pending command after confirmation")
+	}
+	returned()
+	if !called {
+		t.Error("Expected pending command to run")
+	}
+}
+
+func TestConfirmModalCancel(t *testing.T) {
+	model := setupMainViewTest(t)
+	defer func() { _ = logger.Close() }()
+
+	for _, key := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune{'n'}},
+		{Type: tea.KeyEsc},
+		{Type: tea.KeyEnter},
+	} {
+		model.modal = NewConfirmModal("reset_file", "Reset file to HEAD?", "", nil, func() tea.Msg {
+			t.Error("Command must not run when cancelled")
+			return nil
+		})
+
+		_, cmd := model.handleKeyPress(key)
+		if cmd != nil {
+			t.Errorf("Expected no command for key %q", key.String())
+		}
+		if model.modal != nil {
+			t.Errorf("Expected modal to be closed for key %q", key.String())
+		}
+	}
+}
+
+func TestRenderModal(t *testing.T) {
+	model := setupMainViewTest(t)
+	defer func() { _ = logger.Close() }()
+
+	model.width = 80
+	model.height = 24
+	model.modal = NewConfirmModal("discard_changes", "Discard changes?", "All uncommitted changes to this file will be lost.",
+		[]string{"main.go [modified] +3 -1"}, nil)
+
+	view := model.View()
+	for _, expected := range []string{"Discard changes?", "main.go [modified] +3 -1", "[y/N]", "y:confirm"} {
+		if !contains(view, expected) {
+			t.Errorf("Expected modal view to contain %q", expected)
+		}
+	}
+}
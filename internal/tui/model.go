@@ -3,11 +3,14 @@ package tui
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/mopemope/git-rovo/internal/clipboard"
 	"github.com/mopemope/git-rovo/internal/config"
 	"github.com/mopemope/git-rovo/internal/git"
 	"github.com/mopemope/git-rovo/internal/llm"
@@ -22,6 +25,10 @@ const (
 	ViewModeDiff
 	ViewModeLog
 	ViewModeHelp
+	ViewModeCommitDetail
+	ViewModeBranches
+	ViewModeStash
+	ViewModeRebase
 )
 
 // Model represents the main TUI model
@@ -40,22 +47,70 @@ type Model struct {
 	width         int
 	height        int
 	cursor        int
-	selected      map[int]bool
+	selected      map[string]bool // Marked file paths
 
 	// Data
-	fileStatus    []git.FileStatus
-	commitHistory []git.CommitInfo
-	currentDiff   []git.DiffInfo
-	statusMessage string
-	errorMessage  string
+	fileStatus       []git.FileStatus
+	commitHistory    []git.CommitInfo
+	commitDetails    *git.CommitDetails // Commit shown in the commit detail view
+	branches         []git.BranchInfo
+	stashes          []git.StashEntry
+	stashPreview     []git.DiffInfo // Diff of the stash shown in the stash view
+	stashPreviewHash string
+	currentDiff      []git.DiffInfo
+	statusMessage    string
+	errorMessage     string
+
+	// Upstream of the current branch shown in the header
+	upstream git.UpstreamStatus
+
+	// Merge, rebase, cherry-pick or revert in progress
+	repoState git.RepositoryState
+
+	// Conflict resolution suggested by the LLM, pending accept or reject
+	conflictProposal *conflictProposal
+
+	// Interactive rebase planned in the rebase view
+	rebasePlan *RebasePlan
 
 	// Loading states
 	loading        bool
 	loadingMessage string
+	remoteProgress string // Latest progress line of a running push, pull or fetch
 
 	// Generated commit message
 	generatedMessage  string
 	messageConfidence float32
+	breakingChanges   []string // Likely breaking changes of the staged diff
+
+	// Trailers of the next commit besides the ticket references
+	signoff       bool          // Add Signed-off-by, initially git.signoff
+	extraTrailers []git.Trailer // Co-authors and reviewers picked in the trailer editor
+	committer     string        // Committer identity, loaded on first sign-off
+
+	// Current branch and the trailers of the next commit on it, refreshed in
+	// Update so that rendering does not run git
+	branch          string
+	pendingTrailers []git.Trailer
+
+	// Commit signing configuration and the override of the next commit
+	signing  git.SigningConfig
+	signMode git.SignMode
+
+	// Active confirmation modal, if any
+	modal *ConfirmModal
+
+	// Active input prompt, if any
+	prompt *InputPrompt
+
+	// Active trailer editor, if any
+	trailerEditor *TrailerEditor
+
+	// Output of the hook that rejected the last commit, if shown
+	hookOutput *HookOutputModal
+
+	// Writes text to the system clipboard and returns the method used
+	copyText func(text string) (string, error)
 
 	// Styles
 	styles Styles
@@ -92,9 +147,13 @@ func NewModel(cfg *config.Config, repo *git.Repository, llmClient *llm.Client) *
 		llmClient:         llmClient,
 		keyBindingManager: NewKeyBindingManager(cfg),
 		currentView:       ViewModeStatus,
-		selected:          make(map[int]bool),
+		selected:          make(map[string]bool),
+		copyText:          clipboard.Copy,
 		styles:            NewStyles(),
 	}
+	if cfg != nil {
+		model.signoff = cfg.Git.Signoff
+	}
 	model.initMainViewState()
 	model.initDetailedViewStates()
 	return model
@@ -161,6 +220,8 @@ func (m *Model) Init() tea.Cmd {
 	return tea.Batch(
 		m.refreshStatus(),
 		m.refreshCommitHistory(),
+		m.refreshUpstreamStatus(),
+		m.refreshSigningConfig(),
 	)
 }
 
@@ -177,26 +238,127 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case statusRefreshedMsg:
 		m.fileStatus = msg.files
+		m.repoState = msg.state
+		m.branch = msg.branch
+		m.pruneSelection()
 		m.loading = false
 		m.errorMessage = ""
+		m.refreshPendingTrailers()
 		return m, nil
 
 	case commitHistoryRefreshedMsg:
-		m.commitHistory = msg.commits
+		m.setCommitHistory(msg.commits, msg.exhausted)
+		return m, nil
+
+	case commitPageLoadedMsg:
+		m.appendCommitPage(msg)
 		return m, nil
 
 	case diffRefreshedMsg:
+		m.conflictProposal = nil
 		m.currentDiff = msg.diffs
 		m.diffViewState.isStaged = msg.staged
+		m.diffViewState.markedFiles = msg.markedFiles
 		m.diffViewState.currentCommit = "" // Clear commit hash for regular diffs
 		return m, nil
 
+	case conflictSuggestedMsg:
+		m.loading = false
+		m.showConflictProposal(msg.proposal)
+		return m, nil
+
+	case conflictResolutionAppliedMsg:
+		m.statusMessage = fmt.Sprintf("Applied resolution to %s; stage it in the status view once all conflicts are resolved", msg.path)
+		return m, tea.Batch(m.refreshStatus(), m.showConflictDiff(msg.path))
+
+	case rangeMessageGeneratedMsg:
+		m.showRangeMessage(msg)
+		return m, nil
+
+	case pullRequestGeneratedMsg:
+		m.showPullRequest(msg)
+		return m, nil
+
+	case contributorsLoadedMsg:
+		m.openTrailerEditor(msg)
+		return m, nil
+
+	case hookFailedMsg:
+		m.loading = false
+		m.hookOutput = &HookOutputModal{err: msg.err, restaged: msg.restaged, lines: strings.Split(msg.err.Output, "\n")}
+		m.errorMessage = msg.err.Error()
+		if len(msg.restaged) > 0 {
+			m.errorMessage += fmt.Sprintf("; re-staged %d files modified by the hooks", len(msg.restaged))
+		}
+		return m, m.refreshStatus()
+
+	case clipboardCopiedMsg:
+		m.statusMessage = fmt.Sprintf("Copied %s (%s)", msg.description, msg.method)
+		return m, nil
+
+	case commitDetailsLoadedMsg:
+		m.commitDetails = msg.details
+		return m, nil
+
+	case branchesRefreshedMsg:
+		m.branches = msg.branches
+		for _, branch := range msg.branches {
+			if branch.Current && !branch.Remote {
+				m.branch = branch.Name
+				m.refreshPendingTrailers()
+			}
+		}
+		if m.currentView == ViewModeBranches && m.cursor >= len(m.branches) {
+			m.cursor = max(len(m.branches)-1, 0)
+		}
+		return m, nil
+
+	case stashesRefreshedMsg:
+		m.stashes = msg.stashes
+		if m.currentView != ViewModeStash {
+			return m, nil
+		}
+		if m.cursor >= len(m.stashes) {
+			m.cursor = max(len(m.stashes)-1, 0)
+		}
+		return m, m.loadStashPreview()
+
+	case rebasePlanLoadedMsg:
+		m.showRebasePlan(msg)
+		return m, nil
+
+	case rebaseMessagesGeneratedMsg:
+		m.setRebaseMessages(msg.messages)
+		return m, nil
+
+	case fixupTargetsMsg:
+		m.showFixupTargets(msg)
+		return m, nil
+
+	case rewordReadyMsg:
+		m.showRewordPrompt(msg)
+		return m, nil
+
+	case rebaseFinishedMsg:
+		return m, m.finishRebase(msg)
+
+	case stashPreviewLoadedMsg:
+		m.stashPreview = msg.diffs
+		m.stashPreviewHash = msg.hash
+		return m, nil
+
 	case commitDiffRefreshedMsg:
 		m.currentDiff = msg.diffs
 		m.diffViewState.isStaged = false
 		m.diffViewState.selectedFile = 0
+		for i, diff := range msg.diffs {
+			if msg.selectPath != "" && diff.FilePath == msg.selectPath {
+				m.diffViewState.selectedFile = i
+			}
+		}
 		m.diffViewState.scrollOffset = 0
 		m.diffViewState.currentCommit = msg.commitHash
+		m.diffViewState.markedFiles = 0
 		m.statusMessage = fmt.Sprintf("Showing diff for commit: %s", msg.commitHash[:8])
 		return m, nil
 
@@ -208,16 +370,55 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case commitMessageGeneratedMsg:
 		m.generatedMessage = msg.message
 		m.messageConfidence = msg.confidence
+		m.breakingChanges = msg.breakingChanges
 		m.loading = false
 		m.statusMessage = fmt.Sprintf("Generated commit message (confidence: %.1f%%)", msg.confidence*100)
+		if warning := breakingChangeWarning(msg.message, msg.breakingChanges); warning != "" {
+			m.statusMessage += "; " + warning
+		}
 		return m, nil
 
 	case operationCompletedMsg:
 		m.statusMessage = msg.message
 		m.loading = false
+		if msg.clearSelection {
+			m.clearSelection()
+		}
+		return m, tea.Batch(
+			m.refreshStatus(),
+			m.refreshCommitHistory(),
+			m.refreshBranches(),
+			m.refreshStashes(),
+			m.refreshUpstreamStatus(),
+		)
+
+	case upstreamStatusMsg:
+		m.upstream = msg.status
+		return m, nil
+
+	case signingConfigMsg:
+		m.signing = msg.config
+		return m, nil
+
+	case remoteProgressMsg:
+		m.loading = true
+		m.remoteProgress = msg.line
+		return m, waitForRemoteEvent(msg.events)
+
+	case remoteOperationDoneMsg:
+		m.loading = false
+		m.remoteProgress = ""
+		if msg.err != nil {
+			m.errorMessage = fmt.Sprintf("%s failed: %v", msg.operation, msg.err)
+		} else {
+			m.errorMessage = ""
+			m.statusMessage = msg.message
+		}
 		return m, tea.Batch(
 			m.refreshStatus(),
 			m.refreshCommitHistory(),
+			m.refreshBranches(),
+			m.refreshUpstreamStatus(),
 		)
 
 	case autoGenerateAndCommitMsg:
@@ -253,10 +454,28 @@ func (m *Model) View() string {
 		content = m.renderEnhancedLogView()
 	case ViewModeHelp:
 		content = m.renderHelpView()
+	case ViewModeCommitDetail:
+		content = m.renderCommitDetailView()
+	case ViewModeBranches:
+		content = m.renderBranchesView()
+	case ViewModeStash:
+		content = m.renderStashView()
+	case ViewModeRebase:
+		content = m.renderRebaseView()
 	default:
 		content = m.renderEnhancedStatusView()
 	}
 
+	if m.modal != nil {
+		content = m.renderModal()
+	} else if m.prompt != nil {
+		content = m.renderPrompt()
+	} else if m.trailerEditor != nil {
+		content = m.renderTrailerEditor()
+	} else if m.hookOutput != nil {
+		content = m.renderHookOutput()
+	}
+
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
 		m.renderHeader(),
@@ -291,13 +510,24 @@ func (m *Model) renderHeader() string {
 
 	// Repository info
 	repoInfo := fmt.Sprintf("Repository: %s [%s]", workDir, branch)
+	if tracking := describeUpstreamStatus(m.upstream); tracking != "" {
+		repoInfo += " " + m.styles.Info.Render(tracking)
+	}
 
-	if m.loading {
+	if m.remoteProgress != "" {
+		repoInfo += " " + m.styles.Loading.Render(m.remoteProgress)
+	} else if m.loading {
 		repoInfo += " " + m.styles.Loading.Render("(loading...)")
 	}
+	if signing := m.describeSigning(); signing != "" {
+		repoInfo += " " + m.styles.Help.Render(signing)
+	}
 
 	// Combine banner and repo info with proper spacing
 	content := strings.TrimSpace(banner) + "\n\n" + repoInfo
+	if operation := m.renderOperationBanner(); operation != "" {
+		content += "\n" + operation
+	}
 
 	return m.styles.Header.Width(m.width).Render(content)
 }
@@ -318,6 +548,15 @@ func (m *Model) renderFooter() string {
 
 	// Key bindings from key binding manager
 	keyBindings := m.keyBindingManager.GetFooterText(m.currentView)
+	if m.modal != nil {
+		keyBindings = "y:confirm | n/esc:cancel"
+	} else if m.prompt != nil {
+		keyBindings = "enter:apply | tab:next field | ctrl+u:clear | esc:cancel"
+	} else if m.trailerEditor != nil {
+		keyBindings = "space:toggle | r:reviewer | j/k:move | enter:apply | esc:cancel"
+	} else if m.hookOutput != nil {
+		keyBindings = "j/k:scroll | r:retry | n:commit with --no-verify | esc:close"
+	}
 	footer.WriteString(m.styles.Footer.Width(m.width).Render(keyBindings))
 
 	return footer.String()
@@ -325,6 +564,20 @@ func (m *Model) renderFooter() string {
 
 // handleKeyPress handles key press events
 func (m *Model) handleKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// An open modal captures all key presses
+	if m.modal != nil {
+		return m.handleModalKeyPress(msg)
+	}
+	if m.prompt != nil {
+		return m.handlePromptKeyPress(msg)
+	}
+	if m.trailerEditor != nil {
+		return m.handleTrailerEditorKeyPress(msg)
+	}
+	if m.hookOutput != nil {
+		return m.handleHookOutputKeyPress(msg)
+	}
+
 	// Use unified key handling system
 	return m.handleUnifiedKeyPress(msg)
 }
@@ -354,6 +607,14 @@ func viewModeToString(mode ViewMode) string {
 		return "log"
 	case ViewModeHelp:
 		return "help"
+	case ViewModeCommitDetail:
+		return "commit"
+	case ViewModeBranches:
+		return "branches"
+	case ViewModeStash:
+		return "stash"
+	case ViewModeRebase:
+		return "rebase"
 	default:
 		return "unknown"
 	}
@@ -361,21 +622,90 @@ func viewModeToString(mode ViewMode) string {
 
 // Message types for async operations
 type statusRefreshedMsg struct {
-	files []git.FileStatus
+	files  []git.FileStatus
+	state  git.RepositoryState
+	branch string
 }
 
 type commitHistoryRefreshedMsg struct {
-	commits []git.CommitInfo
+	commits   []git.CommitInfo
+	exhausted bool // No more commits match the current filter
+}
+
+type commitPageLoadedMsg struct {
+	commits   []git.CommitInfo
+	skip      int // Number of commits loaded before this page
+	exhausted bool
 }
 
 type diffRefreshedMsg struct {
-	diffs  []git.DiffInfo
-	staged bool
+	diffs       []git.DiffInfo
+	staged      bool
+	markedFiles int // Number of marked files in a combined diff, 0 otherwise
 }
 
 type commitDiffRefreshedMsg struct {
 	diffs      []git.DiffInfo
 	commitHash string
+	selectPath string // File to select initially, empty for the first file
+}
+
+type conflictSuggestedMsg struct {
+	proposal *conflictProposal
+}
+
+type conflictResolutionAppliedMsg struct {
+	path string
+}
+
+type rangeMessageGeneratedMsg struct {
+	mode    llm.RequestMode
+	label   string
+	message string
+}
+
+type pullRequestGeneratedMsg struct {
+	base   string
+	branch string
+	title  string
+	body   string
+}
+
+type clipboardCopiedMsg struct {
+	description string
+	method      string // Clipboard method used, e.g. osc52 or xclip
+}
+
+type branchesRefreshedMsg struct {
+	branches []git.BranchInfo
+}
+
+type stashesRefreshedMsg struct {
+	stashes []git.StashEntry
+}
+
+type stashPreviewLoadedMsg struct {
+	hash  string
+	diffs []git.DiffInfo
+}
+
+type upstreamStatusMsg struct {
+	status git.UpstreamStatus
+}
+
+type remoteProgressMsg struct {
+	line   string
+	events <-chan tea.Msg // Events of the running operation
+}
+
+type remoteOperationDoneMsg struct {
+	operation string
+	message   string
+	err       error
+}
+
+type commitDetailsLoadedMsg struct {
+	details *git.CommitDetails
 }
 
 type errorMsg struct {
@@ -383,12 +713,14 @@ type errorMsg struct {
 }
 
 type commitMessageGeneratedMsg struct {
-	message    string
-	confidence float32
+	message         string
+	confidence      float32
+	breakingChanges []string
 }
 
 type operationCompletedMsg struct {
-	message string
+	message        string
+	clearSelection bool // Clear marks after a batch operation
 }
 
 type autoGenerateAndCommitMsg struct{}
@@ -405,18 +737,30 @@ func (m *Model) refreshStatus() tea.Cmd {
 		if err != nil {
 			return errorMsg{error: err.Error()}
 		}
-		return statusRefreshedMsg{files: files}
+		state, err := m.repo.GetRepositoryState()
+		if err != nil {
+			logger.Debug("Failed to get repository state", "error", err)
+		}
+		branch, err := m.repo.GetCurrentBranch()
+		if err != nil {
+			logger.Debug("Failed to get the current branch", "error", err)
+		}
+		return statusRefreshedMsg{files: files, state: state, branch: branch}
 	}
 }
 
-// refreshCommitHistory refreshes the commit history
+// refreshCommitHistory reloads the first page of commits matching the log filter
 func (m *Model) refreshCommitHistory() tea.Cmd {
+	query := m.logViewState.filter
+	query.Limit = m.logViewState.maxCommits
+	query.Signatures = true
+
 	return func() tea.Msg {
-		commits, err := m.repo.GetCommitHistory(20) // Get last 20 commits
+		commits, err := m.repo.QueryCommits(query)
 		if err != nil {
 			return errorMsg{error: err.Error()}
 		}
-		return commitHistoryRefreshedMsg{commits: commits}
+		return commitHistoryRefreshedMsg{commits: commits, exhausted: query.Limit == 0 || len(commits) < query.Limit}
 	}
 }
 
@@ -489,6 +833,56 @@ func (m *Model) refreshDiff(staged bool, files ...string) tea.Cmd {
 	}
 }
 
+// refreshMarkedDiff builds a combined diff for the marked files, using the staged
+// diff for staged files and the working tree diff for everything else
+func (m *Model) refreshMarkedDiff(files []git.FileStatus) tea.Cmd {
+	return func() tea.Msg {
+		var diffs []git.DiffInfo
+		var staged, unstaged, untracked []string
+
+		for _, file := range files {
+			switch {
+			case file.Unmerged():
+				diff, err := m.repo.GetConflictDiff(file.Path)
+				if err != nil {
+					return errorMsg{error: err.Error()}
+				}
+				diffs = append(diffs, *diff)
+			case file.Status == "??":
+				untracked = append(untracked, file.Path)
+			case file.Staged:
+				staged = append(staged, file.Path)
+			default:
+				unstaged = append(unstaged, file.Path)
+			}
+		}
+
+		if len(staged) > 0 {
+			stagedDiffs, err := m.repo.GetDiff(true, staged...)
+			if err != nil {
+				return errorMsg{error: err.Error()}
+			}
+			diffs = append(diffs, stagedDiffs...)
+		}
+		if len(unstaged) > 0 {
+			unstagedDiffs, err := m.repo.GetDiff(false, unstaged...)
+			if err != nil {
+				return errorMsg{error: err.Error()}
+			}
+			diffs = append(diffs, unstagedDiffs...)
+		}
+		if len(untracked) > 0 {
+			untrackedDiffs, err := m.repo.GetUntrackedFileDiff(untracked...)
+			if err != nil {
+				return errorMsg{error: err.Error()}
+			}
+			diffs = append(diffs, untrackedDiffs...)
+		}
+
+		return diffRefreshedMsg{diffs: diffs, markedFiles: len(files)}
+	}
+}
+
 // generateCommitMessage generates a commit message using LLM
 func (m *Model) generateCommitMessage() tea.Cmd {
 	return func() tea.Msg {
@@ -533,10 +927,12 @@ func (m *Model) generateCommitMessage() tea.Cmd {
 
 		// Create request
 		request := &llm.CommitMessageRequest{
-			Diff:        diffContent.String(),
-			Language:    m.config.LLM.Language,
-			MaxTokens:   m.config.LLM.OpenAI.MaxTokens,
-			Temperature: m.config.LLM.OpenAI.Temperature,
+			Diff:              diffContent.String(),
+			Language:          m.config.LLM.Language,
+			AdditionalContext: ticketContext(m.ticketTrailers()),
+			BreakingChanges:   m.analyzeBreakingChanges(diffs),
+			MaxTokens:         m.config.LLM.OpenAI.MaxTokens,
+			Temperature:       m.config.LLM.OpenAI.Temperature,
 		}
 
 		// Generate message
@@ -549,8 +945,9 @@ func (m *Model) generateCommitMessage() tea.Cmd {
 		}
 
 		return commitMessageGeneratedMsg{
-			message:    formatCommitMessage(response.Message),
-			confidence: response.Confidence,
+			message:         formatCommitMessage(response.Message),
+			confidence:      response.Confidence,
+			breakingChanges: request.BreakingChanges,
 		}
 	}
 }
@@ -582,8 +979,10 @@ func (m *Model) generateCommitMessageForAutoCommit() tea.Cmd {
 
 		// Create request
 		request := &llm.CommitMessageRequest{
-			Diff:     diffContent.String(),
-			Language: m.config.LLM.Language,
+			Diff:              diffContent.String(),
+			Language:          m.config.LLM.Language,
+			AdditionalContext: ticketContext(m.ticketTrailers()),
+			BreakingChanges:   m.analyzeBreakingChanges(diffs),
 		}
 
 		// Generate message
@@ -605,20 +1004,27 @@ func (m *Model) generateCommitMessageForAutoCommit() tea.Cmd {
 // performAutoCommit performs the actual commit after message generation
 func (m *Model) performAutoCommit() tea.Cmd {
 	return func() tea.Msg {
-		// Perform commit
-		err := m.repo.Commit(m.generatedMessage)
+		message, err := m.addCommitTrailers(m.generatedMessage)
 		if err != nil {
 			return errorMsg{error: fmt.Sprintf("Failed to commit: %v", err)}
 		}
 
+		// Perform commit
+		err = m.repo.CommitWithOptions(message, git.CommitOptions{Sign: m.signMode})
+		if err != nil {
+			return m.commitFailed(err)
+		}
+
 		logger.LogUIAction("auto_commit_created", map[string]interface{}{
-			"message":    m.generatedMessage,
+			"message":    message,
 			"confidence": m.messageConfidence,
 		})
 
-		// Clear generated message after successful commit
+		// Clear generated message and picked co-authors after successful commit
 		m.generatedMessage = ""
 		m.messageConfidence = 0
+		m.extraTrailers = nil
+		m.signMode = git.SignDefault
 
 		return operationCompletedMsg{message: "Auto-commit completed successfully"}
 	}
@@ -626,7 +1032,8 @@ func (m *Model) performAutoCommit() tea.Cmd {
 
 // formatCommitMessage ensures the commit message follows proper Git commit format
 // It checks if there's a blank line between the subject (first line) and body (subsequent lines)
-// and adds one if missing
+// and adds one if missing. A trailer block at the end, such as "Co-authored-by:" lines, is
+// separated from the body by a blank line as well, so Git still recognizes it.
 func formatCommitMessage(message string) string {
 	lines := strings.Split(message, "\n")
 
@@ -645,19 +1052,29 @@ func formatCommitMessage(message string) string {
 		return strings.Join(lines, "\n")
 	}
 
-	// Check if the second line is empty (proper format)
-	if len(lines) >= 2 && strings.TrimSpace(lines[1]) == "" {
-		// Already properly formatted
-		return strings.Join(lines, "\n")
+	// Add a blank line between subject and body if missing
+	if strings.TrimSpace(lines[1]) != "" {
+		lines = slices.Insert(lines, 1, "")
 	}
 
-	// Need to add blank line between subject and body
-	subject := lines[0]
-	body := lines[1:]
+	// Add a blank line between body and trailers if missing
+	if start := trailerBlockStart(lines); start > 2 && start < len(lines) && strings.TrimSpace(lines[start-1]) != "" {
+		lines = slices.Insert(lines, start, "")
+	}
+
+	return strings.Join(lines, "\n")
+}
 
-	// Create properly formatted message
-	formattedLines := []string{subject, ""} // subject + blank line
-	formattedLines = append(formattedLines, body...)
+// trailerLine matches a "Key: value" trailer, including the Conventional
+// Commits "BREAKING CHANGE" footer
+var trailerLine = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9-]*|BREAKING CHANGE): \S`)
 
-	return strings.Join(formattedLines, "\n")
+// trailerBlockStart returns the index of the first line of the trailers at
+// the end of the message body, or len(lines) when there are none
+func trailerBlockStart(lines []string) int {
+	start := len(lines)
+	for start > 2 && trailerLine.MatchString(lines[start-1]) {
+		start--
+	}
+	return start
 }
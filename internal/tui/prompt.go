@@ -0,0 +1,142 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mopemope/git-rovo/internal/logger"
+)
+
+// promptField is a single labelled text field of an input prompt
+type promptField struct {
+	label       string
+	value       string
+	placeholder string
+	multiline   bool // Enter inserts a line break; the prompt is submitted with ctrl+s
+}
+
+// InputPrompt represents a dialog with one or more text fields
+type InputPrompt struct {
+	name     string
+	title    string
+	fields   []promptField
+	focus    int
+	onSubmit func(values []string) tea.Cmd
+}
+
+// NewInputPrompt creates a new input prompt; onSubmit receives the field values in order
+func NewInputPrompt(name, title string, fields []promptField, onSubmit func(values []string) tea.Cmd) *InputPrompt {
+	return &InputPrompt{
+		name:     name,
+		title:    title,
+		fields:   fields,
+		onSubmit: onSubmit,
+	}
+}
+
+// values returns the trimmed value of every field
+func (p *InputPrompt) values() []string {
+	values := make([]string, len(p.fields))
+	for i, field := range p.fields {
+		values[i] = strings.TrimSpace(field.value)
+	}
+	return values
+}
+
+// multilineLabels lists the labels of the multi-line fields
+func (p *InputPrompt) multilineLabels() string {
+	var labels []string
+	for _, field := range p.fields {
+		if field.multiline {
+			labels = append(labels, strings.ToLower(field.label))
+		}
+	}
+	return strings.Join(labels, ", ")
+}
+
+// handlePromptKeyPress handles key presses while an input prompt is open
+func (m *Model) handlePromptKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	prompt := m.prompt
+	field := &prompt.fields[prompt.focus]
+
+	switch msg.Type {
+	case tea.KeyEnter, tea.KeyCtrlS:
+		if msg.Type == tea.KeyEnter && field.multiline {
+			field.value += "\n"
+			return m, nil
+		}
+		m.prompt = nil
+		logger.LogUIAction("prompt_submitted", map[string]interface{}{
+			"prompt": prompt.name,
+		})
+		return m, prompt.onSubmit(prompt.values())
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.prompt = nil
+		m.statusMessage = "Cancelled: " + prompt.title
+		return m, nil
+	case tea.KeyTab, tea.KeyDown:
+		prompt.focus = (prompt.focus + 1) % len(prompt.fields)
+	case tea.KeyShiftTab, tea.KeyUp:
+		prompt.focus = (prompt.focus + len(prompt.fields) - 1) % len(prompt.fields)
+	case tea.KeyBackspace:
+		if runes := []rune(field.value); len(runes) > 0 {
+			field.value = string(runes[:len(runes)-1])
+		}
+	case tea.KeyCtrlU:
+		field.value = ""
+	case tea.KeySpace:
+		field.value += " "
+	case tea.KeyRunes:
+		field.value += string(msg.Runes)
+	}
+
+	return m, nil
+}
+
+// renderPrompt renders the active input prompt centered in the content area
+func (m *Model) renderPrompt() string {
+	var content strings.Builder
+
+	content.WriteString(m.styles.Info.Render(m.prompt.title))
+	content.WriteString("\n\n")
+
+	labelWidth := 0
+	for _, field := range m.prompt.fields {
+		labelWidth = max(labelWidth, len(field.label))
+	}
+
+	multiline := false
+	for i, field := range m.prompt.fields {
+		label := field.label + ":" + strings.Repeat(" ", labelWidth-len(field.label)+1)
+		// Continuation lines are aligned with the first line of the value
+		value := strings.ReplaceAll(field.value, "\n", "\n"+strings.Repeat(" ", len(label)+2))
+		multiline = multiline || field.multiline
+		if i == m.prompt.focus {
+			content.WriteString(m.styles.Selected.Render("> " + label))
+			content.WriteString(value + "█")
+		} else {
+			content.WriteString(m.styles.Base.Render("  " + label))
+			if value == "" {
+				value = m.styles.Help.Render(field.placeholder)
+			}
+			content.WriteString(value)
+		}
+		content.WriteString("\n")
+	}
+	if multiline {
+		content.WriteString("\n")
+		content.WriteString(m.styles.Help.Render("enter: new line in " + m.prompt.multilineLabels() + " • ctrl+s: save • tab: next field • esc: cancel"))
+	}
+
+	boxWidth := min(max(m.width-10, 20), 80)
+
+	box := m.styles.Base.
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(CatppuccinBlue)).
+		Padding(1, 2).
+		Width(boxWidth).
+		Render(strings.TrimRight(content.String(), "\n"))
+
+	return lipgloss.Place(m.width, lipgloss.Height(box)+2, lipgloss.Center, lipgloss.Center, box)
+}
@@ -0,0 +1,75 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/mopemope/git-rovo/internal/llm"
+	"github.com/mopemope/git-rovo/internal/logger"
+)
+
+// generatePullRequest generates a pull request title and description for the
+// current branch with the selected branch as its base
+func (m *Model) generatePullRequest() (tea.Model, tea.Cmd) {
+	branch := m.getSelectedBranch()
+	if branch == nil {
+		return m, nil
+	}
+	if branch.Current {
+		m.errorMessage = "Select the branch the pull request should be merged into"
+		return m, nil
+	}
+
+	base := branch.Name
+	m.loading = true
+	m.loadingMessage = fmt.Sprintf("Generating pull request description against %s...", base)
+	return m, func() tea.Msg {
+		info, err := m.repo.GetPullRequestInfo(base)
+		if err != nil {
+			return errorMsg{error: fmt.Sprintf("Failed to compare with %s: %v", base, err)}
+		}
+
+		request := &llm.CommitMessageRequest{
+			Mode:              llm.ModePullRequest,
+			Diff:              info.Describe(),
+			Language:          m.config.LLM.Language,
+			AdditionalContext: info.Template,
+			MaxTokens:         m.config.LLM.OpenAI.MaxTokens,
+			Temperature:       m.config.LLM.OpenAI.Temperature,
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		response, err := m.llmClient.GenerateCommitMessage(ctx, request, "")
+		if err != nil {
+			return errorMsg{error: fmt.Sprintf("Failed to generate pull request description: %v", err)}
+		}
+		title, body, err := llm.ParsePullRequest(response.Message)
+		if err != nil {
+			return errorMsg{error: fmt.Sprintf("Unusable pull request description: %v", err)}
+		}
+
+		logger.LogUIAction("pull_request_generated", map[string]interface{}{
+			"base":     base,
+			"branch":   info.Branch,
+			"commits":  len(info.Summary.Commits),
+			"template": info.TemplatePath,
+		})
+
+		return pullRequestGeneratedMsg{base: base, branch: info.Branch, title: title, body: body}
+	}
+}
+
+// showPullRequest shows a generated pull request and offers to copy it to the clipboard
+func (m *Model) showPullRequest(msg pullRequestGeneratedMsg) {
+	m.loading = false
+
+	text := llm.FormatPullRequest(msg.title, msg.body)
+	m.modal = NewConfirmModal("copy_pull_request", fmt.Sprintf("Pull request %s into %s", msg.branch, msg.base),
+		"Copy the title and description to the clipboard?",
+		strings.Split(strings.TrimSuffix(text, "\n"), "\n"), m.copyToClipboard("pull request", text))
+}
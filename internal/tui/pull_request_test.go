@@ -0,0 +1,56 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/mopemope/git-rovo/internal/llm"
+)
+
+func TestGeneratePullRequest(t *testing.T) {
+	model := setupBranchRepoTest(t)
+	provider := useScriptedLLM(t, model, "<title>\nAdd unmerged work\n</title>\n<body>\n## Summary\n\nFinishes the **unmerged** work.\n</body>")
+	copied := ""
+	model.copyText = func(text string) (string, error) {
+		copied = text
+		return "test", nil
+	}
+
+	selectBranch(t, model, "main")
+	model.executeAction("pr_description")
+	if !contains(model.errorMessage, "merged into") {
+		t.Errorf("Expected the current branch to be refused as base, got %q", model.errorMessage)
+	}
+
+	if err := model.repo.CheckoutBranch("unmerged"); err != nil {
+		t.Fatalf("Failed to checkout: %v", err)
+	}
+	branches, err := model.repo.GetBranches()
+	if err != nil {
+		t.Fatalf("Failed to get branches: %v", err)
+	}
+	model.branches = branches
+	selectBranch(t, model, "main")
+
+	_, cmd := model.executeAction("pr_description")
+	if !model.loading {
+		t.Error("Expected loading state while the description is generated")
+	}
+	model.Update(cmd())
+
+	if len(provider.requests) != 1 || provider.requests[0].Mode != llm.ModePullRequest {
+		t.Fatalf("Expected one pull request request, got %+v", provider.requests)
+	}
+	if diff := provider.requests[0].Diff; !contains(diff, "Branch unmerged into main") || !contains(diff, "Unmerged work") {
+		t.Errorf("Unexpected pull request diff %q", diff)
+	}
+	if model.modal == nil || model.modal.title != "Pull request unmerged into main" {
+		t.Fatalf("Expected the description in a modal, got %+v", model.modal)
+	}
+
+	_, cmd = model.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	model.Update(cmd())
+	if copied != "Add unmerged work\n\n## Summary\n\nFinishes the **unmerged** work.\n" {
+		t.Errorf("Expected the title and description to be copied, got %q", copied)
+	}
+}
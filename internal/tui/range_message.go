@@ -0,0 +1,119 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/mopemope/git-rovo/internal/git"
+	"github.com/mopemope/git-rovo/internal/llm"
+	"github.com/mopemope/git-rovo/internal/logger"
+)
+
+// toggleCommitRange starts a range selection at the selected commit, or
+// clears the one in progress
+func (m *Model) toggleCommitRange() tea.Cmd {
+	if m.logViewState.rangeAnchor >= 0 {
+		m.logViewState.rangeAnchor = -1
+		m.statusMessage = "Range selection cleared"
+		return nil
+	}
+	if m.cursor >= len(m.commitHistory) {
+		return nil
+	}
+	m.logViewState.rangeAnchor = m.cursor
+	m.statusMessage = fmt.Sprintf("Range starts at %s; move to the other end and press M", m.commitHistory[m.cursor].ShortHash)
+	return nil
+}
+
+// isInCommitRange reports whether index lies between the range anchor and the cursor
+func (m *Model) isInCommitRange(index int) bool {
+	anchor := m.logViewState.rangeAnchor
+	if anchor < 0 {
+		return false
+	}
+	return (index >= anchor && index <= m.cursor) || (index <= anchor && index >= m.cursor)
+}
+
+// generateRangeMessage generates a squash message for the selected range of
+// commits, or a merge message for the commits a selected merge brought in
+func (m *Model) generateRangeMessage() (tea.Model, tea.Cmd) {
+	if m.cursor >= len(m.commitHistory) {
+		return m, nil
+	}
+
+	var mode llm.RequestMode
+	var revRange, label, extraContext string
+	if anchor := m.logViewState.rangeAnchor; anchor >= 0 && anchor < len(m.commitHistory) {
+		// The log lists newer commits first
+		newest := m.commitHistory[min(anchor, m.cursor)]
+		oldest := m.commitHistory[max(anchor, m.cursor)]
+		if len(oldest.Parents) == 0 {
+			m.errorMessage = "Cannot squash a range that starts at the root commit"
+			return m, nil
+		}
+		mode = llm.ModeSquash
+		revRange = oldest.Hash + "^.." + newest.Hash
+		label = oldest.ShortHash + "^.." + newest.ShortHash
+	} else {
+		commit := m.commitHistory[m.cursor]
+		if len(commit.Parents) < 2 {
+			m.errorMessage = "Mark a range of commits with v, or select a merge commit"
+			return m, nil
+		}
+		mode = llm.ModeMerge
+		revRange = commit.Parents[0] + ".." + commit.Parents[1]
+		label = git.ShortHash(commit.Parents[0]) + ".." + git.ShortHash(commit.Parents[1])
+		extraContext = "Original merge commit subject: " + commit.Subject
+	}
+
+	m.loading = true
+	m.loadingMessage = fmt.Sprintf("Generating %s message for %s...", mode, label)
+	return m, func() tea.Msg {
+		summary, err := m.repo.GetRangeSummary(revRange)
+		if err != nil {
+			return errorMsg{error: fmt.Sprintf("Failed to read %s: %v", label, err)}
+		}
+
+		request := &llm.CommitMessageRequest{
+			Mode:              mode,
+			Diff:              strings.ReplaceAll(summary.Describe(), revRange, label),
+			Language:          m.config.LLM.Language,
+			AdditionalContext: extraContext,
+			MaxTokens:         m.config.LLM.OpenAI.MaxTokens,
+			Temperature:       m.config.LLM.OpenAI.Temperature,
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		response, err := m.llmClient.GenerateCommitMessage(ctx, request, "")
+		if err != nil {
+			return errorMsg{error: fmt.Sprintf("Failed to generate %s message: %v", mode, err)}
+		}
+
+		logger.LogUIAction("range_message_generated", map[string]interface{}{
+			"mode":    string(mode),
+			"range":   revRange,
+			"commits": len(summary.Commits),
+		})
+
+		return rangeMessageGeneratedMsg{mode: mode, label: label, message: formatCommitMessage(response.Message)}
+	}
+}
+
+// showRangeMessage shows a generated squash or merge message and offers to
+// copy it to the clipboard
+func (m *Model) showRangeMessage(msg rangeMessageGeneratedMsg) {
+	m.loading = false
+	m.logViewState.rangeAnchor = -1
+
+	title := fmt.Sprintf("Squash message for %s", msg.label)
+	if msg.mode == llm.ModeMerge {
+		title = fmt.Sprintf("Merge message for %s", msg.label)
+	}
+	m.modal = NewConfirmModal("copy_range_message", title, "Copy this message to the clipboard?",
+		strings.Split(msg.message, "\n"), m.copyToClipboard(string(msg.mode)+" message", msg.message))
+}
@@ -0,0 +1,131 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/mopemope/git-rovo/internal/llm"
+)
+
+// setupRangeRepoTest creates a history where a feature branch with two
+// commits is merged into main, and loads it into the log view
+func setupRangeRepoTest(t *testing.T) *Model {
+	t.Helper()
+
+	model := setupMainViewTest(t)
+	dir, run := setupGitRepo(t)
+	run("commit", "-q", "--allow-empty", "-m", "Initial commit")
+	run("switch", "-q", "-c", "feature")
+	run("commit", "-q", "--allow-empty", "-m", "Add parser", "-m", "Handles the basic grammar.")
+	run("commit", "-q", "--allow-empty", "-m", "Add lexer")
+	run("switch", "-q", "main")
+	run("commit", "-q", "--allow-empty", "-m", "Main work")
+	run("merge", "-q", "--no-ff", "-m", "Merge branch 'feature'", "feature")
+	openGitRepo(t, model, dir)
+	model.width = 120
+	model.height = 40
+	model.currentView = ViewModeLog
+	model.Update(model.refreshCommitHistory()())
+	if len(model.commitHistory) != 5 {
+		t.Fatalf("Expected 5 commits, got %d", len(model.commitHistory))
+	}
+	return model
+}
+
+func TestSquashMessageForRange(t *testing.T) {
+	model := setupRangeRepoTest(t)
+	provider := useScriptedLLM(t, model, "feat: add parser and lexer\n\n- Parse the basic grammar")
+	copied := ""
+	model.copyText = func(text string) (string, error) {
+		copied = text
+		return "test", nil
+	}
+
+	parser, lexer := commitIndex(t, model, "Add parser"), commitIndex(t, model, "Add lexer")
+	model.cursor = parser
+	model.executeAction("mark_commit_range")
+	model.cursor = lexer
+	if !model.isInCommitRange(parser) || !model.isInCommitRange(lexer) || model.isInCommitRange(commitIndex(t, model, "Main work")) {
+		t.Error("Expected the range to span the marked commits")
+	}
+	if !contains(model.renderEnhancedLogView(), "~ "+model.commitHistory[lexer].ShortHash) {
+		t.Error("Expected commits in the range to be marked")
+	}
+
+	_, cmd := model.executeAction("squash_message")
+	if !model.loading {
+		t.Error("Expected loading state while the message is generated")
+	}
+	model.Update(cmd())
+
+	if len(provider.requests) != 1 || provider.requests[0].Mode != llm.ModeSquash {
+		t.Fatalf("Expected one squash request, got %+v", provider.requests)
+	}
+	diff := provider.requests[0].Diff
+	first, second := strings.Index(diff, "Add parser\n    Handles the basic grammar."), strings.Index(diff, "Add lexer")
+	if first < 0 || second < first || contains(diff, "Main work") || contains(diff, model.commitHistory[parser].Hash) {
+		t.Errorf("Expected the two feature commits oldest first with short hashes, got %q", diff)
+	}
+
+	if model.modal == nil || !contains(model.modal.title, "Squash message for "+model.commitHistory[parser].ShortHash+"^..") {
+		t.Fatalf("Expected the message in a modal, got %+v", model.modal)
+	}
+	if model.logViewState.rangeAnchor != -1 || model.loading {
+		t.Error("Expected the range selection to be cleared")
+	}
+
+	_, cmd = model.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	model.Update(cmd())
+	if copied != "feat: add parser and lexer\n\n- Parse the basic grammar" {
+		t.Errorf("Expected the message to be copied, got %q", copied)
+	}
+}
+
+func TestMergeMessageForMergeCommit(t *testing.T) {
+	model := setupRangeRepoTest(t)
+	provider := useScriptedLLM(t, model, "feat: merge parser work")
+
+	model.cursor = commitIndex(t, model, "Main work")
+	model.executeAction("squash_message")
+	if !contains(model.errorMessage, "Mark a range") {
+		t.Errorf("Expected error for a single regular commit, got %q", model.errorMessage)
+	}
+
+	model.cursor = commitIndex(t, model, "Merge branch 'feature'")
+	_, cmd := model.executeAction("squash_message")
+	model.Update(cmd())
+
+	if len(provider.requests) != 1 || provider.requests[0].Mode != llm.ModeMerge {
+		t.Fatalf("Expected one merge request, got %+v", provider.requests)
+	}
+	request := provider.requests[0]
+	if !contains(request.Diff, "Add lexer") || contains(request.Diff, "Main work") || !contains(request.AdditionalContext, "Merge branch 'feature'") {
+		t.Errorf("Unexpected merge request %+v", request)
+	}
+	if model.modal == nil || !contains(model.modal.title, "Merge message") {
+		t.Errorf("Expected the merge message in a modal, got %+v", model.modal)
+	}
+
+	// A range starting at the root commit has no parent to squash onto
+	model.modal = nil
+	model.cursor = commitIndex(t, model, "Initial commit")
+	model.executeAction("mark_commit_range")
+	model.cursor = commitIndex(t, model, "Add parser")
+	model.executeAction("squash_message")
+	if !contains(model.errorMessage, "root commit") {
+		t.Errorf("Expected root commit error, got %q", model.errorMessage)
+	}
+}
+
+// commitIndex returns the log position of the commit with the given subject
+func commitIndex(t *testing.T, model *Model, subject string) int {
+	t.Helper()
+	for i, commit := range model.commitHistory {
+		if commit.Subject == subject {
+			return i
+		}
+	}
+	t.Fatalf("Commit %q not found", subject)
+	return -1
+}
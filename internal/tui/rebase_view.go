@@ -0,0 +1,355 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/mopemope/git-rovo/internal/git"
+	"github.com/mopemope/git-rovo/internal/llm"
+	"github.com/mopemope/git-rovo/internal/logger"
+)
+
+// RebasePlan is the todo list of an interactive rebase planned in the rebase
+// view, oldest commit first as git runs it
+type RebasePlan struct {
+	base    git.CommitInfo
+	entries []git.RebaseTodoEntry
+}
+
+type rebasePlanLoadedMsg struct {
+	base    git.CommitInfo
+	entries []git.RebaseTodoEntry
+}
+
+type rebaseMessagesGeneratedMsg struct {
+	messages map[string]string // New messages by commit hash
+}
+
+type rebaseFinishedMsg struct {
+	message string
+	err     error
+	stopped bool // The rebase stopped on a conflict and is still in progress
+}
+
+// openRebasePlan loads the commits after the selected commit into a new
+// rebase plan
+func (m *Model) openRebasePlan() (tea.Model, tea.Cmd) {
+	if m.cursor >= len(m.commitHistory) {
+		return m, nil
+	}
+	if m.repoState.InProgress() {
+		m.errorMessage = fmt.Sprintf("Finish or abort the %s in progress first", m.repoState.Operation)
+		return m, nil
+	}
+
+	base := m.commitHistory[m.cursor]
+	m.logViewState.selectedCommit = m.cursor
+	return m, func() tea.Msg {
+		entries, err := m.repo.GetRebaseTodo(base.Hash)
+		if err != nil {
+			return errorMsg{error: fmt.Sprintf("Cannot rebase onto %s: %v", base.ShortHash, err)}
+		}
+		return rebasePlanLoadedMsg{base: base, entries: entries}
+	}
+}
+
+// showRebasePlan opens the rebase view with a loaded plan
+func (m *Model) showRebasePlan(msg rebasePlanLoadedMsg) {
+	m.rebasePlan = &RebasePlan{base: msg.base, entries: msg.entries}
+	m.switchView(ViewModeRebase)
+	m.statusMessage = fmt.Sprintf("Planning rebase of %d commits onto %s", len(msg.entries), msg.base.ShortHash)
+}
+
+// rebaseEntries returns the todo list of the planned rebase
+func (m *Model) rebaseEntries() []git.RebaseTodoEntry {
+	if m.rebasePlan == nil {
+		return nil
+	}
+	return m.rebasePlan.entries
+}
+
+// selectedRebaseEntry returns the todo entry under the cursor
+func (m *Model) selectedRebaseEntry() *git.RebaseTodoEntry {
+	if m.cursor >= len(m.rebaseEntries()) {
+		return nil
+	}
+	return &m.rebasePlan.entries[m.cursor]
+}
+
+// setRebaseAction changes the action of the selected entry
+func (m *Model) setRebaseAction(action git.RebaseAction) tea.Cmd {
+	entry := m.selectedRebaseEntry()
+	if entry == nil {
+		return nil
+	}
+	entry.Action = action
+	if action == git.RebaseReword && entry.Message == "" {
+		m.statusMessage = "Press e to write the new message or g to generate it"
+	}
+	return nil
+}
+
+// moveRebaseEntry moves the selected entry up or down the todo list
+func (m *Model) moveRebaseEntry(delta int) tea.Cmd {
+	if m.rebasePlan == nil {
+		return nil
+	}
+	target := m.cursor + delta
+	if target < 0 || target >= len(m.rebasePlan.entries) {
+		return nil
+	}
+	entries := m.rebasePlan.entries
+	entries[m.cursor], entries[target] = entries[target], entries[m.cursor]
+	m.cursor = target
+	return nil
+}
+
+// openRebaseMessagePrompt edits the new message of the selected entry and
+// marks it for rewording
+func (m *Model) openRebaseMessagePrompt() tea.Cmd {
+	entry := m.selectedRebaseEntry()
+	if entry == nil {
+		return nil
+	}
+
+	message := entry.Message
+	if message == "" {
+		original, err := m.repo.GetCommitMessage(entry.Hash)
+		if err != nil {
+			m.errorMessage = err.Error()
+			return nil
+		}
+		message = original
+	}
+
+	hash := entry.Hash
+	m.openMessagePrompt("Reword "+git.ShortHash(hash), message, func(message string) tea.Cmd {
+		m.setRebaseMessages(map[string]string{hash: message})
+		return nil
+	})
+	return nil
+}
+
+// generateRebaseMessages generates the new messages of reworded commits from
+// their diffs: the selected entry, or with all set every reworded entry
+// without a message
+func (m *Model) generateRebaseMessages(all bool) (tea.Model, tea.Cmd) {
+	if m.rebasePlan == nil || m.llmClient == nil {
+		return m, nil
+	}
+
+	var hashes []string
+	if all {
+		for _, entry := range m.rebasePlan.entries {
+			if entry.Action == git.RebaseReword && entry.Message == "" {
+				hashes = append(hashes, entry.Hash)
+			}
+		}
+		if len(hashes) == 0 {
+			m.statusMessage = "No reworded commits without a message"
+			return m, nil
+		}
+	} else if entry := m.selectedRebaseEntry(); entry != nil {
+		hashes = append(hashes, entry.Hash)
+	}
+	if len(hashes) == 0 {
+		return m, nil
+	}
+
+	m.loading = true
+	m.loadingMessage = fmt.Sprintf("Generating messages for %d commits...", len(hashes))
+	return m, func() tea.Msg {
+		messages := make(map[string]string, len(hashes))
+		for _, hash := range hashes {
+			message, err := m.generateMessageForCommit(hash)
+			if err != nil {
+				return errorMsg{error: fmt.Sprintf("Failed to generate message for %s: %v", git.ShortHash(hash), err)}
+			}
+			messages[hash] = message
+		}
+
+		logger.LogUIAction("rebase_messages_generated", map[string]interface{}{
+			"commits": len(messages),
+		})
+		return rebaseMessagesGeneratedMsg{messages: messages}
+	}
+}
+
+// generateMessageForCommit asks the LLM for a new message of an existing
+// commit, based on its diff as shown by git show. The trailers of the
+// current message, such as Signed-off-by, are kept.
+func (m *Model) generateMessageForCommit(hash string) (string, error) {
+	output, err := m.repo.RunGitCommand("show", "--no-color", "--format=", hash)
+	if err != nil {
+		return "", err
+	}
+	original, err := m.repo.GetCommitMessage(hash)
+	if err != nil {
+		return "", err
+	}
+	details, err := m.repo.GetCommitDetails(hash)
+	if err != nil {
+		return "", err
+	}
+
+	request := &llm.CommitMessageRequest{
+		Diff:              output,
+		Language:          m.config.LLM.Language,
+		AdditionalContext: "Current commit message:\n" + original,
+		MaxTokens:         m.config.LLM.OpenAI.MaxTokens,
+		Temperature:       m.config.LLM.OpenAI.Temperature,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	response, err := m.llmClient.GenerateCommitMessage(ctx, request, "")
+	if err != nil {
+		return "", err
+	}
+	return m.repo.AddTrailers(formatCommitMessage(response.Message), details.Trailers)
+}
+
+// setRebaseMessages sets the new messages of entries and marks them for
+// rewording
+func (m *Model) setRebaseMessages(messages map[string]string) {
+	m.loading = false
+	if m.rebasePlan == nil {
+		return
+	}
+	for i, entry := range m.rebasePlan.entries {
+		if message, ok := messages[entry.Hash]; ok {
+			m.rebasePlan.entries[i].Action = git.RebaseReword
+			m.rebasePlan.entries[i].Message = message
+		}
+	}
+	m.statusMessage = fmt.Sprintf("Set new message of %d commits", len(messages))
+}
+
+// runRebasePlan runs the planned rebase after confirmation
+func (m *Model) runRebasePlan() (tea.Model, tea.Cmd) {
+	if m.rebasePlan == nil {
+		return m, nil
+	}
+	if err := git.ValidateRebaseTodo(m.rebasePlan.entries); err != nil {
+		m.errorMessage = err.Error()
+		return m, nil
+	}
+
+	base := m.rebasePlan.base
+	entries := append([]git.RebaseTodoEntry(nil), m.rebasePlan.entries...)
+	cmd := func() tea.Msg {
+		err := m.repo.RunInteractiveRebase(base.Hash, entries, git.CommitOptions{Sign: m.signMode})
+		if err == nil {
+			logger.LogUIAction("rebase_completed", map[string]interface{}{
+				"base":    base.Hash,
+				"commits": len(entries),
+			})
+			return rebaseFinishedMsg{message: "Rebase completed"}
+		}
+		state, stateErr := m.repo.GetRepositoryState()
+		return rebaseFinishedMsg{err: err, stopped: stateErr == nil && state.InProgress()}
+	}
+	return m.withConfirmation("run_rebase", cmd, m.rebaseConfirmation)
+}
+
+// rebaseConfirmation describes the plan before it rewrites history
+func (m *Model) rebaseConfirmation() *ConfirmModal {
+	var details []string
+	for _, entry := range m.rebasePlan.entries {
+		details = append(details, describeRebaseEntry(entry))
+	}
+	title := fmt.Sprintf("Rebase %d commits onto %s", len(m.rebasePlan.entries), m.rebasePlan.base.ShortHash)
+	return NewConfirmModal("run_rebase", title, "Rewrite these commits?", details, nil)
+}
+
+// finishRebase leaves the rebase view once git rebase returned. A rebase
+// stopped on a conflict continues in the status view.
+func (m *Model) finishRebase(msg rebaseFinishedMsg) tea.Cmd {
+	m.loading = false
+	switch {
+	case msg.stopped:
+		m.rebasePlan = nil
+		m.switchView(ViewModeStatus)
+		m.errorMessage = fmt.Sprintf("%v; resolve the conflicts, then press M to continue or X to abort", msg.err)
+	case msg.err != nil:
+		m.errorMessage = msg.err.Error()
+		return nil
+	default:
+		if m.currentView == ViewModeRebase {
+			m.rebasePlan = nil
+			m.returnToLog()
+		}
+		m.statusMessage = msg.message
+	}
+	return tea.Batch(
+		m.refreshStatus(),
+		m.refreshCommitHistory(),
+		m.refreshBranches(),
+		m.refreshUpstreamStatus(),
+	)
+}
+
+// cancelRebasePlan discards the plan and returns to the log
+func (m *Model) cancelRebasePlan() *Model {
+	m.rebasePlan = nil
+	m.returnToLog()
+	m.statusMessage = "Rebase cancelled"
+	return m
+}
+
+// describeRebaseEntry renders an entry as a todo line, with the new subject
+// of reworded commits
+func describeRebaseEntry(entry git.RebaseTodoEntry) string {
+	line := fmt.Sprintf("%-6s %s %s", entry.Action, git.ShortHash(entry.Hash), entry.Subject)
+	if entry.Action == git.RebaseReword {
+		subject, _, _ := strings.Cut(entry.Message, "\n")
+		if subject == "" {
+			subject = "(no message yet)"
+		}
+		line += " → " + subject
+	}
+	return line
+}
+
+// renderRebaseView renders the todo list of the planned rebase
+func (m *Model) renderRebaseView() string {
+	var content strings.Builder
+
+	if m.rebasePlan == nil {
+		content.WriteString(m.styles.Info.Render("No rebase planned; select a base commit in the log view and press i"))
+		return content.String()
+	}
+
+	header := fmt.Sprintf("Rebase onto %s %s - %d commits, oldest first", m.rebasePlan.base.ShortHash, m.rebasePlan.base.Subject, len(m.rebasePlan.entries))
+	content.WriteString(m.styles.Header.Width(m.width).Render(header))
+	content.WriteString("\n")
+
+	maxVisible := max(m.getMaxVisibleLines(), 1)
+	start := 0
+	if m.cursor >= maxVisible {
+		start = m.cursor - maxVisible + 1
+	}
+	for i := start; i < min(start+maxVisible, len(m.rebasePlan.entries)); i++ {
+		entry := m.rebasePlan.entries[i]
+		line := describeRebaseEntry(entry)
+		switch {
+		case i == m.cursor:
+			line = "> " + m.styles.Selected.Render(line)
+		case entry.Action == git.RebaseDrop:
+			line = "  " + m.styles.Deleted.Render(line)
+		case entry.Action == git.RebaseReword && entry.Message == "":
+			line = "  " + m.styles.Warning.Render(line)
+		case entry.Action != git.RebasePick:
+			line = "  " + m.styles.Info.Render(line)
+		default:
+			line = "  " + m.styles.Unselected.Render(line)
+		}
+		content.WriteString(line)
+		content.WriteString("\n")
+	}
+	return content.String()
+}
@@ -0,0 +1,182 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/mopemope/git-rovo/internal/git"
+)
+
+// setupRebaseRepoTest creates a linear history of four commits after the
+// initial one and loads it into the log view
+func setupRebaseRepoTest(t *testing.T) *Model {
+	t.Helper()
+
+	model := setupMainViewTest(t)
+	dir, run := setupGitRepo(t)
+	run("commit", "-q", "--allow-empty", "-m", "Initial commit")
+	for _, name := range []string{"a", "b", "c", "d"} {
+		writeRepoFile(t, dir, name+".txt", name+"\n")
+		run("add", name+".txt")
+		run("commit", "-q", "-m", "Add "+name)
+	}
+	openGitRepo(t, model, dir)
+	model.width = 120
+	model.height = 40
+	model.currentView = ViewModeLog
+	model.Update(model.refreshCommitHistory()())
+	return model
+}
+
+// openTestRebasePlan opens the rebase view onto the commit with subject
+func openTestRebasePlan(t *testing.T, model *Model, subject string) {
+	t.Helper()
+	model.cursor = commitIndex(t, model, subject)
+	_, cmd := model.executeAction("rebase_interactive")
+	model.Update(cmd())
+	if model.currentView != ViewModeRebase || model.rebasePlan == nil {
+		t.Fatalf("Expected the rebase view, got %s (%s)", viewModeToString(model.currentView), model.errorMessage)
+	}
+}
+
+func TestRebasePlanEditing(t *testing.T) {
+	model := setupRebaseRepoTest(t)
+	openTestRebasePlan(t, model, "Initial commit")
+
+	var subjects []string
+	for _, entry := range model.rebasePlan.entries {
+		subjects = append(subjects, entry.Subject)
+	}
+	if strings.Join(subjects, ",") != "Add a,Add b,Add c,Add d" {
+		t.Fatalf("Expected commits oldest first, got %v", subjects)
+	}
+
+	// Move d up before c and fold c into it
+	model.executeAction("nav_end")
+	model.executeAction("rebase_move_up")
+	if model.cursor != 2 || model.rebasePlan.entries[2].Subject != "Add d" {
+		t.Fatalf("Expected d to move up, got %+v at %d", model.rebasePlan.entries, model.cursor)
+	}
+	model.executeAction("nav_down")
+	model.executeAction("rebase_fixup")
+	model.executeAction("nav_home")
+	model.executeAction("rebase_drop")
+	model.executeAction("nav_down")
+	model.executeAction("rebase_reword")
+
+	view := model.renderRebaseView()
+	for _, want := range []string{"drop   ", "fixup  ", "reword", "(no message yet)"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q in the rebase view:\n%s", want, view)
+		}
+	}
+	if !strings.Contains(model.keyBindingManager.GetFooterText(ViewModeRebase), "r:reword") {
+		t.Error("Expected rebase actions in the footer")
+	}
+
+	// A reworded commit needs a message before the rebase runs
+	if _, cmd := model.executeAction("run_rebase"); cmd != nil || !strings.Contains(model.errorMessage, "no new message") {
+		t.Errorf("Expected the plan to be refused, got %q", model.errorMessage)
+	}
+
+	model.executeAction("rebase_edit_message")
+	if model.prompt == nil || model.prompt.fields[0].value != "Add b" {
+		t.Fatalf("Expected a prompt with the current message, got %+v", model.prompt)
+	}
+	model.prompt.fields[0].value = "feat: add b"
+	model.prompt.fields[1].value = "First line\nSecond line"
+	model.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := model.rebasePlan.entries[1].Message; got != "feat: add b\n\nFirst line\nSecond line" {
+		t.Errorf("Unexpected message %q", got)
+	}
+
+	_, cmd := model.executeAction("run_rebase")
+	if model.modal != nil {
+		_, cmd = model.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	}
+	model.Update(cmd())
+
+	if model.currentView != ViewModeLog || model.rebasePlan != nil || model.errorMessage != "" {
+		t.Fatalf("Expected to return to the log, got %s (%s)", viewModeToString(model.currentView), model.errorMessage)
+	}
+	log, _ := model.repo.RunGitCommand("log", "--format=%s")
+	if log != "Add d\nfeat: add b\nInitial commit" {
+		t.Errorf("Unexpected history %q", log)
+	}
+	if files, _ := model.repo.RunGitCommand("show", "--name-only", "--format=", "HEAD"); files != "c.txt\nd.txt" {
+		t.Errorf("Expected c folded into d, got %q", files)
+	}
+}
+
+func TestRebaseGeneratedMessages(t *testing.T) {
+	model := setupRebaseRepoTest(t)
+	provider := useScriptedLLM(t, model, "feat: add the c file")
+	openTestRebasePlan(t, model, "Add a")
+
+	model.executeAction("nav_down")
+	model.executeAction("rebase_reword")
+	_, cmd := model.executeAction("rebase_generate_all")
+	if !model.loading {
+		t.Error("Expected loading state while messages are generated")
+	}
+	model.Update(cmd())
+
+	if len(provider.requests) != 1 {
+		t.Fatalf("Expected one request, got %d", len(provider.requests))
+	}
+	request := provider.requests[0]
+	if !strings.Contains(request.Diff, "+c") || !strings.Contains(request.AdditionalContext, "Add c") {
+		t.Errorf("Expected the diff and message of c, got %+v", request)
+	}
+	entry := model.rebasePlan.entries[1]
+	if entry.Action != git.RebaseReword || entry.Message != "feat: add the c file" {
+		t.Errorf("Unexpected entry %+v", entry)
+	}
+
+	model.executeAction("cancel_rebase")
+	if model.currentView != ViewModeLog || model.rebasePlan != nil {
+		t.Error("Expected cancel to return to the log")
+	}
+}
+
+func TestRebaseStoppedOnConflict(t *testing.T) {
+	model := setupRebaseRepoTest(t)
+	dir := model.repo.GetWorkDir()
+	writeRepoFile(t, dir, "a.txt", "changed\n")
+	if _, err := model.repo.RunGitCommand("commit", "-qam", "Change a"); err != nil {
+		t.Fatal(err)
+	}
+	model.Update(model.refreshCommitHistory()())
+	openTestRebasePlan(t, model, "Add a")
+
+	// Moving the change of a before b, c and d applies cleanly; dropping the
+	// commit that adds a makes it conflict
+	model.executeAction("nav_end")
+	for range 3 {
+		model.executeAction("rebase_move_up")
+	}
+	_, cmd := model.executeAction("run_rebase")
+	if model.modal != nil {
+		_, cmd = model.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	}
+	model.Update(cmd())
+	if model.currentView != ViewModeLog {
+		t.Fatalf("Expected the reorder to succeed, got %q", model.errorMessage)
+	}
+
+	openTestRebasePlan(t, model, "Initial commit")
+	model.executeAction("rebase_drop")
+	_, cmd = model.executeAction("run_rebase")
+	if model.modal != nil {
+		_, cmd = model.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	}
+	model.Update(cmd())
+
+	if model.currentView != ViewModeStatus || !strings.Contains(model.errorMessage, "press M to continue") {
+		t.Errorf("Expected the stopped rebase in the status view, got %s (%s)", viewModeToString(model.currentView), model.errorMessage)
+	}
+	if state, _ := model.repo.GetRepositoryState(); state.Operation != git.OperationRebase {
+		t.Errorf("Expected a rebase in progress, got %+v", state)
+	}
+}
@@ -0,0 +1,121 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/mopemope/git-rovo/internal/git"
+	"github.com/mopemope/git-rovo/internal/logger"
+)
+
+// refreshUpstreamStatus reloads the ahead/behind counts shown in the header.
+// Failures are ignored; the header then simply shows no tracking information.
+func (m *Model) refreshUpstreamStatus() tea.Cmd {
+	return func() tea.Msg {
+		status, err := m.repo.GetUpstreamStatus()
+		if err != nil {
+			logger.Debug("Failed to get upstream status", "error", err)
+		}
+		return upstreamStatusMsg{status: status}
+	}
+}
+
+// runRemoteOperation runs a push, pull or fetch in the background. Progress
+// lines are delivered as remoteProgressMsg until a remoteOperationDoneMsg
+// reports the result.
+func (m *Model) runRemoteOperation(operation, doneMessage string, run func(git.ProgressFunc) error) tea.Cmd {
+	return func() tea.Msg {
+		events := make(chan tea.Msg, 16)
+		go func() {
+			defer close(events)
+			events <- remoteProgressMsg{line: operation + "...", events: events}
+			err := run(func(line string) {
+				events <- remoteProgressMsg{line: operation + ": " + line, events: events}
+			})
+
+			logger.LogUIAction("remote_operation", map[string]interface{}{
+				"operation": operation,
+				"success":   err == nil,
+			})
+
+			events <- remoteOperationDoneMsg{operation: operation, message: doneMessage, err: err}
+		}()
+
+		return <-events
+	}
+}
+
+// waitForRemoteEvent waits for the next event of a running remote operation
+func waitForRemoteEvent(events <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-events
+		if !ok {
+			return nil
+		}
+		return msg
+	}
+}
+
+// pushCurrentBranch pushes the current branch. Branches without an upstream
+// are offered to be pushed to a remote and tracked from there.
+func (m *Model) pushCurrentBranch() (tea.Model, tea.Cmd) {
+	status, err := m.repo.GetUpstreamStatus()
+	if err != nil {
+		m.errorMessage = fmt.Sprintf("Failed to get upstream: %v", err)
+		return m, nil
+	}
+	if status.Upstream != "" {
+		return m, m.runRemoteOperation("Pushing", "Pushed to "+status.Upstream, func(progress git.ProgressFunc) error {
+			return m.repo.Push(git.PushOptions{}, progress)
+		})
+	}
+
+	branch, err := m.repo.GetCurrentBranch()
+	if err != nil || branch == "" || branch == "HEAD" {
+		m.errorMessage = "Cannot push: not on a branch"
+		return m, nil
+	}
+	remotes, err := m.repo.GetRemotes()
+	if err != nil {
+		m.errorMessage = fmt.Sprintf("Failed to list remotes: %v", err)
+		return m, nil
+	}
+	if len(remotes) == 0 {
+		m.errorMessage = "Cannot push: no remote configured"
+		return m, nil
+	}
+
+	remote := remotes[0]
+	if slices.Contains(remotes, "origin") {
+		remote = "origin"
+	}
+
+	opts := git.PushOptions{Remote: remote, Branch: branch, SetUpstream: true}
+	details := []string{"git push --set-upstream " + remote + " " + branch}
+	m.modal = NewConfirmModal("push", "Push "+branch+"?",
+		fmt.Sprintf("%s has no upstream. Push it to %s and track %s/%s?", branch, remote, remote, branch),
+		details, m.runRemoteOperation("Pushing", fmt.Sprintf("Pushed %s to %s/%s", branch, remote, branch), func(progress git.ProgressFunc) error {
+			return m.repo.Push(opts, progress)
+		}))
+	return m, nil
+}
+
+// pullCurrentBranch pulls the upstream of the current branch
+func (m *Model) pullCurrentBranch() tea.Cmd {
+	return m.runRemoteOperation("Pulling", "Pulled from upstream", func(progress git.ProgressFunc) error {
+		return m.repo.Pull(progress)
+	})
+}
+
+// fetchRemote fetches from the default remote
+func (m *Model) fetchRemote() tea.Cmd {
+	return m.runRemoteOperation("Fetching", "Fetched from remote", func(progress git.ProgressFunc) error {
+		return m.repo.Fetch(progress)
+	})
+}
+
+// describeUpstreamStatus describes the upstream and ahead/behind counts for the header
+func describeUpstreamStatus(status git.UpstreamStatus) string {
+	return describeTracking(git.BranchInfo{Upstream: status.Upstream, Ahead: status.Ahead, Behind: status.Behind})
+}
@@ -0,0 +1,126 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/mopemope/git-rovo/internal/git"
+)
+
+// addBareRemote adds an empty bare repository as the origin remote
+func addBareRemote(t *testing.T, model *Model) {
+	t.Helper()
+	remoteDir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q", "--bare", remoteDir},
+		{"remote", "add", "origin", remoteDir},
+	} {
+		if output, err := model.repo.RunGitCommand(args...); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+}
+
+// runRemoteEvents feeds the events of a remote operation into the model until
+// it is done and returns the progress lines that were shown
+func runRemoteEvents(t *testing.T, model *Model, cmd tea.Cmd) (remoteOperationDoneMsg, []string) {
+	t.Helper()
+
+	var progress []string
+	for cmd != nil {
+		switch msg := cmd().(type) {
+		case remoteProgressMsg:
+			_, cmd = model.Update(msg)
+			progress = append(progress, model.remoteProgress)
+			if !model.loading {
+				t.Error("Expected loading state while the operation runs")
+			}
+		case remoteOperationDoneMsg:
+			model.Update(msg)
+			return msg, progress
+		default:
+			t.Fatalf("Unexpected message %#v", msg)
+		}
+	}
+	t.Fatal("Remote operation ended without a result")
+	return remoteOperationDoneMsg{}, nil
+}
+
+func TestPushOffersSetUpstream(t *testing.T) {
+	model := setupBranchRepoTest(t)
+	model.width = 120
+	model.height = 40
+	model.currentView = ViewModeStatus
+	addBareRemote(t, model)
+
+	model.executeAction("push")
+	if model.modal == nil || !contains(model.modal.message, "no upstream") {
+		t.Fatalf("Expected set-upstream offer, got %+v", model.modal)
+	}
+	if !contains(model.modal.details[0], "--set-upstream origin main") {
+		t.Errorf("Unexpected modal details %v", model.modal.details)
+	}
+
+	_, cmd := model.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	done, progress := runRemoteEvents(t, model, cmd)
+	if done.err != nil {
+		t.Fatalf("Expected push to succeed, got %v", done.err)
+	}
+	if len(progress) == 0 || progress[0] != "Pushing..." {
+		t.Errorf("Expected progress lines, got %v", progress)
+	}
+	if model.loading || model.remoteProgress != "" || !contains(model.statusMessage, "origin/main") {
+		t.Errorf("Unexpected state after push: loading=%v progress=%q status=%q", model.loading, model.remoteProgress, model.statusMessage)
+	}
+
+	model.Update(model.refreshUpstreamStatus()())
+	if model.upstream.Upstream != "origin/main" {
+		t.Errorf("Expected upstream origin/main, got %+v", model.upstream)
+	}
+	if !contains(model.renderHeader(), "origin/main up to date") {
+		t.Error("Expected tracking status in the header")
+	}
+
+	// With an upstream, push runs without asking
+	_, cmd = model.executeAction("push")
+	if model.modal != nil {
+		t.Fatal("Expected no modal when an upstream is configured")
+	}
+	if done, _ := runRemoteEvents(t, model, cmd); done.err != nil {
+		t.Errorf("Expected push to succeed, got %v", done.err)
+	}
+}
+
+func TestRemoteOperationFailure(t *testing.T) {
+	model := setupBranchRepoTest(t)
+	model.currentView = ViewModeStatus
+
+	model.executeAction("push")
+	if !contains(model.errorMessage, "no remote") {
+		t.Errorf("Expected no remote error, got %q", model.errorMessage)
+	}
+
+	_, cmd := model.executeAction("pull")
+	done, _ := runRemoteEvents(t, model, cmd)
+	if done.err == nil {
+		t.Fatal("Expected pull without upstream to fail")
+	}
+	if !contains(model.errorMessage, "Pulling failed") || model.loading {
+		t.Errorf("Unexpected state after failure: error=%q loading=%v", model.errorMessage, model.loading)
+	}
+}
+
+func TestHeaderShowsAheadBehind(t *testing.T) {
+	model := setupBranchRepoTest(t)
+	model.width = 120
+	model.height = 40
+	model.upstream = git.UpstreamStatus{Upstream: "origin/main", Ahead: 2, Behind: 1}
+	model.remoteProgress = "Pushing: Writing objects:  50% (1/2)"
+
+	header := model.renderHeader()
+	for _, want := range []string{"origin/main ↑2 ↓1", "Writing objects:  50%"} {
+		if !contains(header, want) {
+			t.Errorf("Expected header to contain %q", want)
+		}
+	}
+}
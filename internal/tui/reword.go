@@ -0,0 +1,122 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/mopemope/git-rovo/internal/git"
+	"github.com/mopemope/git-rovo/internal/logger"
+)
+
+type rewordReadyMsg struct {
+	commit    git.CommitInfo
+	message   string // Generated message, or the current one when generation failed
+	generated bool
+	upstream  string // Upstream already containing the commit
+}
+
+// openMessagePrompt edits a commit message as a subject and a multi-line body
+func (m *Model) openMessagePrompt(title, message string, onSubmit func(message string) tea.Cmd) {
+	subject, body, _ := strings.Cut(message, "\n")
+	fields := []promptField{
+		{label: "Subject", value: subject},
+		{label: "Body", value: strings.TrimSpace(body), placeholder: "optional", multiline: true},
+	}
+	m.prompt = NewInputPrompt("commit_message", title, fields, func(values []string) tea.Cmd {
+		if values[0] == "" {
+			m.errorMessage = "Commit subject cannot be empty"
+			return nil
+		}
+		message := values[0]
+		if values[1] != "" {
+			message += "\n\n" + values[1]
+		}
+		return onSubmit(message)
+	})
+}
+
+// rewordSelectedCommit regenerates the message of the selected commit from
+// its diff and opens it for editing
+func (m *Model) rewordSelectedCommit() (tea.Model, tea.Cmd) {
+	if m.cursor >= len(m.commitHistory) {
+		return m, nil
+	}
+	if m.repoState.InProgress() {
+		m.errorMessage = fmt.Sprintf("Finish or abort the %s in progress first", m.repoState.Operation)
+		return m, nil
+	}
+
+	commit := m.commitHistory[m.cursor]
+	m.loading = true
+	m.loadingMessage = fmt.Sprintf("Generating a new message for %s...", commit.ShortHash)
+	return m, func() tea.Msg {
+		upstream, err := m.repo.GetUpstreamContaining(commit.Hash)
+		if err != nil {
+			return errorMsg{error: fmt.Sprintf("Failed to check the upstream: %v", err)}
+		}
+
+		msg := rewordReadyMsg{commit: commit, upstream: upstream}
+		if m.llmClient != nil {
+			message, err := m.generateMessageForCommit(commit.Hash)
+			if err == nil {
+				msg.message, msg.generated = message, true
+				return msg
+			}
+			logger.Warn("Failed to generate reword message", "commit", commit.Hash, "error", err)
+		}
+
+		msg.message, err = m.repo.GetCommitMessage(commit.Hash)
+		if err != nil {
+			return errorMsg{error: err.Error()}
+		}
+		return msg
+	}
+}
+
+// showRewordPrompt lets the user edit the new message, then rewords the
+// commit. Commits already on the upstream are only rewritten after a warning.
+func (m *Model) showRewordPrompt(msg rewordReadyMsg) {
+	m.loading = false
+	switch {
+	case msg.upstream != "":
+		m.statusMessage = fmt.Sprintf("%s is already on %s; rewording it requires a force push", msg.commit.ShortHash, msg.upstream)
+	case !msg.generated && m.llmClient != nil:
+		m.statusMessage = "Could not generate a message; editing the current one"
+	}
+
+	title := fmt.Sprintf("Reword %s %s", msg.commit.ShortHash, msg.commit.Subject)
+	m.openMessagePrompt(title, msg.message, func(message string) tea.Cmd {
+		cmd := m.rewordCommit(msg.commit, message)
+		if msg.upstream == "" {
+			return cmd
+		}
+
+		subject, _, _ := strings.Cut(message, "\n")
+		m.modal = NewConfirmModal("reword_pushed", fmt.Sprintf("%s is already on %s", msg.commit.ShortHash, msg.upstream),
+			"Rewording it rewrites published history and requires a force push. Reword anyway?",
+			[]string{"Old: " + msg.commit.Subject, "New: " + subject}, cmd)
+		return nil
+	})
+}
+
+// rewordCommit replaces the message of a commit, amending HEAD or rebasing
+// older commits
+func (m *Model) rewordCommit(commit git.CommitInfo, message string) tea.Cmd {
+	return func() tea.Msg {
+		if err := m.repo.RewordCommit(commit.Hash, message, git.CommitOptions{Sign: m.signMode}); err != nil {
+			if state, stateErr := m.repo.GetRepositoryState(); stateErr == nil && state.InProgress() {
+				return rebaseFinishedMsg{err: err, stopped: true}
+			}
+			return errorMsg{error: fmt.Sprintf("Failed to reword %s: %s", commit.ShortHash, commitErrorMessage(err))}
+		}
+
+		logger.LogUIAction("commit_reworded", map[string]interface{}{
+			"commit":  commit.Hash,
+			"message": message,
+		})
+
+		m.signMode = git.SignDefault
+		return operationCompletedMsg{message: fmt.Sprintf("Reworded %s; press U to undo", commit.ShortHash)}
+	}
+}
@@ -0,0 +1,147 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbletea"
+)
+
+// openTestReword opens the reword prompt of the commit with subject once
+// its message was generated
+func openTestReword(t *testing.T, model *Model, subject string) {
+	t.Helper()
+	model.cursor = commitIndex(t, model, subject)
+	_, cmd := model.executeAction("reword_commit")
+	if cmd == nil || !model.loading {
+		t.Fatalf("Expected the message to be generated (%s)", model.errorMessage)
+	}
+	model.Update(cmd())
+	if model.prompt == nil {
+		t.Fatalf("Expected the message prompt, got %q", model.errorMessage)
+	}
+}
+
+func TestRewordOlderCommit(t *testing.T) {
+	model := setupRebaseRepoTest(t)
+	provider := useScriptedLLM(t, model, "feat: add the b file\n\nNeeded by c.")
+	openTestReword(t, model, "Add b")
+
+	if len(provider.requests) != 1 {
+		t.Fatalf("Expected one request, got %d", len(provider.requests))
+	}
+	request := provider.requests[0]
+	if !strings.Contains(request.Diff, "+b") || strings.Contains(request.Diff, "+c") || !strings.Contains(request.AdditionalContext, "Add b") {
+		t.Errorf("Expected the diff and message of b, got %+v", request)
+	}
+	if model.prompt.fields[0].value != "feat: add the b file" || model.prompt.fields[1].value != "Needed by c." {
+		t.Fatalf("Expected the generated message, got %+v", model.prompt.fields)
+	}
+
+	// Enter breaks lines in the body, which keeps literal backslashes
+	model.handleKeyPress(tea.KeyMsg{Type: tea.KeyTab})
+	model.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	model.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(`Split on "\n".`)})
+	if model.prompt == nil || !strings.Contains(model.renderPrompt(), "ctrl+s: save") {
+		t.Fatal("Expected Enter to stay in the multi-line body")
+	}
+	_, cmd := model.handleKeyPress(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if model.modal != nil {
+		t.Fatal("Expected no warning for an unpushed commit")
+	}
+	model.Update(cmd())
+
+	if model.errorMessage != "" || !strings.Contains(model.statusMessage, "Reworded") {
+		t.Fatalf("Expected the reword to succeed, got %q", model.errorMessage)
+	}
+	log, _ := model.repo.RunGitCommand("log", "--format=%s")
+	if log != "Add d\nAdd c\nfeat: add the b file\nAdd a\nInitial commit" {
+		t.Errorf("Unexpected history %q", log)
+	}
+	if body, _ := model.repo.RunGitCommand("log", "-1", "--format=%b", "HEAD~2"); strings.TrimSpace(body) != "Needed by c.\nSplit on \"\\n\"." {
+		t.Errorf("Unexpected body %q", body)
+	}
+	if files, _ := model.repo.RunGitCommand("show", "--name-only", "--format=", "HEAD"); files != "d.txt" {
+		t.Errorf("Expected the later commits to keep their changes, got %q", files)
+	}
+}
+
+func TestRewordKeepsTrailers(t *testing.T) {
+	model := setupRebaseRepoTest(t)
+	trailers := "Signed-off-by: Test User <test@example.com>\nCo-authored-by: Jane Doe <jane@example.com>\nRefs: #42"
+	if _, err := model.repo.RunGitCommand("commit", "-q", "--amend", "-m", "Add d\n\n"+trailers); err != nil {
+		t.Fatal(err)
+	}
+	model.Update(model.refreshCommitHistory()())
+	useScriptedLLM(t, model, "feat: add the d file\n\nRefs: #42")
+	openTestReword(t, model, "Add d")
+
+	_, cmd := model.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	model.Update(cmd())
+
+	message, _ := model.repo.GetCommitMessage("HEAD")
+	if message != "feat: add the d file\n\nRefs: #42\nSigned-off-by: Test User <test@example.com>\nCo-authored-by: Jane Doe <jane@example.com>" {
+		t.Errorf("Expected the trailers to be kept once, got %q", message)
+	}
+}
+
+func TestRewordHeadKeepsStagedChanges(t *testing.T) {
+	model := setupRebaseRepoTest(t)
+	// Without an LLM the current message is edited
+	model.llmClient = nil
+	openTestReword(t, model, "Add d")
+	if model.prompt.fields[0].value != "Add d" {
+		t.Fatalf("Expected the current message, got %+v", model.prompt.fields)
+	}
+
+	if _, err := model.repo.RunGitCommand("rm", "-q", "--cached", "a.txt"); err != nil {
+		t.Fatal(err)
+	}
+	model.prompt.fields[0].value = "feat: add d"
+	_, cmd := model.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	model.Update(cmd())
+
+	if subject, _ := model.repo.RunGitCommand("log", "-1", "--format=%s"); subject != "feat: add d" {
+		t.Errorf("Expected HEAD to be amended, got %q", subject)
+	}
+	if staged, _ := model.repo.RunGitCommand("diff", "--cached", "--name-only"); staged != "a.txt" {
+		t.Errorf("Expected the staged change to stay staged, got %q", staged)
+	}
+
+	_, cmd = model.executeAction("undo")
+	model.Update(cmd())
+	if subject, _ := model.repo.RunGitCommand("log", "-1", "--format=%s"); subject != "Add d" {
+		t.Errorf("Expected undo to restore the message, got %q", subject)
+	}
+}
+
+func TestRewordPushedCommitWarns(t *testing.T) {
+	model := setupRebaseRepoTest(t)
+	addBareRemote(t, model)
+	if output, err := model.repo.RunGitCommand("push", "-q", "-u", "origin", "main"); err != nil {
+		t.Fatalf("Failed to push: %v\n%s", err, output)
+	}
+	useScriptedLLM(t, model, "feat: add the c file")
+	openTestReword(t, model, "Add c")
+
+	if !strings.Contains(model.statusMessage, "already on origin/main") {
+		t.Errorf("Expected a warning while editing, got %q", model.statusMessage)
+	}
+	model.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	if model.modal == nil || !strings.Contains(model.modal.title, "origin/main") {
+		t.Fatalf("Expected a force push warning, got %+v", model.modal)
+	}
+
+	model.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if subject, _ := model.repo.RunGitCommand("log", "-1", "--format=%s", "HEAD~1"); subject != "Add c" {
+		t.Fatalf("Expected the commit to be kept, got %q", subject)
+	}
+
+	openTestReword(t, model, "Add c")
+	model.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	_, cmd := model.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	model.Update(cmd())
+	if subject, _ := model.repo.RunGitCommand("log", "-1", "--format=%s", "HEAD~1"); subject != "feat: add the c file" {
+		t.Errorf("Expected the commit to be reworded after confirming, got %q", subject)
+	}
+}
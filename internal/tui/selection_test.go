@@ -0,0 +1,130 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/mopemope/git-rovo/internal/git"
+	"github.com/mopemope/git-rovo/internal/logger"
+)
+
+func setupSelectionTest(t *testing.T) *Model {
+	model := setupMainViewTest(t)
+	model.fileStatus = []git.FileStatus{
+		{Path: "staged.go", Status: "M ", Staged: true},
+		{Path: "modified.go", Status: " M", Modified: true},
+		{Path: "other.go", Status: " M", Modified: true},
+		{Path: "new.txt", Status: "??"},
+	}
+	return model
+}
+
+func TestGetTargetFiles(t *testing.T) {
+	model := setupSelectionTest(t)
+	defer func() { _ = logger.Close() }()
+
+	// Without marks the current file is the target
+	model.cursor = 1
+	targets := model.getTargetFiles()
+	if len(targets) != 1 || targets[0].Path != "modified.go" {
+		t.Fatalf("Expected current file as target, got %+v", targets)
+	}
+
+	// With marks, the marked set is the target in display order
+	model.selected["new.txt"] = true
+	model.selected["staged.go"] = true
+	targets = model.getTargetFiles()
+	if len(targets) != 2 {
+		t.Fatalf("Expected 2 marked targets, got %d", len(targets))
+	}
+	if targets[0].Path != "staged.go" || targets[1].Path != "new.txt" {
+		t.Errorf("Expected marked files in display order, got %s, %s", targets[0].Path, targets[1].Path)
+	}
+}
+
+func TestToggleMark(t *testing.T) {
+	model := setupSelectionTest(t)
+	defer func() { _ = logger.Close() }()
+
+	model.cursor = 0
+	model.toggleMark()
+	if !model.selected["staged.go"] {
+		t.Error("Expected staged.go to be marked")
+	}
+	if model.cursor != 1 {
+		t.Errorf("Expected cursor to advance to 1, got %d", model.cursor)
+	}
+
+	model.cursor = 0
+	model.toggleMark()
+	if model.selected["staged.go"] {
+		t.Error("Expected staged.go to be unmarked")
+	}
+}
+
+func TestMarkRange(t *testing.T) {
+	model := setupSelectionTest(t)
+	defer func() { _ = logger.Close() }()
+
+	model.cursor = 3
+	model.markRange()
+	if model.mainViewState.markAnchor != 3 {
+		t.Fatalf("Expected range anchor at 3, got %d", model.mainViewState.markAnchor)
+	}
+	if !model.isInMarkRange(3) || model.isInMarkRange(0) {
+		t.Error("Expected only the anchor to be in range initially")
+	}
+
+	model.cursor = 1
+	model.markRange()
+	if model.mainViewState.markAnchor != -1 {
+		t.Error("Expected range anchor to be cleared")
+	}
+	for _, path := range []string{"modified.go", "other.go", "new.txt"} {
+		if !model.selected[path] {
+			t.Errorf("Expected %s to be marked", path)
+		}
+	}
+	if model.selected["staged.go"] {
+		t.Error("Expected staged.go to stay unmarked")
+	}
+}
+
+func TestSelectAllAndPruneSelection(t *testing.T) {
+	model := setupSelectionTest(t)
+	defer func() { _ = logger.Close() }()
+
+	model.selectAllFiles()
+	if len(model.getMarkedFiles()) != 4 {
+		t.Fatalf("Expected all 4 files to be marked, got %d", len(model.getMarkedFiles()))
+	}
+
+	// Files that disappear from status lose their mark
+	model.fileStatus = model.fileStatus[:2]
+	model.pruneSelection()
+	if len(model.selected) != 2 {
+		t.Errorf("Expected 2 marks after pruning, got %d", len(model.selected))
+	}
+
+	model.clearSelection()
+	if len(model.selected) != 0 {
+		t.Error("Expected selection to be cleared")
+	}
+}
+
+func TestRenderMarkedFileLine(t *testing.T) {
+	model := setupSelectionTest(t)
+	defer func() { _ = logger.Close() }()
+
+	model.cursor = 0
+	model.selected["other.go"] = true
+
+	line := model.renderEnhancedFileStatusLine(model.fileStatus[2], 2)
+	if !contains(line, "*M other.go") {
+		t.Errorf("Expected marked indicator in line, got %q", line)
+	}
+
+	line = model.renderEnhancedFileStatusLine(model.fileStatus[1], 1)
+	if contains(line, "*") {
+		t.Errorf("Expected no marked indicator for unmarked file, got %q", line)
+	}
+}
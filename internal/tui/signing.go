@@ -0,0 +1,74 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/mopemope/git-rovo/internal/git"
+	"github.com/mopemope/git-rovo/internal/logger"
+)
+
+// signingConfigMsg delivers the commit signing configuration shown in the header
+type signingConfigMsg struct {
+	config git.SigningConfig
+}
+
+// refreshSigningConfig reloads commit.gpgsign and gpg.format. Failures are
+// only logged; the header then shows signing as off.
+func (m *Model) refreshSigningConfig() tea.Cmd {
+	return func() tea.Msg {
+		config, err := m.repo.GetSigningConfig()
+		if err != nil {
+			logger.Debug("Failed to get signing config", "error", err)
+		}
+		return signingConfigMsg{config: config}
+	}
+}
+
+// toggleCommitSigning overrides commit.gpgsign for the next commit, or
+// restores the configured behavior
+func (m *Model) toggleCommitSigning() {
+	switch {
+	case m.signMode != git.SignDefault:
+		m.signMode = git.SignDefault
+		m.statusMessage = "Next commit follows commit.gpgsign"
+	case m.signing.Enabled:
+		m.signMode = git.SignNever
+		m.statusMessage = "Next commit will not be signed (--no-gpg-sign)"
+	default:
+		m.signMode = git.SignAlways
+		m.statusMessage = fmt.Sprintf("Next commit will be signed with %s (-S)", m.signing.Format)
+	}
+}
+
+// describeSigning describes the signing state for the header, e.g.
+// "[signing: ssh]" or "[signing: off, next: -S]". Nothing is shown while
+// commits are not signed.
+func (m *Model) describeSigning() string {
+	state := "signing: off"
+	if m.signing.Enabled {
+		state = "signing: " + m.signing.Format
+	}
+	switch m.signMode {
+	case git.SignAlways:
+		state += ", next: -S"
+	case git.SignNever:
+		state += ", next: --no-gpg-sign"
+	default:
+		if !m.signing.Enabled {
+			return ""
+		}
+	}
+	return "[" + state + "]"
+}
+
+// commitErrorMessage describes a failed commit. Signing failures get a hint,
+// since git runs without a terminal where pinentry could ask for a passphrase.
+func commitErrorMessage(err error) string {
+	var signingErr *git.SigningError
+	if errors.As(err, &signingErr) {
+		return fmt.Sprintf("Commit not created, %v (unlock the key or use a graphical pinentry, or press K to commit without signing)", signingErr)
+	}
+	return fmt.Sprintf("Failed to commit: %v", err)
+}
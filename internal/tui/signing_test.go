@@ -0,0 +1,62 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/mopemope/git-rovo/internal/git"
+)
+
+func TestCommitSigning(t *testing.T) {
+	model := setupMainViewTest(t)
+	model.width = 200
+	model.height = 40
+	dir, run := setupGitRepo(t)
+	run("commit", "-q", "--allow-empty", "-m", "Initial commit")
+	// A signing program that always fails stands in for a locked key
+	run("config", "commit.gpgsign", "true")
+	run("config", "gpg.format", "ssh")
+	run("config", "gpg.ssh.program", "false")
+	writeRepoFile(t, dir, "signed.txt", "signed\n")
+	run("add", ".")
+	openGitRepo(t, model, dir)
+	model.generatedMessage = "Add signed file"
+
+	model.Update(model.refreshSigningConfig()())
+	if header := model.renderHeader(); !contains(header, "[signing: ssh]") {
+		t.Errorf("Expected the signing state in the header, got %q", header)
+	}
+
+	model.Update(model.commitStagedChanges()())
+	if !contains(model.errorMessage, "failed to sign the commit with ssh") || !contains(model.errorMessage, "press K") {
+		t.Fatalf("Expected a signing error, got %q", model.errorMessage)
+	}
+
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'K'}})
+	if model.signMode != git.SignNever || !contains(model.renderHeader(), "next: --no-gpg-sign") {
+		t.Fatalf("Expected K to skip signing the next commit, got %v", model.signMode)
+	}
+	if msg, ok := model.commitStagedChanges()().(errorMsg); ok {
+		t.Fatalf("Failed to commit without signing: %s", msg.error)
+	}
+	if model.signMode != git.SignDefault {
+		t.Error("Expected the override to apply to one commit only")
+	}
+}
+
+func TestLogShowsSignatureStatus(t *testing.T) {
+	model := setupMainViewTest(t)
+	model.width = 120
+	model.height = 40
+	model.commitHistory = []git.CommitInfo{
+		{ShortHash: "abc12345", Author: "Test User", Subject: "Signed", Signature: "G"},
+		{ShortHash: "def67890", Author: "Test User", Subject: "Unsigned", Signature: "N"},
+	}
+
+	if entry := model.renderCommitEntry(model.commitHistory[0], false, 0); !contains(entry, "[signature: good]") {
+		t.Errorf("Expected the signature status, got %q", entry)
+	}
+	if entry := model.renderCommitEntry(model.commitHistory[1], false, 1); contains(entry, "signature") {
+		t.Errorf("Expected no signature status for an unsigned commit, got %q", entry)
+	}
+}
@@ -0,0 +1,367 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/mopemope/git-rovo/internal/git"
+	"github.com/mopemope/git-rovo/internal/llm"
+	"github.com/mopemope/git-rovo/internal/logger"
+)
+
+// refreshStashes reloads the stash list
+func (m *Model) refreshStashes() tea.Cmd {
+	return func() tea.Msg {
+		stashes, err := m.repo.GetStashes()
+		if err != nil {
+			return errorMsg{error: fmt.Sprintf("Failed to load stashes: %v", err)}
+		}
+		return stashesRefreshedMsg{stashes: stashes}
+	}
+}
+
+// getSelectedStash returns the stash entry under the cursor in the stash view
+func (m *Model) getSelectedStash() *git.StashEntry {
+	if m.currentView != ViewModeStash || m.cursor >= len(m.stashes) {
+		return nil
+	}
+	return &m.stashes[m.cursor]
+}
+
+// loadStashPreview loads the diff of the selected stash unless it is already shown
+func (m *Model) loadStashPreview() tea.Cmd {
+	stash := m.getSelectedStash()
+	if stash == nil || stash.Hash == m.stashPreviewHash {
+		return nil
+	}
+
+	index, hash := stash.Index, stash.Hash
+	return func() tea.Msg {
+		diffs, err := m.repo.GetStashDiff(index)
+		if err != nil {
+			return errorMsg{error: fmt.Sprintf("Failed to load stash diff: %v", err)}
+		}
+		return stashPreviewLoadedMsg{hash: hash, diffs: diffs}
+	}
+}
+
+// openStashPrompt asks for the message of a new stash. Staged-only stashes
+// save the index; otherwise the marked files, or all changes including
+// untracked files, are stashed.
+func (m *Model) openStashPrompt(stagedOnly bool) tea.Cmd {
+	opts := git.StashOptions{StagedOnly: stagedOnly}
+	title := "Stash staged changes"
+
+	if !stagedOnly {
+		marked := m.getMarkedFiles()
+		for _, file := range marked {
+			opts.Paths = append(opts.Paths, file.Path)
+			if file.Status == "??" {
+				opts.IncludeUntracked = true
+			}
+		}
+		if len(marked) > 0 {
+			title = "Stash " + describeTargets(opts.Paths)
+		} else {
+			opts.IncludeUntracked = true
+			title = "Stash all changes"
+		}
+	}
+
+	placeholder := "empty for WIP on <branch>"
+	if m.llmClient != nil {
+		placeholder = "empty to generate from the diff"
+	}
+
+	fields := []promptField{{label: "Message", placeholder: placeholder}}
+	m.prompt = NewInputPrompt("stash_changes", title, fields, func(values []string) tea.Cmd {
+		opts.Message = values[0]
+		if opts.Message == "" && m.llmClient != nil {
+			m.loading = true
+			m.loadingMessage = "Generating stash message..."
+		}
+		return m.stashChanges(opts)
+	})
+
+	return nil
+}
+
+// stashChanges creates a stash entry, generating its message when none was given
+func (m *Model) stashChanges(opts git.StashOptions) tea.Cmd {
+	return func() tea.Msg {
+		if opts.Message == "" && m.llmClient != nil {
+			// Fall back to Git's default message when generation fails
+			message, err := m.generateStashMessage(opts)
+			if err != nil {
+				logger.Warn("Failed to generate stash message", "error", err)
+			}
+			opts.Message = message
+		}
+
+		if err := m.repo.StashPush(opts); err != nil {
+			return errorMsg{error: fmt.Sprintf("Failed to stash changes: %v", err)}
+		}
+
+		logger.LogUIAction("stash_created", map[string]interface{}{
+			"message":     opts.Message,
+			"staged_only": opts.StagedOnly,
+			"paths":       opts.Paths,
+		})
+
+		message := "Stashed changes"
+		if opts.Message != "" {
+			message += ": " + opts.Message
+		}
+		return operationCompletedMsg{message: message, clearSelection: len(opts.Paths) > 0}
+	}
+}
+
+// generateStashMessage asks the LLM for a one-line description of the changes
+// that are about to be stashed
+func (m *Model) generateStashMessage(opts git.StashOptions) (string, error) {
+	var diffs []git.DiffInfo
+	if opts.StagedOnly {
+		staged, err := m.repo.GetDiff(true)
+		if err != nil {
+			return "", err
+		}
+		diffs = staged
+	} else {
+		for _, staged := range []bool{true, false} {
+			changes, err := m.repo.GetDiff(staged, opts.Paths...)
+			if err != nil {
+				return "", err
+			}
+			diffs = append(diffs, changes...)
+		}
+
+		var untracked []string
+		for _, file := range m.fileStatus {
+			if file.Status == "??" && (len(opts.Paths) == 0 || containsPath(opts.Paths, file.Path)) {
+				untracked = append(untracked, file.Path)
+			}
+		}
+		if len(untracked) > 0 {
+			if untrackedDiffs, err := m.repo.GetUntrackedFileDiff(untracked...); err == nil {
+				diffs = append(diffs, untrackedDiffs...)
+			}
+		}
+	}
+
+	if len(diffs) == 0 {
+		return "", fmt.Errorf("no changes to describe")
+	}
+
+	var diffContent strings.Builder
+	for _, diff := range diffs {
+		diffContent.WriteString(diff.Content)
+		diffContent.WriteString("\n")
+	}
+
+	request := &llm.CommitMessageRequest{
+		Mode:        llm.ModeStash,
+		Diff:        diffContent.String(),
+		Language:    m.config.LLM.Language,
+		MaxTokens:   m.config.LLM.OpenAI.MaxTokens,
+		Temperature: m.config.LLM.OpenAI.Temperature,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	response, err := m.llmClient.GenerateCommitMessage(ctx, request, "")
+	if err != nil {
+		return "", err
+	}
+
+	message, _, _ := strings.Cut(strings.TrimSpace(response.Message), "\n")
+	return strings.Trim(message, "\"' "), nil
+}
+
+// containsPath reports whether path is in paths
+func containsPath(paths []string, path string) bool {
+	for _, p := range paths {
+		if p == path {
+			return true
+		}
+	}
+	return false
+}
+
+// applySelectedStash applies the selected stash, removing it when pop is set
+func (m *Model) applySelectedStash(pop bool) tea.Cmd {
+	stash := m.getSelectedStash()
+	if stash == nil {
+		return nil
+	}
+
+	index, ref := stash.Index, stash.Ref
+	return func() tea.Msg {
+		var err error
+		if pop {
+			err = m.repo.StashPop(index)
+		} else {
+			err = m.repo.StashApply(index)
+		}
+		if err != nil {
+			return errorMsg{error: fmt.Sprintf("Failed to apply %s: %v", ref, err)}
+		}
+
+		logger.LogUIAction("stash_applied", map[string]interface{}{
+			"stash": ref,
+			"pop":   pop,
+		})
+
+		if pop {
+			return operationCompletedMsg{message: fmt.Sprintf("Popped %s", ref)}
+		}
+		return operationCompletedMsg{message: fmt.Sprintf("Applied %s", ref)}
+	}
+}
+
+// dropSelectedStash removes the selected stash entry
+func (m *Model) dropSelectedStash() (tea.Model, tea.Cmd) {
+	stash := m.getSelectedStash()
+	if stash == nil {
+		return m, nil
+	}
+
+	entry := *stash
+	cmd := func() tea.Msg {
+		if err := m.repo.StashDrop(entry.Index); err != nil {
+			return errorMsg{error: fmt.Sprintf("Failed to drop %s: %v", entry.Ref, err)}
+		}
+
+		logger.LogUIAction("stash_dropped", map[string]interface{}{
+			"stash": entry.Ref,
+			"hash":  entry.Hash,
+		})
+
+		return operationCompletedMsg{message: fmt.Sprintf("Dropped %s (%s)", entry.Ref, entry.Hash[:min(8, len(entry.Hash))])}
+	}
+
+	return m.withConfirmation("drop_stash", cmd, func() *ConfirmModal {
+		details := []string{
+			fmt.Sprintf("%s %s", entry.Ref, entry.Message),
+			"The entry can be recovered with: git stash store " + entry.Hash,
+		}
+		return NewConfirmModal("drop_stash", "Drop "+entry.Ref+"?", "The stash entry will be removed.", details, nil)
+	})
+}
+
+// showSelectedStashDiff shows the full diff of the selected stash in the diff view
+func (m *Model) showSelectedStashDiff() (tea.Model, tea.Cmd) {
+	stash := m.getSelectedStash()
+	if stash == nil {
+		return m, nil
+	}
+
+	index, hash := stash.Index, stash.Hash
+	return m.switchView(ViewModeDiff), func() tea.Msg {
+		diffs, err := m.repo.GetStashDiff(index)
+		if err != nil {
+			return errorMsg{error: fmt.Sprintf("Failed to load stash diff: %v", err)}
+		}
+		return commitDiffRefreshedMsg{diffs: diffs, commitHash: hash}
+	}
+}
+
+// renderStashView renders the stash list and a preview of the selected entry
+func (m *Model) renderStashView() string {
+	var content strings.Builder
+
+	header := fmt.Sprintf("Stash - %d entries", len(m.stashes))
+	content.WriteString(m.styles.Header.Width(m.width).Render(header))
+	content.WriteString("\n")
+
+	if len(m.stashes) == 0 {
+		content.WriteString(m.styles.Info.Render("No stashed changes"))
+		content.WriteString("\n\n")
+		content.WriteString(m.styles.Help.Render("Press 'z' in the status view to stash changes, 'Z' to stash only staged changes"))
+		return content.String()
+	}
+
+	// The list takes at most half of the space, the preview the rest
+	maxVisible := max(m.getMaxVisibleLines(), 2)
+	listHeight := min(len(m.stashes), max(maxVisible/2, 1))
+	start := 0
+	if m.cursor >= listHeight {
+		start = m.cursor - listHeight + 1
+	}
+
+	for i := start; i < min(start+listHeight, len(m.stashes)); i++ {
+		content.WriteString(m.renderStashLine(m.stashes[i], i == m.cursor))
+		content.WriteString("\n")
+	}
+
+	content.WriteString("\n")
+	content.WriteString(m.renderStashPreview(maxVisible - listHeight - 1))
+	return content.String()
+}
+
+// renderStashLine renders a single stash entry
+func (m *Model) renderStashLine(stash git.StashEntry, selected bool) string {
+	prefix := "  "
+	ref := stash.Ref
+	if selected {
+		prefix = "> "
+		ref = m.styles.Selected.Render(ref)
+	}
+
+	line := prefix + ref + "  " + m.styles.Warning.Render(stash.Hash[:min(8, len(stash.Hash))]) + "  " + stash.Message
+
+	var info []string
+	if stash.Branch != "" {
+		info = append(info, stash.Branch)
+	}
+	if !stash.Date.IsZero() {
+		info = append(info, stash.Date.Format("2006-01-02 15:04"))
+	}
+	if len(info) > 0 {
+		line += m.styles.Help.Render(" (" + strings.Join(info, ", ") + ")")
+	}
+
+	return line
+}
+
+// renderStashPreview renders the changed files and the diff of the selected stash
+func (m *Model) renderStashPreview(height int) string {
+	stash := m.getSelectedStash()
+	if stash == nil || stash.Hash != m.stashPreviewHash {
+		return m.styles.Help.Render("Loading preview...")
+	}
+	if len(m.stashPreview) == 0 {
+		return m.styles.Info.Render("The stash entry contains no changes")
+	}
+
+	var lines []string
+	for _, diff := range m.stashPreview {
+		lines = append(lines, fmt.Sprintf("%s %s %s",
+			m.getStatusText(diff.Status),
+			diff.FilePath,
+			m.styles.Info.Render(fmt.Sprintf("+%d -%d", diff.Additions, diff.Deletions))))
+	}
+	lines = append(lines, "")
+
+	for _, diff := range m.stashPreview {
+		for _, line := range strings.Split(diff.Content, "\n") {
+			switch {
+			case strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "+++"):
+				line = m.styles.DiffAdd.Render(line)
+			case strings.HasPrefix(line, "-") && !strings.HasPrefix(line, "---"):
+				line = m.styles.DiffRemove.Render(line)
+			case strings.HasPrefix(line, "@@"):
+				line = m.styles.Info.Render(line)
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	if height > 0 && len(lines) > height {
+		lines = append(lines[:max(height-1, 0)], m.styles.Help.Render("... press enter for the full diff"))
+	}
+	return strings.Join(lines, "\n")
+}
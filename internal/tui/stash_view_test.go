@@ -0,0 +1,186 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/mopemope/git-rovo/internal/git"
+)
+
+// setupStashRepoTest creates a model backed by a temporary repository with
+// two modified tracked files and an untracked file
+func setupStashRepoTest(t *testing.T) *Model {
+	t.Helper()
+
+	model := setupMainViewTest(t)
+	dir, run := setupGitRepo(t)
+	writeRepoFile(t, dir, "a.txt", "a\n")
+	writeRepoFile(t, dir, "b.txt", "b\n")
+	run("add", ".")
+	run("commit", "-q", "-m", "Initial commit")
+	writeRepoFile(t, dir, "a.txt", "a changed\n")
+	writeRepoFile(t, dir, "b.txt", "b changed\n")
+	writeRepoFile(t, dir, "new.txt", "new\n")
+	repo := openGitRepo(t, model, dir)
+
+	status, err := repo.GetStatus()
+	if err != nil {
+		t.Fatalf("Failed to get status: %v", err)
+	}
+	model.fileStatus = status
+	return model
+}
+
+// submitPrompt types the given text into the open prompt and submits it
+func submitPrompt(t *testing.T, model *Model, text string) tea.Msg {
+	t.Helper()
+	if model.prompt == nil {
+		t.Fatal("Expected an open prompt")
+	}
+	typeText(model, text)
+	_, cmd := model.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("Expected a command from the prompt")
+	}
+	return cmd()
+}
+
+func TestStashMarkedFiles(t *testing.T) {
+	model := setupStashRepoTest(t)
+	model.selected["b.txt"] = true
+	model.selected["new.txt"] = true
+
+	model.executeAction("stash_changes")
+	if model.prompt == nil || !contains(model.prompt.title, "2 files") {
+		t.Fatalf("Expected stash prompt for the marked files, got %+v", model.prompt)
+	}
+
+	result := submitPrompt(t, model, "partial work")
+	msg, ok := result.(operationCompletedMsg)
+	if !ok {
+		t.Fatalf("Expected stash to complete, got %#v", result)
+	}
+	if !msg.clearSelection || !contains(msg.message, "partial work") {
+		t.Fatalf("Expected the selection to be cleared, got %#v", msg)
+	}
+
+	stashes, _ := model.repo.GetStashes()
+	if len(stashes) != 1 || stashes[0].Message != "partial work" {
+		t.Fatalf("Unexpected stashes %+v", stashes)
+	}
+	diffs, _ := model.repo.GetStashDiff(0)
+	paths := map[string]bool{}
+	for _, diff := range diffs {
+		paths[diff.FilePath] = true
+	}
+	if len(paths) != 2 || !paths["b.txt"] || !paths["new.txt"] {
+		t.Errorf("Expected only the marked files to be stashed, got %v", paths)
+	}
+}
+
+func TestStashStagedWithGeneratedMessage(t *testing.T) {
+	model := setupStashRepoTest(t)
+	if err := model.repo.StageFiles("a.txt"); err != nil {
+		t.Fatalf("Failed to stage file: %v", err)
+	}
+
+	model.executeAction("stash_staged")
+	if model.prompt == nil || model.prompt.title != "Stash staged changes" {
+		t.Fatalf("Expected staged stash prompt, got %+v", model.prompt)
+	}
+
+	// An empty message is generated by the LLM provider
+	if _, ok := submitPrompt(t, model, "").(operationCompletedMsg); !ok {
+		t.Fatal("Expected stash to complete")
+	}
+
+	stashes, _ := model.repo.GetStashes()
+	if len(stashes) != 1 || stashes[0].Message != "feat: add new feature" {
+		t.Fatalf("Expected generated stash message, got %+v", stashes)
+	}
+	diffs, _ := model.repo.GetStashDiff(0)
+	if len(diffs) != 1 || diffs[0].FilePath != "a.txt" {
+		t.Errorf("Expected only the staged file to be stashed, got %+v", diffs)
+	}
+}
+
+func TestStashViewActions(t *testing.T) {
+	model := setupStashRepoTest(t)
+	model.width = 120
+	model.height = 40
+	model.config.UI.ConfirmActions = []string{"drop_stash"}
+
+	for _, message := range []string{"first", "second"} {
+		if err := model.repo.StashPush(git.StashOptions{Message: message, Paths: []string{"a.txt"}}); err != nil {
+			t.Fatalf("Failed to stash: %v", err)
+		}
+		writeRepoFile(t, model.repo.GetWorkDir(), "a.txt", "again\n")
+	}
+
+	_, cmd := model.executeAction("stashes")
+	_, cmd = model.Update(cmd())
+	if len(model.stashes) != 2 || cmd == nil {
+		t.Fatalf("Expected stashes and a preview command, got %+v", model.stashes)
+	}
+	model.Update(cmd())
+
+	output := model.renderStashView()
+	for _, want := range []string{"2 entries", "stash@{0}", "second", "stash@{1}", "first", "main", "a.txt", "+again"} {
+		if !contains(output, want) {
+			t.Errorf("Expected stash view to contain %q", want)
+		}
+	}
+
+	// Moving the cursor loads the preview of the next entry
+	_, cmd = model.executeAction("nav_down")
+	if cmd == nil {
+		t.Fatal("Expected preview command for the next entry")
+	}
+	model.Update(cmd())
+	if model.stashPreviewHash != model.stashes[1].Hash {
+		t.Error("Expected preview of the selected entry")
+	}
+
+	// Drop asks for confirmation
+	model.executeAction("drop_stash")
+	if model.modal == nil || !contains(model.modal.title, "stash@{1}") {
+		t.Fatalf("Expected drop confirmation, got %+v", model.modal)
+	}
+	_, cmd = model.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if _, ok := cmd().(operationCompletedMsg); !ok {
+		t.Fatal("Expected stash to be dropped")
+	}
+
+	stashes, _ := model.repo.GetStashes()
+	if len(stashes) != 1 || stashes[0].Message != "second" {
+		t.Fatalf("Expected only the second stash to remain, got %+v", stashes)
+	}
+
+	// Pop applies and removes the entry
+	model.stashes = stashes
+	model.cursor = 0
+	if err := model.repo.DiscardChanges("a.txt"); err != nil {
+		t.Fatalf("Failed to discard changes: %v", err)
+	}
+	_, cmd = model.executeAction("pop_stash")
+	if _, ok := cmd().(operationCompletedMsg); !ok {
+		t.Fatal("Expected stash to be popped")
+	}
+	if stashes, _ = model.repo.GetStashes(); len(stashes) != 0 {
+		t.Errorf("Expected empty stash, got %+v", stashes)
+	}
+}
+
+func TestRenderEmptyStashView(t *testing.T) {
+	model := setupMainViewTest(t)
+	model.width = 80
+	model.height = 24
+	model.currentView = ViewModeStash
+
+	if !contains(model.renderStashView(), "No stashed changes") {
+		t.Error("Expected empty stash message")
+	}
+	if _, cmd := model.executeAction("apply_stash"); cmd != nil {
+		t.Error("Expected no command without a selected stash")
+	}
+}
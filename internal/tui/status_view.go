@@ -2,104 +2,151 @@ package tui
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/charmbracelet/bubbletea"
 	"github.com/mopemope/git-rovo/internal/git"
 	"github.com/mopemope/git-rovo/internal/logger"
 )
 
+// getDisplayedFiles returns the files in display order (unmerged, staged,
+// unstaged, untracked)
+func (m *Model) getDisplayedFiles() []git.FileStatus {
+	staged, unstaged, untracked := m.groupFiles()
+
+	files := m.unmergedFiles()
+	if m.mainViewState.showStagedSection {
+		files = append(files, staged...)
+	}
+	if m.mainViewState.showUnstagedSection {
+		files = append(files, unstaged...)
+	}
+	if m.mainViewState.showUntrackedSection {
+		files = append(files, untracked...)
+	}
+	return files
+}
+
 // getCurrentFile returns the currently selected file based on display order
 func (m *Model) getCurrentFile() *git.FileStatus {
-	if len(m.fileStatus) == 0 {
+	files := m.getDisplayedFiles()
+	if m.cursor < 0 || m.cursor >= len(files) {
 		return nil
 	}
+	return &files[m.cursor]
+}
 
-	// Group files to match display order
-	staged, unstaged, untracked := m.groupFiles()
-
-	currentIndex := 0
-
-	// Check staged files first
-	if m.mainViewState.showStagedSection && len(staged) > 0 {
-		if m.cursor >= currentIndex && m.cursor < currentIndex+len(staged) {
-			return &staged[m.cursor-currentIndex]
+// getMarkedFiles returns the marked files in display order
+func (m *Model) getMarkedFiles() []git.FileStatus {
+	var marked []git.FileStatus
+	for _, file := range m.getDisplayedFiles() {
+		if m.selected[file.Path] {
+			marked = append(marked, file)
 		}
-		currentIndex += len(staged)
 	}
+	return marked
+}
 
-	// Check unstaged files
-	if m.mainViewState.showUnstagedSection && len(unstaged) > 0 {
-		if m.cursor >= currentIndex && m.cursor < currentIndex+len(unstaged) {
-			return &unstaged[m.cursor-currentIndex]
-		}
-		currentIndex += len(unstaged)
+// getTargetFiles returns the files a file action applies to: the marked set
+// when it is non-empty, otherwise the current file
+func (m *Model) getTargetFiles() []git.FileStatus {
+	if marked := m.getMarkedFiles(); len(marked) > 0 {
+		return marked
+	}
+	if file := m.getCurrentFile(); file != nil {
+		return []git.FileStatus{*file}
 	}
+	return nil
+}
 
-	// Check untracked files
-	if m.mainViewState.showUntrackedSection && len(untracked) > 0 {
-		if m.cursor >= currentIndex && m.cursor < currentIndex+len(untracked) {
-			return &untracked[m.cursor-currentIndex]
-		}
+// filePaths returns the paths of the given files
+func filePaths(files []git.FileStatus) []string {
+	paths := make([]string, 0, len(files))
+	for _, file := range files {
+		paths = append(paths, file.Path)
 	}
+	return paths
+}
 
-	return nil
+// describeTargets returns "path" for a single file or "N files" for a batch
+func describeTargets(paths []string) string {
+	if len(paths) == 1 {
+		return paths[0]
+	}
+	return fmt.Sprintf("%d files", len(paths))
 }
 
-// stageCurrentFile stages the currently selected file
+// stageCurrentFile stages the marked files, or the current file when nothing is marked
 func (m *Model) stageCurrentFile() tea.Cmd {
-	file := m.getCurrentFile()
-	if file == nil {
+	targets := m.getTargetFiles()
+	if len(targets) == 0 {
 		return func() tea.Msg {
 			return errorMsg{error: "No file selected"}
 		}
 	}
 
-	if file.Staged {
+	var toStage []git.FileStatus
+	for _, file := range targets {
+		if !file.Staged || file.Modified {
+			toStage = append(toStage, file)
+		}
+	}
+	if len(toStage) == 0 {
 		return func() tea.Msg {
 			return errorMsg{error: "File is already staged"}
 		}
 	}
 
+	batch := len(m.getMarkedFiles()) > 0
+	paths := filePaths(toStage)
 	return func() tea.Msg {
-		err := m.repo.StageFiles(file.Path)
+		err := m.repo.StageFiles(paths...)
 		if err != nil {
 			return errorMsg{error: fmt.Sprintf("Failed to stage file: %v", err)}
 		}
 
 		logger.LogUIAction("file_staged", map[string]interface{}{
-			"file": file.Path,
+			"files": paths,
 		})
 
-		return operationCompletedMsg{message: fmt.Sprintf("Staged: %s", file.Path)}
+		return operationCompletedMsg{message: fmt.Sprintf("Staged: %s", describeTargets(paths)), clearSelection: batch}
 	}
 }
 
-// unstageCurrentFile unstages the currently selected file
+// unstageCurrentFile unstages the marked files, or the current file when nothing is marked
 func (m *Model) unstageCurrentFile() tea.Cmd {
-	file := m.getCurrentFile()
-	if file == nil {
+	targets := m.getTargetFiles()
+	if len(targets) == 0 {
 		return func() tea.Msg {
 			return errorMsg{error: "No file selected"}
 		}
 	}
 
-	if !file.Staged {
+	var toUnstage []git.FileStatus
+	for _, file := range targets {
+		if file.Staged {
+			toUnstage = append(toUnstage, file)
+		}
+	}
+	if len(toUnstage) == 0 {
 		return func() tea.Msg {
 			return errorMsg{error: "File is not staged"}
 		}
 	}
 
+	batch := len(m.getMarkedFiles()) > 0
+	paths := filePaths(toUnstage)
 	return func() tea.Msg {
-		err := m.repo.UnstageFiles(file.Path)
+		err := m.repo.UnstageFiles(paths...)
 		if err != nil {
 			return errorMsg{error: fmt.Sprintf("Failed to unstage file: %v", err)}
 		}
 
 		logger.LogUIAction("file_unstaged", map[string]interface{}{
-			"file": file.Path,
+			"files": paths,
 		})
 
-		return operationCompletedMsg{message: fmt.Sprintf("Unstaged: %s", file.Path)}
+		return operationCompletedMsg{message: fmt.Sprintf("Unstaged: %s", describeTargets(paths)), clearSelection: batch}
 	}
 }
 
@@ -117,24 +164,75 @@ func (m *Model) stageAllFiles() tea.Cmd {
 	}
 }
 
-// toggleCurrentFile toggles staging of the current file
+// toggleCurrentFile toggles staging of the target files: staged files are
+// unstaged and everything else is staged
 func (m *Model) toggleCurrentFile() tea.Cmd {
-	file := m.getCurrentFile()
-	if file == nil {
+	targets := m.getTargetFiles()
+	if len(targets) == 0 {
 		return func() tea.Msg {
 			return errorMsg{error: "No file selected"}
 		}
 	}
 
-	if file.Staged {
-		return m.unstageCurrentFile()
-	} else {
-		return m.stageCurrentFile()
+	var toStage, toUnstage []string
+	for _, file := range targets {
+		if file.Staged {
+			toUnstage = append(toUnstage, file.Path)
+		} else {
+			toStage = append(toStage, file.Path)
+		}
+	}
+
+	batch := len(m.getMarkedFiles()) > 0
+	return func() tea.Msg {
+		if len(toUnstage) > 0 {
+			if err := m.repo.UnstageFiles(toUnstage...); err != nil {
+				return errorMsg{error: fmt.Sprintf("Failed to unstage file: %v", err)}
+			}
+		}
+		if len(toStage) > 0 {
+			if err := m.repo.StageFiles(toStage...); err != nil {
+				return errorMsg{error: fmt.Sprintf("Failed to stage file: %v", err)}
+			}
+		}
+
+		logger.LogUIAction("files_toggled", map[string]interface{}{
+			"staged":   toStage,
+			"unstaged": toUnstage,
+		})
+
+		var parts []string
+		if len(toStage) > 0 {
+			parts = append(parts, "Staged: "+describeTargets(toStage))
+		}
+		if len(toUnstage) > 0 {
+			parts = append(parts, "Unstaged: "+describeTargets(toUnstage))
+		}
+
+		return operationCompletedMsg{message: strings.Join(parts, ", "), clearSelection: batch}
 	}
 }
 
 // commitStagedChanges commits the staged changes
 func (m *Model) commitStagedChanges() tea.Cmd {
+	return m.commitStagedChangesWith(false)
+}
+
+// commitStagedChangesWith commits the staged changes, skipping the
+// pre-commit and commit-msg hooks when noVerify is set
+func (m *Model) commitStagedChangesWith(noVerify bool) tea.Cmd {
+	if unmerged := len(m.unmergedFiles()); unmerged > 0 {
+		return func() tea.Msg {
+			return errorMsg{error: fmt.Sprintf("Resolve %d conflicted files before committing", unmerged)}
+		}
+	}
+	if operation := m.repoState.Operation; operation != git.OperationNone && operation != git.OperationMerge {
+		// Committing would interrupt the sequence; continue it instead
+		return func() tea.Msg {
+			return errorMsg{error: fmt.Sprintf("A %s is in progress; press M to continue it", operation)}
+		}
+	}
+
 	return func() tea.Msg {
 		// Check if there are staged changes
 		hasStaged, err := m.repo.HasStagedChanges()
@@ -152,19 +250,27 @@ func (m *Model) commitStagedChanges() tea.Cmd {
 			return autoGenerateAndCommitMsg{}
 		}
 
-		// Perform commit
-		err = m.repo.Commit(commitMessage)
+		commitMessage, err = m.addCommitTrailers(commitMessage)
 		if err != nil {
 			return errorMsg{error: fmt.Sprintf("Failed to commit: %v", err)}
 		}
 
+		// Perform commit
+		err = m.repo.CommitWithOptions(commitMessage, git.CommitOptions{Sign: m.signMode, NoVerify: noVerify})
+		if err != nil {
+			return m.commitFailed(err)
+		}
+
 		logger.LogUIAction("commit_created", map[string]interface{}{
-			"message": commitMessage,
+			"message":   commitMessage,
+			"no_verify": noVerify,
 		})
 
-		// Clear generated message after successful commit
+		// Clear generated message and picked co-authors after successful commit
 		m.generatedMessage = ""
 		m.messageConfidence = 0
+		m.extraTrailers = nil
+		m.signMode = git.SignDefault
 
 		return operationCompletedMsg{message: "Commit created successfully"}
 	}
@@ -0,0 +1,59 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mopemope/git-rovo/internal/config"
+	"github.com/mopemope/git-rovo/internal/git"
+	"github.com/mopemope/git-rovo/internal/logger"
+)
+
+// ticketTrailersFor returns the trailers of the ticket IDs the rules find
+// in a branch name, in rule order
+func ticketTrailersFor(branch string, rules []config.TicketRule) []git.Trailer {
+	var trailers []git.Trailer
+	for _, rule := range rules {
+		if id := rule.Match(branch); id != "" {
+			trailers = append(trailers, git.Trailer{Key: rule.Trailer, Value: id})
+		}
+	}
+	return trailers
+}
+
+// ticketTrailers returns the trailers of the ticket IDs in the current branch
+// name. A detached HEAD has no tickets.
+func (m *Model) ticketTrailers() []git.Trailer {
+	if len(m.config.Git.TicketRules) == 0 {
+		return nil
+	}
+	branch, err := m.repo.GetCurrentBranch()
+	if err != nil {
+		logger.Warn("Failed to get the branch for ticket rules", "error", err)
+		return nil
+	}
+	return ticketTrailersFor(branch, m.config.Git.TicketRules)
+}
+
+// ticketContext describes the tickets of the current branch for the prompt,
+// so the message can refer to them without repeating the trailers
+func ticketContext(trailers []git.Trailer) string {
+	if len(trailers) == 0 {
+		return ""
+	}
+	ids := make([]string, 0, len(trailers))
+	lines := make([]string, 0, len(trailers))
+	for _, trailer := range trailers {
+		if !slices.Contains(ids, trailer.Value) {
+			ids = append(ids, trailer.Value)
+		}
+		lines = append(lines, trailer.String())
+	}
+	noun := "ticket"
+	if len(ids) > 1 {
+		noun = "tickets"
+	}
+	return fmt.Sprintf("The branch refers to %s %s. These trailers are appended automatically, so do not write them:\n%s",
+		noun, strings.Join(ids, ", "), strings.Join(lines, "\n"))
+}
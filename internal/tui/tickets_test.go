@@ -0,0 +1,67 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/mopemope/git-rovo/internal/config"
+)
+
+func TestTicketTrailers(t *testing.T) {
+	model := setupMainViewTest(t)
+	model.width = 120
+	model.height = 40
+	dir, run := setupGitRepo(t)
+	run("commit", "-q", "--allow-empty", "-m", "Initial commit")
+	run("checkout", "-q", "-b", "feature/PROJ-1234-login")
+	writeRepoFile(t, dir, "login.go", "package login\n")
+	run("add", ".")
+	openGitRepo(t, model, dir)
+	model.config.Git.TicketRules = []config.TicketRule{{Pattern: `([A-Z][A-Z0-9]+-\d+)`, Trailer: "Refs"}}
+	provider := useScriptedLLM(t, model, "feat: add login")
+	model.Update(model.refreshStatus()())
+
+	model.Update(model.generateCommitMessage()())
+	if len(provider.requests) != 1 || !contains(provider.requests[0].AdditionalContext, "ticket PROJ-1234") {
+		t.Fatalf("Expected the ticket in the prompt context, got %+v", provider.requests)
+	}
+	if output := model.renderCommitMessageSection(); !contains(output, "Trailers to add on commit: Refs: PROJ-1234") {
+		t.Errorf("Expected the trailer indicator, got %q", output)
+	}
+	if summary := model.renderRepositorySummary(); !contains(summary, "Refs: PROJ-1234") {
+		t.Errorf("Expected the trailer in the summary, got %q", summary)
+	}
+
+	msg := model.commitStagedChanges()()
+	if errMsg, ok := msg.(errorMsg); ok {
+		t.Fatalf("Failed to commit: %s", errMsg.error)
+	}
+	if message := run("log", "-1", "--format=%B"); message != "feat: add login\n\nRefs: PROJ-1234\n\n" {
+		t.Errorf("Expected the trailer in the commit, got %q", message)
+	}
+
+	// Rendering shows the trailers computed in Update until the next refresh
+	run("checkout", "-q", "main")
+	if summary := model.renderRepositorySummary(); !contains(summary, "Refs: PROJ-1234") {
+		t.Errorf("Expected the stored trailers before the refresh, got %q", summary)
+	}
+	model.Update(model.refreshStatus()())
+	if summary := model.renderRepositorySummary(); contains(summary, "Refs:") {
+		t.Errorf("Expected no trailers on main after the refresh, got %q", summary)
+	}
+
+	// Branches without a ticket add nothing
+	if trailers := ticketTrailersFor("main", model.config.Git.TicketRules); len(trailers) != 0 {
+		t.Errorf("Expected no trailers for main, got %v", trailers)
+	}
+}
+
+func TestTicketContextListsEveryTicket(t *testing.T) {
+	rules := []config.TicketRule{
+		{Pattern: `([A-Z][A-Z0-9]+-\d+)`, Trailer: "Refs"},
+		{Pattern: `#(\d+)`, Trailer: "Fixes"},
+	}
+	context := ticketContext(ticketTrailersFor("fix/PROJ-7-#42-crash", rules))
+	if !contains(context, "refers to tickets PROJ-7, 42.") || !contains(context, "Refs: PROJ-7\nFixes: 42") {
+		t.Errorf("Expected both tickets in the context, got %q", context)
+	}
+}
@@ -0,0 +1,285 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mopemope/git-rovo/internal/git"
+	"github.com/mopemope/git-rovo/internal/logger"
+)
+
+// Trailer keys managed by the trailer editor
+const (
+	trailerCoAuthor = "Co-authored-by"
+	trailerReviewer = "Reviewed-by"
+	trailerSignoff  = "Signed-off-by"
+)
+
+// TrailerEditor picks co-authors and reviewers among the contributors of the
+// repository and toggles the sign-off of the next commit
+type TrailerEditor struct {
+	identity     string // Committer identity used for Signed-off-by
+	signoff      bool
+	contributors []git.Contributor
+	coAuthors    map[string]bool // Selected contributor identities
+	reviewers    map[string]bool
+	cursor       int // The sign-off row, then one row per contributor
+}
+
+// contributorsLoadedMsg opens the trailer editor once the contributors are known
+type contributorsLoadedMsg struct {
+	identity     string
+	contributors []git.Contributor
+}
+
+// loadTrailerEditor loads the committer identity and the contributors from
+// git shortlog for the trailer editor
+func (m *Model) loadTrailerEditor() tea.Cmd {
+	return func() tea.Msg {
+		identity, err := m.repo.GetCommitterIdentity()
+		if err != nil {
+			return errorMsg{error: err.Error()}
+		}
+
+		// Without history there is nobody to pick, but the sign-off still works
+		contributors, err := m.repo.GetContributors()
+		if err != nil {
+			logger.Warn("Failed to list contributors", "error", err)
+		}
+		return contributorsLoadedMsg{identity: identity, contributors: contributors}
+	}
+}
+
+// openTrailerEditor opens the trailer editor with the current selection.
+// The committer is not offered as a co-author of their own commit.
+func (m *Model) openTrailerEditor(msg contributorsLoadedMsg) {
+	editor := &TrailerEditor{
+		identity:  msg.identity,
+		signoff:   m.signoff,
+		coAuthors: map[string]bool{},
+		reviewers: map[string]bool{},
+	}
+	for _, contributor := range msg.contributors {
+		if !strings.HasSuffix(msg.identity, "<"+contributor.Email+">") {
+			editor.contributors = append(editor.contributors, contributor)
+		}
+	}
+	for _, trailer := range m.extraTrailers {
+		switch trailer.Key {
+		case trailerCoAuthor:
+			editor.coAuthors[trailer.Value] = true
+		case trailerReviewer:
+			editor.reviewers[trailer.Value] = true
+		}
+	}
+
+	m.committer = msg.identity
+	m.trailerEditor = editor
+}
+
+// trailers returns the co-author and reviewer trailers picked in the editor,
+// in contributor order
+func (e *TrailerEditor) trailers() []git.Trailer {
+	var trailers []git.Trailer
+	for _, key := range []string{trailerCoAuthor, trailerReviewer} {
+		selected := e.coAuthors
+		if key == trailerReviewer {
+			selected = e.reviewers
+		}
+		for _, contributor := range e.contributors {
+			if identity := contributor.String(); selected[identity] {
+				trailers = append(trailers, git.Trailer{Key: key, Value: identity})
+			}
+		}
+	}
+	return trailers
+}
+
+// handleTrailerEditorKeyPress handles key presses while the trailer editor is open
+func (m *Model) handleTrailerEditorKeyPress(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	editor := m.trailerEditor
+	rows := len(editor.contributors) + 1
+
+	switch msg.String() {
+	case "up", "k":
+		editor.cursor = (editor.cursor + rows - 1) % rows
+	case "down", "j":
+		editor.cursor = (editor.cursor + 1) % rows
+	case " ":
+		if editor.cursor == 0 {
+			editor.signoff = !editor.signoff
+		} else {
+			identity := editor.contributors[editor.cursor-1].String()
+			editor.coAuthors[identity] = !editor.coAuthors[identity]
+		}
+	case "r":
+		if editor.cursor > 0 {
+			identity := editor.contributors[editor.cursor-1].String()
+			editor.reviewers[identity] = !editor.reviewers[identity]
+		}
+	case "enter":
+		m.trailerEditor = nil
+		m.signoff = editor.signoff
+		m.extraTrailers = editor.trailers()
+		m.refreshPendingTrailers()
+		m.statusMessage = fmt.Sprintf("%d trailers will be added to the next commit", len(m.pendingTrailers))
+		logger.LogUIAction("trailers_edited", map[string]interface{}{
+			"signoff":  m.signoff,
+			"trailers": len(m.extraTrailers),
+		})
+	case "esc", "q", "ctrl+c":
+		m.trailerEditor = nil
+		m.statusMessage = "Cancelled: Commit trailers"
+	}
+	return m, nil
+}
+
+// toggleSignoff toggles the Signed-off-by trailer of the next commit
+func (m *Model) toggleSignoff() tea.Cmd {
+	m.signoff = !m.signoff
+	if !m.signoff {
+		m.refreshPendingTrailers()
+		m.statusMessage = "Commits will not be signed off"
+		return nil
+	}
+
+	identity, err := m.committerIdentity()
+	if err != nil {
+		m.signoff = false
+		return func() tea.Msg { return errorMsg{error: err.Error()} }
+	}
+	m.refreshPendingTrailers()
+	m.statusMessage = fmt.Sprintf("Commits will be signed off by %s", identity)
+	return nil
+}
+
+// committerIdentity returns the identity used for Signed-off-by, loading it
+// on first use
+func (m *Model) committerIdentity() (string, error) {
+	if m.committer == "" {
+		identity, err := m.repo.GetCommitterIdentity()
+		if err != nil {
+			return "", err
+		}
+		m.committer = identity
+	}
+	return m.committer, nil
+}
+
+// commitTrailersFor returns the trailers appended to the next commit on
+// branch: ticket references, picked co-authors and reviewers, and the
+// sign-off last as git commit --signoff does
+func (m *Model) commitTrailersFor(branch string) ([]git.Trailer, error) {
+	trailers := ticketTrailersFor(branch, m.config.Git.TicketRules)
+	trailers = append(trailers, m.extraTrailers...)
+	if m.signoff {
+		identity, err := m.committerIdentity()
+		if err != nil {
+			return trailers, err
+		}
+		trailers = append(trailers, git.Trailer{Key: trailerSignoff, Value: identity})
+	}
+	return trailers, nil
+}
+
+// refreshPendingTrailers recomputes the trailers of the next commit on the
+// current branch for display. It runs in Update whenever the branch or the
+// trailer selection changes; a sign-off without a committer identity is
+// reported and left out.
+func (m *Model) refreshPendingTrailers() {
+	trailers, err := m.commitTrailersFor(m.branch)
+	if err != nil {
+		m.errorMessage = fmt.Sprintf("Failed to get the committer identity: %v", err)
+	}
+	m.pendingTrailers = trailers
+}
+
+// commitTrailers returns the trailers appended to the next commit on the
+// current branch
+func (m *Model) commitTrailers() ([]git.Trailer, error) {
+	branch, err := m.repo.GetCurrentBranch()
+	if err != nil {
+		logger.Warn("Failed to get the branch for ticket rules", "error", err)
+	}
+	return m.commitTrailersFor(branch)
+}
+
+// addCommitTrailers appends the trailers of the next commit to its message
+// before committing
+func (m *Model) addCommitTrailers(message string) (string, error) {
+	trailers, err := m.commitTrailers()
+	if err != nil {
+		return message, err
+	}
+	if len(trailers) == 0 {
+		return message, nil
+	}
+	message, err = m.repo.AddTrailers(message, trailers)
+	if err != nil {
+		return message, fmt.Errorf("failed to add trailers: %w", err)
+	}
+	return message, nil
+}
+
+// formatTrailers renders trailers on one line for indicators
+func formatTrailers(trailers []git.Trailer) string {
+	items := make([]string, 0, len(trailers))
+	for _, trailer := range trailers {
+		items = append(items, trailer.String())
+	}
+	return strings.Join(items, ", ")
+}
+
+// renderTrailerEditor renders the trailer editor centered in the content area
+func (m *Model) renderTrailerEditor() string {
+	editor := m.trailerEditor
+	var content strings.Builder
+
+	content.WriteString(m.styles.Info.Render("Commit trailers"))
+	content.WriteString("\n\n")
+
+	checkbox := func(checked bool) string {
+		if checked {
+			return "[x]"
+		}
+		return "[ ]"
+	}
+	row := func(index int, text string) {
+		if index == editor.cursor {
+			content.WriteString(m.styles.Selected.Render("> " + text))
+		} else {
+			content.WriteString(m.styles.Base.Render("  " + text))
+		}
+		content.WriteString("\n")
+	}
+
+	row(0, fmt.Sprintf("%s %s: %s", checkbox(editor.signoff), trailerSignoff, editor.identity))
+	if len(editor.contributors) == 0 {
+		content.WriteString(m.styles.Help.Render("  No other contributors in the history"))
+		content.WriteString("\n")
+	}
+
+	// Keep the cursor visible in long histories
+	visible := max(m.height-20, 5)
+	start := max(0, editor.cursor-visible)
+	end := min(len(editor.contributors), start+visible)
+	for i := start; i < end; i++ {
+		contributor := editor.contributors[i]
+		identity := contributor.String()
+		row(i+1, fmt.Sprintf("%s co-author %s reviewer  %s (%d commits)",
+			checkbox(editor.coAuthors[identity]), checkbox(editor.reviewers[identity]), identity, contributor.Commits))
+	}
+
+	boxWidth := min(max(m.width-10, 20), 100)
+
+	box := m.styles.Base.
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(CatppuccinBlue)).
+		Padding(1, 2).
+		Width(boxWidth).
+		Render(strings.TrimRight(content.String(), "\n"))
+
+	return lipgloss.Place(m.width, lipgloss.Height(box)+2, lipgloss.Center, lipgloss.Center, box)
+}
@@ -0,0 +1,62 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/bubbletea"
+)
+
+func TestTrailerEditor(t *testing.T) {
+	model := setupMainViewTest(t)
+	model.width = 120
+	model.height = 40
+	dir, run := setupGitRepo(t)
+	run("commit", "-q", "--allow-empty", "-m", "Initial commit")
+	run("-c", "user.name=Jane Doe", "-c", "user.email=jane@example.com", "commit", "-q", "--allow-empty", "-m", "Jane's work")
+	writeRepoFile(t, dir, "login.go", "package login\n")
+	run("add", ".")
+	openGitRepo(t, model, dir)
+	model.generatedMessage = "feat: add login\n\nAdd the login form"
+
+	model.Update(model.loadTrailerEditor()())
+	editor := model.trailerEditor
+	if editor == nil {
+		t.Fatalf("Expected the trailer editor to open, got error %q", model.errorMessage)
+	}
+	if len(editor.contributors) != 1 || editor.contributors[0].String() != "Jane Doe <jane@example.com>" {
+		t.Fatalf("Expected the committer to be left out of the contributors, got %+v", editor.contributors)
+	}
+	if output := model.View(); !contains(output, "Signed-off-by: Test User <test@example.com>") || !contains(output, "Jane Doe <jane@example.com> (1 commits)") {
+		t.Errorf("Expected the editor to list the sign-off and contributors, got %q", output)
+	}
+
+	// Sign off, then pick Jane as co-author and reviewer
+	model.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	model.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if model.trailerEditor != nil || !model.signoff || len(model.extraTrailers) != 2 {
+		t.Fatalf("Expected the trailers to be applied, got signoff %v and %+v", model.signoff, model.extraTrailers)
+	}
+	if output := model.renderCommitMessageSection(); !contains(output, "Co-authored-by: Jane Doe <jane@example.com>, Reviewed-by: Jane Doe <jane@example.com>, Signed-off-by: Test User <test@example.com>") {
+		t.Errorf("Expected the trailer indicator, got %q", output)
+	}
+
+	if msg, ok := model.commitStagedChanges()().(errorMsg); ok {
+		t.Fatalf("Failed to commit: %s", msg.error)
+	}
+	expected := "feat: add login\n\nAdd the login form\n\nCo-authored-by: Jane Doe <jane@example.com>\nReviewed-by: Jane Doe <jane@example.com>\nSigned-off-by: Test User <test@example.com>\n\n"
+	if message := run("log", "-1", "--format=%B"); message != expected {
+		t.Errorf("Expected the trailers after the body, got %q", message)
+	}
+
+	// Co-authors are picked per commit, the sign-off is kept until toggled off
+	if len(model.extraTrailers) != 0 || !model.signoff {
+		t.Errorf("Expected only the sign-off to be kept, got signoff %v and %+v", model.signoff, model.extraTrailers)
+	}
+	model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'O'}})
+	if model.signoff {
+		t.Error("Expected O to toggle the sign-off off")
+	}
+}
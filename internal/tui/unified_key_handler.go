@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/charmbracelet/bubbletea"
+	"github.com/mopemope/git-rovo/internal/git"
 	"github.com/mopemope/git-rovo/internal/logger"
 )
 
@@ -42,11 +43,23 @@ func (m *Model) executeAction(action string) (tea.Model, tea.Cmd) {
 		return m, tea.Batch(
 			m.refreshStatus(),
 			m.refreshCommitHistory(),
+			m.refreshBranches(),
+			m.refreshStashes(),
+			m.refreshUpstreamStatus(),
+			m.refreshSigningConfig(),
 		)
 	case "status":
 		return m.switchView(ViewModeStatus), nil
 	case "diff":
+		if marked := m.getMarkedFiles(); len(marked) > 0 {
+			// Show the combined diff for the marked set
+			return m.switchView(ViewModeDiff), m.refreshMarkedDiff(marked)
+		}
 		file := m.getCurrentFile()
+		if file != nil && file.Unmerged() {
+			// Show the file with its conflict markers
+			return m.switchView(ViewModeDiff), m.showConflictDiff(file.Path)
+		}
 		if file != nil {
 			// Show staged diff if file is staged, otherwise show unstaged diff
 			return m.switchView(ViewModeDiff), m.refreshDiff(file.Staged, file.Path)
@@ -55,6 +68,10 @@ func (m *Model) executeAction(action string) (tea.Model, tea.Cmd) {
 		return m.switchView(ViewModeDiff), m.refreshDiff(false)
 	case "log":
 		return m.switchView(ViewModeLog), nil
+	case "branches":
+		return m.switchView(ViewModeBranches), m.refreshBranches()
+	case "stashes":
+		return m.switchView(ViewModeStash), m.refreshStashes()
 
 	// Navigation actions
 	case "nav_up":
@@ -80,11 +97,11 @@ func (m *Model) executeAction(action string) (tea.Model, tea.Cmd) {
 	case "stage_all":
 		return m, m.stageAllFiles()
 	case "unstage_all":
-		return m, m.unstageAllFiles()
+		return m.withConfirmation(action, m.unstageAllFiles(), m.unstageAllConfirmation)
 	case "commit":
 		return m, m.commitStagedChanges()
 	case "amend_commit":
-		return m, m.amendLastCommit()
+		return m.withConfirmation(action, m.amendLastCommit(), m.amendConfirmation)
 	case "quick_commit":
 		return m.handleQuickCommit()
 	case "generate_message":
@@ -97,12 +114,39 @@ func (m *Model) executeAction(action string) (tea.Model, tea.Cmd) {
 		m.loading = true
 		m.loadingMessage = "Regenerating commit message..."
 		return m, m.generateCommitMessage()
+	case "edit_trailers":
+		return m, m.loadTrailerEditor()
+	case "toggle_signoff":
+		return m, m.toggleSignoff()
+	case "toggle_signing":
+		m.toggleCommitSigning()
+		return m, nil
 	case "reset_file":
-		return m, m.resetCurrentFile()
+		return m.withConfirmation(action, m.resetCurrentFile(), m.resetConfirmation)
 	case "discard_changes":
-		return m, m.discardCurrentFileChanges()
+		return m.withConfirmation(action, m.discardCurrentFileChanges(), m.discardConfirmation)
+	case "fixup_commit":
+		return m.fixupCommit()
+	case "autosquash":
+		return m.autosquash()
+	case "undo":
+		return m, m.undoLastOperation()
+	case "resolve_ours":
+		return m, m.resolveConflicts(git.ConflictOurs)
+	case "resolve_theirs":
+		return m, m.resolveConflicts(git.ConflictTheirs)
+	case "resolve_both":
+		return m, m.resolveConflicts(git.ConflictBoth)
+	case "continue_operation":
+		return m, m.continueOperation()
+	case "abort_operation":
+		return m.handleAbortOperation()
 	case "toggle_section":
 		return m, m.toggleCurrentSection()
+	case "mark_file":
+		return m, m.toggleMark()
+	case "mark_range":
+		return m, m.markRange()
 	case "select_all":
 		return m, m.selectAllFiles()
 	case "clear_selection":
@@ -125,6 +169,16 @@ func (m *Model) executeAction(action string) (tea.Model, tea.Cmd) {
 	case "toggle_stats":
 		m.diffViewState.showStats = !m.diffViewState.showStats
 		return m, nil
+	case "suggest_resolution":
+		return m.suggestConflictResolution()
+	case "accept_resolution":
+		return m.acceptConflictResolution()
+	case "reject_resolution":
+		return m.rejectConflictResolution()
+	case "next_conflict":
+		return m.jumpToConflict(true)
+	case "prev_conflict":
+		return m.jumpToConflict(false)
 	case "toggle_staged_unstaged":
 		// Toggle between staged and unstaged diff for current file
 		if len(m.currentDiff) > 0 {
@@ -146,9 +200,100 @@ func (m *Model) executeAction(action string) (tea.Model, tea.Cmd) {
 		return m.handleShowCommitDetails()
 	case "copy_commit_hash":
 		return m.handleCopyCommitHash()
+	case "copy_commit_subject":
+		return m.handleCopyCommitSubject()
+	case "copy_commit_message":
+		return m.handleCopyCommitMessage()
+	case "copy_file_path":
+		return m.handleCopyFilePath()
+	case "mark_commit_range":
+		return m, m.toggleCommitRange()
+	case "squash_message":
+		return m.generateRangeMessage()
 	case "toggle_graph":
 		m.logViewState.showGraph = !m.logViewState.showGraph
 		return m, nil
+	case "rebase_interactive":
+		return m.openRebasePlan()
+	case "reword_commit":
+		return m.rewordSelectedCommit()
+
+	// Rebase view actions
+	case "rebase_pick":
+		return m, m.setRebaseAction(git.RebasePick)
+	case "rebase_reword":
+		return m, m.setRebaseAction(git.RebaseReword)
+	case "rebase_squash":
+		return m, m.setRebaseAction(git.RebaseSquash)
+	case "rebase_fixup":
+		return m, m.setRebaseAction(git.RebaseFixup)
+	case "rebase_drop":
+		return m, m.setRebaseAction(git.RebaseDrop)
+	case "rebase_move_up":
+		return m, m.moveRebaseEntry(-1)
+	case "rebase_move_down":
+		return m, m.moveRebaseEntry(1)
+	case "rebase_edit_message":
+		return m, m.openRebaseMessagePrompt()
+	case "rebase_generate":
+		return m.generateRebaseMessages(false)
+	case "rebase_generate_all":
+		return m.generateRebaseMessages(true)
+	case "run_rebase":
+		return m.runRebasePlan()
+	case "cancel_rebase":
+		return m.cancelRebasePlan(), nil
+	// Commit detail view actions
+	case "show_file_diff":
+		return m.handleShowCommitFileDiff()
+	case "show_commit_diff":
+		return m.handleShowFullCommitDiff()
+	case "back_to_log":
+		return m.returnToLog(), nil
+
+	// Branch actions
+	case "checkout_branch":
+		return m, m.checkoutSelectedBranch()
+	case "create_branch":
+		return m, m.openCreateBranchPrompt()
+	case "rename_branch":
+		return m, m.openRenameBranchPrompt()
+	case "delete_branch":
+		return m.deleteSelectedBranch()
+	case "set_upstream":
+		return m, m.openSetUpstreamPrompt()
+	case "pr_description":
+		return m.generatePullRequest()
+
+	// Remote actions
+	case "push":
+		return m.pushCurrentBranch()
+	case "pull":
+		return m, m.pullCurrentBranch()
+	case "fetch":
+		return m, m.fetchRemote()
+
+	// Stash actions
+	case "stash_changes":
+		return m, m.openStashPrompt(false)
+	case "stash_staged":
+		return m, m.openStashPrompt(true)
+	case "show_stash_diff":
+		return m.showSelectedStashDiff()
+	case "apply_stash":
+		return m, m.applySelectedStash(false)
+	case "pop_stash":
+		return m, m.applySelectedStash(true)
+	case "drop_stash":
+		return m.dropSelectedStash()
+
+	case "filter_log":
+		return m, m.openLogFilterPrompt()
+	case "clear_log_filter":
+		if !m.logViewState.filter.IsFiltered() {
+			return m, nil
+		}
+		return m, m.applyLogFilter(git.CommitQuery{})
 
 	// Help view actions
 	case "return_to_status":
@@ -179,6 +324,15 @@ func (m *Model) handleNavUp() (tea.Model, tea.Cmd) {
 				m.logViewState.scrollOffset = m.cursor
 			}
 		}
+	case ViewModeCommitDetail, ViewModeBranches, ViewModeRebase:
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case ViewModeStash:
+		if m.cursor > 0 {
+			m.cursor--
+		}
+		return m, m.loadStashPreview()
 	}
 	return m, nil
 }
@@ -202,6 +356,24 @@ func (m *Model) handleNavDown() (tea.Model, tea.Cmd) {
 				m.logViewState.scrollOffset = m.cursor - maxVisible + 1
 			}
 		}
+		return m, m.loadMoreCommits()
+	case ViewModeCommitDetail:
+		if m.cursor < len(m.getCommitDetailFiles())-1 {
+			m.cursor++
+		}
+	case ViewModeBranches:
+		if m.cursor < len(m.branches)-1 {
+			m.cursor++
+		}
+	case ViewModeRebase:
+		if m.cursor < len(m.rebaseEntries())-1 {
+			m.cursor++
+		}
+	case ViewModeStash:
+		if m.cursor < len(m.stashes)-1 {
+			m.cursor++
+		}
+		return m, m.loadStashPreview()
 	}
 	return m, nil
 }
@@ -215,6 +387,11 @@ func (m *Model) handleNavHome() (tea.Model, tea.Cmd) {
 	case ViewModeLog:
 		m.cursor = 0
 		m.logViewState.scrollOffset = 0
+	case ViewModeCommitDetail, ViewModeBranches, ViewModeRebase:
+		m.cursor = 0
+	case ViewModeStash:
+		m.cursor = 0
+		return m, m.loadStashPreview()
 	}
 	return m, nil
 }
@@ -236,6 +413,16 @@ func (m *Model) handleNavEnd() (tea.Model, tea.Cmd) {
 				m.logViewState.scrollOffset = 0
 			}
 		}
+		return m, m.loadMoreCommits()
+	case ViewModeCommitDetail:
+		m.cursor = max(len(m.getCommitDetailFiles())-1, 0)
+	case ViewModeBranches:
+		m.cursor = max(len(m.branches)-1, 0)
+	case ViewModeRebase:
+		m.cursor = max(len(m.rebaseEntries())-1, 0)
+	case ViewModeStash:
+		m.cursor = max(len(m.stashes)-1, 0)
+		return m, m.loadStashPreview()
 	}
 	return m, nil
 }
@@ -261,6 +448,11 @@ func (m *Model) handleNavPageUp() (tea.Model, tea.Cmd) {
 			m.cursor = 0
 		}
 		m.logViewState.scrollOffset = m.cursor
+	case ViewModeCommitDetail, ViewModeBranches, ViewModeRebase:
+		m.cursor = max(m.cursor-m.getMaxVisibleLines()/2, 0)
+	case ViewModeStash:
+		m.cursor = max(m.cursor-m.getMaxVisibleLines()/2, 0)
+		return m, m.loadStashPreview()
 	}
 	return m, nil
 }
@@ -290,6 +482,16 @@ func (m *Model) handleNavPageDown() (tea.Model, tea.Cmd) {
 		if m.cursor >= m.logViewState.scrollOffset+maxVisible {
 			m.logViewState.scrollOffset = m.cursor - maxVisible + 1
 		}
+		return m, m.loadMoreCommits()
+	case ViewModeCommitDetail:
+		m.cursor = max(min(m.cursor+m.getMaxVisibleLines()/2, len(m.getCommitDetailFiles())-1), 0)
+	case ViewModeBranches:
+		m.cursor = max(min(m.cursor+m.getMaxVisibleLines()/2, len(m.branches)-1), 0)
+	case ViewModeRebase:
+		m.cursor = max(min(m.cursor+m.getMaxVisibleLines()/2, len(m.rebaseEntries())-1), 0)
+	case ViewModeStash:
+		m.cursor = max(min(m.cursor+m.getMaxVisibleLines()/2, len(m.stashes)-1), 0)
+		return m, m.loadStashPreview()
 	}
 	return m, nil
 }
@@ -315,20 +517,11 @@ func (m *Model) handleDiffNextFile() (tea.Model, tea.Cmd) {
 func (m *Model) handleShowCommitDetails() (tea.Model, tea.Cmd) {
 	if m.cursor < len(m.commitHistory) {
 		commit := m.commitHistory[m.cursor]
-		// Switch to diff view and show commit diff
-		m = m.switchView(ViewModeDiff)
-		return m, m.showCommitDiff(commit.Hash)
-	}
-	return m, nil
-}
-
-func (m *Model) handleCopyCommitHash() (tea.Model, tea.Cmd) {
-	if m.cursor < len(m.commitHistory) {
-		commit := m.commitHistory[m.cursor]
-		logger.LogUIAction("commit_hash_copied", map[string]interface{}{
-			"hash": commit.Hash,
-		})
-		m.statusMessage = "Commit hash copied: " + commit.ShortHash
+		// Remember the log position and open the commit detail view
+		m.logViewState.selectedCommit = m.cursor
+		m.commitDetails = nil
+		m = m.switchView(ViewModeCommitDetail)
+		return m, m.loadCommitDetails(commit.Hash)
 	}
 	return m, nil
 }